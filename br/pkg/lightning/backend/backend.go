@@ -114,6 +114,9 @@ type ExternalEngineConfig struct {
 	TotalFileSize int64
 	// TotalKVCount can be an estimated value.
 	TotalKVCount int64
+	// EncryptionKey is the master key the files are encrypted with, empty means
+	// the files are not encrypted.
+	EncryptionKey []byte
 }
 
 // CheckCtx contains all parameters used in CheckRequirements
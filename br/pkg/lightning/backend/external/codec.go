@@ -23,12 +23,15 @@ import (
 // - offset: the start offset of the range in the file.
 // - size: the size of the range.
 // - keys: the number of keys in the range.
+// - compressedSize: the size of the range in the compressed data file, it's 0
+// if the data file is not compressed.
 type rangeProperty struct {
-	firstKey []byte
-	lastKey  []byte
-	offset   uint64
-	size     uint64
-	keys     uint64
+	firstKey       []byte
+	lastKey        []byte
+	offset         uint64
+	size           uint64
+	keys           uint64
+	compressedSize uint64
 }
 
 // decodeMultiProps is only used for test.
@@ -60,6 +63,11 @@ func decodeProp(data []byte) *rangeProperty {
 	rp.keys = binary.BigEndian.Uint64(data[n : n+8])
 	n += 8
 	rp.offset = binary.BigEndian.Uint64(data[n : n+8])
+	n += 8
+	// compressedSize is only encoded for the compressed data file.
+	if len(data) >= n+8 {
+		rp.compressedSize = binary.BigEndian.Uint64(data[n : n+8])
+	}
 	return rp
 }
 
@@ -69,8 +77,11 @@ const propertyLengthExceptKeys = 4*2 + 8 + 8 + 8
 func encodeMultiProps(buf []byte, props []*rangeProperty) []byte {
 	var propLen [4]byte
 	for _, p := range props {
-		binary.BigEndian.PutUint32(propLen[:],
-			uint32(propertyLengthExceptKeys+len(p.firstKey)+len(p.lastKey)))
+		l := propertyLengthExceptKeys + len(p.firstKey) + len(p.lastKey)
+		if p.compressedSize > 0 {
+			l += 8
+		}
+		binary.BigEndian.PutUint32(propLen[:], uint32(l))
 		buf = append(buf, propLen[:4]...)
 		buf = encodeProp(buf, p)
 	}
@@ -91,5 +102,9 @@ func encodeProp(buf []byte, r *rangeProperty) []byte {
 	buf = append(buf, b[:]...)
 	binary.BigEndian.PutUint64(b[:], r.offset)
 	buf = append(buf, b[:]...)
+	if r.compressedSize > 0 {
+		binary.BigEndian.PutUint64(b[:], r.compressedSize)
+		buf = append(buf, b[:]...)
+	}
 	return buf
 }
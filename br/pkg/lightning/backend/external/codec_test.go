@@ -44,6 +44,13 @@ func TestRangePropertyCodec(t *testing.T) {
 	buf = encodeMultiProps(nil, []*rangeProperty{p1, p2, p3})
 	props := decodeMultiProps(buf)
 	require.EqualValues(t, []*rangeProperty{p1, p2, p3}, props)
+
+	// compressedSize is only encoded when it's not 0.
+	p2.compressedSize = 5
+	buf = encodeMultiProps(nil, []*rangeProperty{p1, p2, p3})
+	props = decodeMultiProps(buf)
+	require.EqualValues(t, []*rangeProperty{p1, p2, p3}, props)
+	require.Len(t, encodeProp(nil, p2), len(encodeProp(nil, p1))+8)
 }
 
 func TestPropertyLengthExceptKeys(t *testing.T) {
@@ -17,13 +17,25 @@ package external
 import (
 	"context"
 	"encoding/binary"
+	"strings"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/tidb/br/pkg/storage"
 )
 
 // we use uint64 to store the length of key and value.
 const lengthBytes = 8
 
+// compressedDataSuffix is the suffix of the data file compressed by zstd. Each
+// range property of the file is compressed as an independent frame, so the file
+// can be read from the offset of any range property.
+const compressedDataSuffix = ".zst"
+
+// isCompressedDataFile returns whether the data file is compressed.
+func isCompressedDataFile(name string) bool {
+	return strings.HasSuffix(name, compressedDataSuffix)
+}
+
 // KeyValueStore stores key-value pairs and maintains the range properties.
 type KeyValueStore struct {
 	dataWriter storage.ExternalFileWriter
@@ -31,6 +43,12 @@ type KeyValueStore struct {
 	rc     *rangePropertiesCollector
 	ctx    context.Context
 	offset uint64
+
+	// encoder is not nil if the data is compressed, the data of current range
+	// property is buffered in frameBuf and compressed when the property is done.
+	encoder     *zstd.Encoder
+	frameBuf    []byte
+	compressBuf []byte
 }
 
 // NewKeyValueStore creates a new KeyValueStore. The data will be written to the
@@ -49,15 +67,25 @@ func NewKeyValueStore(
 	return kvStore, nil
 }
 
+// enableCompression makes the KeyValueStore compress the data by the encoder.
+func (s *KeyValueStore) enableCompression(encoder *zstd.Encoder) {
+	s.encoder = encoder
+}
+
 // addEncodedData saves encoded key-value pairs to the KeyValueStore.
 // data layout: keyLen + key + valueLen + value. If the accumulated
 // size or key count exceeds the given distance, a new range property will be
 // appended to the rangePropertiesCollector with current status.
 // `key` must be in strictly ascending order for invocations of a KeyValueStore.
 func (s *KeyValueStore) addEncodedData(val []byte) error {
-	_, err := s.dataWriter.Write(s.ctx, val)
-	if err != nil {
-		return err
+	if s.encoder != nil {
+		s.frameBuf = append(s.frameBuf, val...)
+	} else {
+		_, err := s.dataWriter.Write(s.ctx, val)
+		if err != nil {
+			return err
+		}
+		s.offset += uint64(len(val))
 	}
 
 	keyLen := binary.BigEndian.Uint64(val)
@@ -67,12 +95,14 @@ func (s *KeyValueStore) addEncodedData(val []byte) error {
 	}
 	s.rc.currProp.lastKey = key
 
-	s.offset += uint64(len(val))
 	s.rc.currProp.size += uint64(len(val) - lengthBytes*2)
 	s.rc.currProp.keys++
 
 	if s.rc.currProp.size >= s.rc.propSizeDist ||
 		s.rc.currProp.keys >= s.rc.propKeysDist {
+		if err := s.flushFrame(); err != nil {
+			return err
+		}
 		newProp := *s.rc.currProp
 		s.rc.props = append(s.rc.props, &newProp)
 
@@ -80,17 +110,39 @@ func (s *KeyValueStore) addEncodedData(val []byte) error {
 		s.rc.currProp.offset = s.offset
 		s.rc.currProp.keys = 0
 		s.rc.currProp.size = 0
+		s.rc.currProp.compressedSize = 0
 	}
 
 	return nil
 }
 
+// flushFrame compresses the buffered data of current range property and writes
+// it to the data file.
+func (s *KeyValueStore) flushFrame() error {
+	if s.encoder == nil || len(s.frameBuf) == 0 {
+		return nil
+	}
+	s.compressBuf = s.encoder.EncodeAll(s.frameBuf, s.compressBuf[:0])
+	s.frameBuf = s.frameBuf[:0]
+	_, err := s.dataWriter.Write(s.ctx, s.compressBuf)
+	if err != nil {
+		return err
+	}
+	s.offset += uint64(len(s.compressBuf))
+	s.rc.currProp.compressedSize = uint64(len(s.compressBuf))
+	return nil
+}
+
 // Close closes the KeyValueStore and append the last range property.
-func (s *KeyValueStore) Close() {
+func (s *KeyValueStore) Close() error {
 	if s.rc.currProp.keys > 0 {
+		if err := s.flushFrame(); err != nil {
+			return err
+		}
 		newProp := *s.rc.currProp
 		s.rc.props = append(s.rc.props, &newProp)
 	}
+	return nil
 }
 
 const statSuffix = "_stat"
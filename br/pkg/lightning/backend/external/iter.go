@@ -324,6 +324,10 @@ func (p kvReaderProxy) next() (*kvPair, error) {
 }
 
 func (p kvReaderProxy) switchConcurrentMode(useConcurrent bool) error {
+	if isCompressedDataFile(p.p) {
+		// the compressed data file can't be read concurrently.
+		return nil
+	}
 	return p.r.byteReader.switchConcurrentMode(useConcurrent)
 }
 
@@ -362,6 +366,9 @@ func NewMergeKVIter(
 			if err != nil {
 				return nil, err
 			}
+			if isCompressedDataFile(paths[i]) {
+				return &kvReaderProxy{p: paths[i], r: rd}, nil
+			}
 			rd.byteReader.enableConcurrentRead(
 				exStorage,
 				paths[i],
@@ -19,6 +19,7 @@ import (
 	"encoding/binary"
 	"io"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -40,6 +41,13 @@ func newKVReader(
 	if err != nil {
 		return nil, err
 	}
+	if isCompressedDataFile(name) {
+		// initFileOffset is always the beginning of a compressed frame.
+		sr, err = newZstdFileReader(sr)
+		if err != nil {
+			return nil, err
+		}
+	}
 	br, err := newByteReader(ctx, sr, bufSize)
 	if err != nil {
 		return nil, err
@@ -49,6 +57,35 @@ func newKVReader(
 	}, nil
 }
 
+// zstdFileReader decompresses the data file written with compression. It can't
+// seek, so the concurrent reading is not used for the compressed data file.
+type zstdFileReader struct {
+	storage.ExternalFileReader
+	decoder *zstd.Decoder
+}
+
+func newZstdFileReader(r storage.ExternalFileReader) (*zstdFileReader, error) {
+	decoder, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1), zstd.WithDecoderLowmem(true))
+	if err != nil {
+		_ = r.Close()
+		return nil, errors.Trace(err)
+	}
+	return &zstdFileReader{ExternalFileReader: r, decoder: decoder}, nil
+}
+
+func (r *zstdFileReader) Read(p []byte) (int, error) {
+	return r.decoder.Read(p)
+}
+
+func (*zstdFileReader) Seek(int64, int) (int64, error) {
+	return 0, errors.New("seek is not supported for the compressed data file")
+}
+
+func (r *zstdFileReader) Close() error {
+	r.decoder.Close()
+	return r.ExternalFileReader.Close()
+}
+
 func (r *kvReader) nextKV() (key, val []byte, err error) {
 	r.byteReader.reset()
 	lenBytes, err := r.byteReader.readNBytes(8)
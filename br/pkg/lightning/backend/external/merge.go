@@ -21,6 +21,7 @@ func MergeOverlappingFiles(
 	propSizeDist uint64,
 	propKeysDist uint64,
 	onClose OnCloseFunc,
+	compressData bool,
 ) error {
 	zeroOffsets := make([]uint64, len(paths))
 	iter, err := NewMergeKVIter(ctx, paths, zeroOffsets, store, readBufferSize)
@@ -36,6 +37,7 @@ func MergeOverlappingFiles(
 		SetPropKeysDistance(propKeysDist).
 		SetPropSizeDistance(propSizeDist).
 		SetOnCloseFunc(onClose).
+		SetDataCompression(compressData).
 		Build(store, newFilePrefix, writerID)
 
 	// currently use same goroutine to do read and write. The main advantage is
@@ -27,6 +27,7 @@ import (
 
 	"github.com/docker/go-units"
 	"github.com/jfcg/sorty/v2"
+	"github.com/klauspost/compress/zstd"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/encode"
@@ -106,6 +107,7 @@ type WriterBuilder struct {
 	propKeysDist    uint64
 	onClose         OnCloseFunc
 	keyDupeEncoding bool
+	compressData    bool
 	// This mutex is used to make sure the writer is flushed mutually exclusively in a TiDB server.
 	mu *sync.Mutex
 
@@ -171,6 +173,12 @@ func (b *WriterBuilder) SetKeyDuplicationEncoding(val bool) *WriterBuilder {
 	return b
 }
 
+// SetDataCompression sets if the writer compresses the data files by zstd.
+func (b *WriterBuilder) SetDataCompression(val bool) *WriterBuilder {
+	b.compressData = val
+	return b
+}
+
 // SetMutex sets the mutex of the writer.
 func (b *WriterBuilder) SetMutex(mu *sync.Mutex) *WriterBuilder {
 	b.mu = mu
@@ -222,6 +230,11 @@ func (b *WriterBuilder) Build(
 		shareMu:        b.mu,
 	}
 	ret.multiFileStats[0].Filenames = make([][2]string, 0, multiFileStatNum)
+	if b.compressData {
+		// only EncodeAll is used, so the concurrency of the encoder is useless.
+		// NewWriter never fails with these options.
+		ret.encoder, _ = zstd.NewWriter(nil, zstd.WithEncoderConcurrency(1))
+	}
 	return ret
 }
 
@@ -290,6 +303,8 @@ type Writer struct {
 
 	kvStore *KeyValueStore
 	rc      *rangePropertiesCollector
+	// encoder is used to compress the data files, nil means no compression.
+	encoder *zstd.Encoder
 
 	memSizeLimit uint64
 
@@ -366,6 +381,9 @@ func (w *Writer) Close(ctx context.Context) error {
 	}
 	w.closed = true
 	defer w.kvBuffer.destroy()
+	if w.encoder != nil {
+		defer w.encoder.Close()
+	}
 	err := w.flushKVs(ctx, true)
 	if err != nil {
 		return err
@@ -419,6 +437,7 @@ func (w *Writer) flushKVs(ctx context.Context, fromClose bool) (err error) {
 
 	var (
 		savedBytes                  uint64
+		dataFileSize                uint64
 		statSize                    int
 		sortDuration, writeDuration time.Duration
 		writeStartTime              time.Time
@@ -455,6 +474,7 @@ func (w *Writer) flushKVs(ctx context.Context, fromClose bool) (err error) {
 		writeDuration = time.Since(writeStartTime)
 		logger.Info("flush kv",
 			zap.Uint64("bytes", savedBytes),
+			zap.Uint64("data-file-size", dataFileSize),
 			zap.Int("kv-cnt", kvCnt),
 			zap.Int("stat-size", statSize),
 			zap.Duration("sort-time", sortDuration),
@@ -496,6 +516,9 @@ func (w *Writer) flushKVs(ctx context.Context, fromClose bool) (err error) {
 	if err != nil {
 		return err
 	}
+	if w.encoder != nil {
+		w.kvStore.enableCompression(w.encoder)
+	}
 
 	for _, pair := range w.kvLocations {
 		err = w.kvStore.addEncodedData(w.getEncodedKVData(pair))
@@ -504,7 +527,10 @@ func (w *Writer) flushKVs(ctx context.Context, fromClose bool) (err error) {
 		}
 	}
 
-	w.kvStore.Close()
+	if err = w.kvStore.Close(); err != nil {
+		return err
+	}
+	dataFileSize = w.kvStore.offset
 	encodedStat := w.rc.encode()
 	statSize = len(encodedStat)
 	_, err = statWriter.Write(ctx, encodedStat)
@@ -557,6 +583,9 @@ func (w *Writer) createStorageWriter(ctx context.Context) (
 	err error,
 ) {
 	dataPath := filepath.Join(w.filenamePrefix, strconv.Itoa(w.currentSeq))
+	if w.encoder != nil {
+		dataPath += compressedDataSuffix
+	}
 	dataWriter, err := w.store.Create(ctx, dataPath, &storage.WriterOption{Concurrency: 20})
 	if err != nil {
 		return "", "", nil, nil, err
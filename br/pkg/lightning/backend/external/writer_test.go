@@ -101,6 +101,96 @@ func TestWriter(t *testing.T) {
 	require.NoError(t, statReader.Close())
 }
 
+func TestWriterWithCompression(t *testing.T) {
+	ctx := context.Background()
+	memStore := storage.NewMemStorage()
+
+	w := NewWriterBuilder().
+		SetPropSizeDistance(1000).
+		SetPropKeysDistance(2).
+		SetDataCompression(true).
+		Build(memStore, "/test", "0")
+
+	kvCnt := 100
+	keys := make([][]byte, 0, kvCnt)
+	values := make([][]byte, 0, kvCnt)
+	for i := 0; i < kvCnt; i++ {
+		keys = append(keys, []byte(fmt.Sprintf("key%03d", i)))
+		values = append(values, bytes.Repeat([]byte("value"), 20))
+	}
+	dataFiles, statFiles, err := MockExternalEngineWithWriter(memStore, w, "/test", keys, values)
+	require.NoError(t, err)
+	require.Equal(t, []string{"/test/0/0" + compressedDataSuffix}, dataFiles)
+	data, err := memStore.ReadFile(ctx, dataFiles[0])
+	require.NoError(t, err)
+	require.Less(t, len(data), kvCnt*(len(keys[0])+len(values[0])))
+
+	statReader, err := newStatsReader(ctx, memStore, statFiles[0], 100)
+	require.NoError(t, err)
+	props := make([]*rangeProperty, 0, kvCnt/2)
+	for {
+		p, err := statReader.nextProp()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+		require.Greater(t, p.compressedSize, uint64(0))
+		props = append(props, p)
+	}
+	require.NoError(t, statReader.Close())
+	require.Len(t, props, kvCnt/2)
+	last := props[len(props)-1]
+	require.EqualValues(t, len(data), last.offset+last.compressedSize)
+
+	// each range property can be read from its offset.
+	for i, p := range props {
+		kvReader, err := newKVReader(ctx, dataFiles[0], memStore, p.offset, 64)
+		require.NoError(t, err)
+		for j := i * 2; j < kvCnt; j++ {
+			key, value, err := kvReader.nextKV()
+			require.NoError(t, err)
+			require.Equal(t, keys[j], key)
+			require.Equal(t, values[j], value)
+		}
+		_, _, err = kvReader.nextKV()
+		require.Equal(t, io.EOF, err)
+		require.NoError(t, kvReader.Close())
+	}
+
+	// merge the compressed file into a compressed file.
+	var summary *WriterSummary
+	err = MergeOverlappingFiles(
+		ctx,
+		dataFiles,
+		memStore,
+		100,
+		"/test2",
+		"mergeID",
+		1000,
+		1000,
+		8*1024,
+		1*size.MB,
+		2,
+		func(s *WriterSummary) { summary = s },
+		true,
+	)
+	require.NoError(t, err)
+	require.EqualValues(t, kvCnt*(len(keys[0])+len(values[0])), summary.TotalSize)
+	mergedFiles, _, err := GetAllFileNames(ctx, memStore, "/test2")
+	require.NoError(t, err)
+	iter, err := NewMergeKVIter(ctx, mergedFiles, make([]uint64, len(mergedFiles)), memStore, 100)
+	require.NoError(t, err)
+	i := 0
+	for iter.Next() {
+		require.Equal(t, keys[i], iter.Key())
+		require.Equal(t, values[i], iter.Value())
+		i++
+	}
+	require.NoError(t, iter.Error())
+	require.Equal(t, kvCnt, i)
+	require.NoError(t, iter.Close())
+}
+
 func TestWriterFlushMultiFileNames(t *testing.T) {
 	seed := time.Now().Unix()
 	rand.Seed(uint64(seed))
@@ -188,6 +278,7 @@ func TestWriterDuplicateDetect(t *testing.T) {
 		1*size.MB,
 		2,
 		nil,
+		false,
 	)
 	require.NoError(t, err)
 
@@ -230,6 +321,12 @@ func TestWriterDuplicateDetect(t *testing.T) {
 	err = iter.Error()
 	require.Error(t, err)
 	require.Contains(t, err.Error(), "found duplicate key")
+	require.True(t, common.ErrFoundDuplicateKeys.Equal(err))
+	var dupErr *common.DuplicateKeyError
+	require.ErrorAs(t, err, &dupErr)
+	dupKey := []byte{byte(kvCount/2 - 1)}
+	require.Equal(t, dupKey, dupErr.Key)
+	require.Equal(t, [][]byte{dupKey, dupKey}, dupErr.Values)
 }
 
 func TestMultiFileStat(t *testing.T) {
@@ -385,6 +482,7 @@ func TestWriterMultiFileStat(t *testing.T) {
 		1*size.MB,
 		2,
 		closeFn,
+		false,
 	)
 	require.NoError(t, err)
 	require.Equal(t, 3, len(summary.MultipleFilesStats))
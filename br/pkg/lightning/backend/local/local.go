@@ -406,6 +406,10 @@ type BackendConfig struct {
 	DuplicateDetectOpt common.DupDetectOpt
 	// max write speed in bytes per second to each store(burst is allowed), 0 means no limit
 	StoreWriteBWLimit int
+	// GetNodeWriteBWLimit returns the max write speed in bytes per second of this node to all the
+	// stores, 0 means no limit. It's checked on every write, so the limit can be changed while
+	// importing. nil means no limit.
+	GetNodeWriteBWLimit func() int
 	// When TiKV is in normal mode, ingesting too many SSTs will cause TiKV write stall.
 	// To avoid this, we should check write stall before ingesting SSTs. Note that, we
 	// must check both leader node and followers in client side, because followers will
@@ -482,7 +486,9 @@ type Backend struct {
 	bufferPool   *membuf.Pool
 	metrics      *metric.Common
 	writeLimiter StoreWriteLimiter
-	logger       log.Logger
+	// nodeWriteLimiter is nil if GetNodeWriteBWLimit is not set.
+	nodeWriteLimiter *nodeWriteLimiter
+	logger           log.Logger
 	// This mutex is used to do some mutual exclusion work in the backend, flushKVs() in writer for now.
 	mu sync.Mutex
 }
@@ -586,6 +592,10 @@ func NewBackend(
 	} else {
 		writeLimiter = noopStoreWriteLimiter{}
 	}
+	var nodeLimiter *nodeWriteLimiter
+	if config.GetNodeWriteBWLimit != nil {
+		nodeLimiter = newNodeWriteLimiter(config.GetNodeWriteBWLimit)
+	}
 	alloc := manual.Allocator{}
 	if RunInTest {
 		alloc.RefCnt = new(atomic.Int64)
@@ -608,6 +618,7 @@ func NewBackend(
 		importClientFactory: importClientFactory,
 		bufferPool:          membuf.NewPool(membuf.WithAllocator(alloc)),
 		writeLimiter:        writeLimiter,
+		nodeWriteLimiter:    nodeLimiter,
 		logger:              log.FromContext(ctx),
 	}
 	if m, ok := metric.GetCommonMetric(ctx); ok {
@@ -952,6 +963,7 @@ func (local *Backend) CloseEngine(ctx context.Context, cfg *backend.EngineConfig
 		if err != nil {
 			return err
 		}
+		store = storage.WithEncryption(store, externalCfg.EncryptionKey)
 		physical, logical, err := local.pdCtl.GetPDClient().GetTS(ctx)
 		if err != nil {
 			return err
@@ -650,23 +650,28 @@ type storeWriteLimiter struct {
 }
 
 func newStoreWriteLimiter(limit int) *storeWriteLimiter {
-	var burst int
-	// Allow burst of at most 20% of the limit.
-	if limit <= math.MaxInt-limit/5 {
-		burst = limit + limit/5
-	} else {
-		// If overflowed, set burst to math.MaxInt.
-		burst = math.MaxInt
-	}
 	return &storeWriteLimiter{
 		limiters: make(map[uint64]*rate.Limiter),
 		limit:    limit,
-		burst:    burst,
+		burst:    writeLimitBurst(limit),
 	}
 }
 
+// writeLimitBurst returns the burst of the write limiter.
+func writeLimitBurst(limit int) int {
+	// Allow burst of at most 20% of the limit.
+	if limit <= math.MaxInt-limit/5 {
+		return limit + limit/5
+	}
+	// If overflowed, set burst to math.MaxInt.
+	return math.MaxInt
+}
+
 func (s *storeWriteLimiter) WaitN(ctx context.Context, storeID uint64, n int) error {
-	limiter := s.getLimiter(storeID)
+	return waitNWithBurst(ctx, s.getLimiter(storeID), n)
+}
+
+func waitNWithBurst(ctx context.Context, limiter *rate.Limiter, n int) error {
 	// The original WaitN doesn't allow n > burst,
 	// so we call WaitN with burst multiple times.
 	for n > limiter.Burst() {
@@ -709,6 +714,48 @@ func (noopStoreWriteLimiter) Limit() int {
 	return math.MaxInt
 }
 
+// nodeWriteLimiter limits the total write speed of this node to all the stores. The limit
+// is refreshed on every write, so it can be changed while the engine is being imported.
+type nodeWriteLimiter struct {
+	getLimit func() int
+
+	mu      sync.Mutex
+	limit   int
+	limiter *rate.Limiter
+}
+
+func newNodeWriteLimiter(getLimit func() int) *nodeWriteLimiter {
+	return &nodeWriteLimiter{
+		getLimit: getLimit,
+		limiter:  rate.NewLimiter(rate.Inf, 0),
+	}
+}
+
+func (l *nodeWriteLimiter) refresh() {
+	limit := l.getLimit()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit == l.limit {
+		return
+	}
+	l.limit = limit
+	if limit <= 0 {
+		l.limiter.SetLimit(rate.Inf)
+		return
+	}
+	l.limiter.SetLimit(rate.Limit(limit))
+	l.limiter.SetBurst(writeLimitBurst(limit))
+}
+
+// WaitN blocks until n bytes can be written.
+func (l *nodeWriteLimiter) WaitN(ctx context.Context, n int) error {
+	l.refresh()
+	if l.limiter.Limit() == rate.Inf {
+		return nil
+	}
+	return waitNWithBurst(ctx, l.limiter, n)
+}
+
 // compaction threshold
 const (
 	CompactionLowerThreshold = 512 * units.MiB
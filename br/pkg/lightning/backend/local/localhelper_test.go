@@ -947,6 +947,30 @@ func TestStoreWriteLimiter(t *testing.T) {
 	wg.Wait()
 }
 
+func TestNodeWriteLimiter(t *testing.T) {
+	var limit atomic.Int64
+	limiter := newNodeWriteLimiter(func() int {
+		return int(limit.Load())
+	})
+	ctx := context.Background()
+	// No limit.
+	start := time.Now()
+	require.NoError(t, limiter.WaitN(ctx, 1<<30))
+	require.Less(t, time.Since(start), time.Second)
+
+	// 120 is the initial burst, the other 120 tokens need more than 1 second.
+	limit.Store(100)
+	start = time.Now()
+	require.NoError(t, limiter.WaitN(ctx, 120+120))
+	require.Greater(t, time.Since(start), time.Second)
+
+	// The limit is removed while writing.
+	limit.Store(0)
+	start = time.Now()
+	require.NoError(t, limiter.WaitN(ctx, 1<<30))
+	require.Less(t, time.Since(start), time.Second)
+}
+
 type scatterRegionCli struct {
 	split.SplitClient
 
@@ -205,6 +205,7 @@ func (local *Backend) writeToTiKV(ctx context.Context, j *regionJob) error {
 	kvBatchSize := local.KVWriteBatchSize
 	bufferPool := local.bufferPool
 	writeLimiter := local.writeLimiter
+	nodeWriteLimiter := local.nodeWriteLimiter
 
 	begin := time.Now()
 	region := j.region.Region
@@ -304,6 +305,12 @@ func (local *Backend) writeToTiKV(ctx context.Context, j *regionJob) error {
 			return err
 		}
 
+		if nodeWriteLimiter != nil {
+			// The data is sent to all the peers, which all count for the bandwidth of this node.
+			if err := nodeWriteLimiter.WaitN(ctx, int(size)*len(clients)); err != nil {
+				return errors.Trace(err)
+			}
+		}
 		for i := range clients {
 			if err := writeLimiter.WaitN(ctx, allPeers[i].StoreId, int(size)); err != nil {
 				return errors.Trace(err)
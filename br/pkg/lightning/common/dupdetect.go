@@ -16,6 +16,7 @@ package common
 
 import (
 	"bytes"
+	"slices"
 
 	"github.com/cockroachdb/pebble"
 	"github.com/pingcap/tidb/br/pkg/lightning/log"
@@ -94,11 +95,8 @@ func (d *DupDetector) Next(iter KVIter) (key []byte, value []byte, ok bool, err
 			return d.curKey, d.curVal, true, nil
 		}
 		if d.option.ReportErrOnDup {
-			dupKey := make([]byte, len(d.curKey))
-			dupVal := make([]byte, len(val))
-			copy(dupKey, d.curKey)
-			copy(dupVal, d.curVal)
-			return nil, nil, false, ErrFoundDuplicateKeys.FastGenByArgs(dupKey, dupVal)
+			return nil, nil, false, NewDuplicateKeyError(
+				slices.Clone(d.curKey), slices.Clone(d.curVal), slices.Clone(val))
 		}
 		if !recordFirst {
 			if err = d.record(d.curRawKey, d.curKey, d.curVal); err != nil {
@@ -149,3 +147,38 @@ func (d *DupDetector) Close() error {
 type DupDetectOpt struct {
 	ReportErrOnDup bool
 }
+
+// DuplicateKeyError is returned by DupDetector when it meets the duplicate keys and
+// ReportErrOnDup is set. Its cause is ErrFoundDuplicateKeys, and it carries the
+// conflicting values of the key, so the caller can find the conflicting rows.
+type DuplicateKeyError struct {
+	err error
+	// Key is the decoded duplicate key.
+	Key []byte
+	// Values are the conflicting values of the key.
+	Values [][]byte
+}
+
+// NewDuplicateKeyError creates a DuplicateKeyError of the key and its conflicting values.
+func NewDuplicateKeyError(key []byte, values ...[]byte) *DuplicateKeyError {
+	return &DuplicateKeyError{
+		err:    ErrFoundDuplicateKeys.FastGenByArgs(key, values[0]),
+		Key:    key,
+		Values: values,
+	}
+}
+
+// Error implements the error interface.
+func (e *DuplicateKeyError) Error() string {
+	return e.err.Error()
+}
+
+// Cause returns the ErrFoundDuplicateKeys error.
+func (e *DuplicateKeyError) Cause() error {
+	return e.err
+}
+
+// Unwrap returns the ErrFoundDuplicateKeys error.
+func (e *DuplicateKeyError) Unwrap() error {
+	return e.err
+}
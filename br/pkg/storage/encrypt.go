@@ -0,0 +1,400 @@
+// Copyright 2023 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/log"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
+	"github.com/pingcap/tidb/br/pkg/logutil"
+)
+
+// The layout of an encrypted file is:
+//
+//	magic | salt | chunk 0 | chunk 1 | ... | chunk N
+//
+// The data key of the file is derived from the master key and the random salt,
+// each chunk is at most encryptChunkSize bytes of plaintext sealed by AES-GCM,
+// whose nonce is the index of the chunk. The last chunk always has less than
+// encryptChunkSize bytes of plaintext (maybe 0), and it's sealed with a different
+// additional data, so a truncated file can be detected.
+const (
+	encryptMagic     = "TEF1"
+	encryptSaltSize  = 32
+	encryptHeaderLen = int64(len(encryptMagic) + encryptSaltSize)
+	encryptChunkSize = 64 * 1024
+	encryptTagSize   = 16
+	sealedChunkSize  = encryptChunkSize + encryptTagSize
+)
+
+var (
+	encryptMiddleChunkAD = []byte{0}
+	encryptLastChunkAD   = []byte{1}
+)
+
+type withEncryption struct {
+	ExternalStorage
+	masterKey []byte
+}
+
+// WithEncryption returns an ExternalStorage which encrypts the files it writes
+// and decrypts the files it reads with the 256-bit master key. The offsets and
+// sizes of the readers are the ones of the plaintext. If the key is empty, the
+// inner storage is returned.
+func WithEncryption(inner ExternalStorage, masterKey []byte) ExternalStorage {
+	if len(masterKey) == 0 {
+		return inner
+	}
+	return &withEncryption{
+		ExternalStorage: inner,
+		masterKey:       masterKey,
+	}
+}
+
+func (w *withEncryption) newAEAD(salt []byte) (cipher.AEAD, error) {
+	mac := hmac.New(sha256.New, w.masterKey)
+	mac.Write(salt)
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	return aead, errors.Trace(err)
+}
+
+func (w *withEncryption) newHeader() (header []byte, aead cipher.AEAD, err error) {
+	header = make([]byte, encryptHeaderLen)
+	copy(header, encryptMagic)
+	if _, err = rand.Read(header[len(encryptMagic):]); err != nil {
+		return nil, nil, errors.Trace(err)
+	}
+	aead, err = w.newAEAD(header[len(encryptMagic):])
+	return header, aead, err
+}
+
+func (w *withEncryption) parseHeader(name string, header []byte) (cipher.AEAD, error) {
+	if int64(len(header)) < encryptHeaderLen || string(header[:len(encryptMagic)]) != encryptMagic {
+		return nil, errors.Annotatef(berrors.ErrStorageUnknown, "file %s is not encrypted", name)
+	}
+	return w.newAEAD(header[len(encryptMagic):encryptHeaderLen])
+}
+
+func chunkNonce(aead cipher.AEAD, idx int64) []byte {
+	nonce := make([]byte, aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], uint64(idx))
+	return nonce
+}
+
+// sealedOffset returns the offset of the idx-th chunk in the encrypted file.
+func sealedOffset(idx int64) int64 {
+	return encryptHeaderLen + idx*sealedChunkSize
+}
+
+// plainSize returns the size of the plaintext of an encrypted file.
+func plainSize(fileSize int64) int64 {
+	n := fileSize - encryptHeaderLen
+	if n < encryptTagSize {
+		return 0
+	}
+	return n - (n/sealedChunkSize+1)*encryptTagSize
+}
+
+func (w *withEncryption) Create(ctx context.Context, name string, o *WriterOption) (ExternalFileWriter, error) {
+	header, aead, err := w.newHeader()
+	if err != nil {
+		return nil, err
+	}
+	writer, err := w.ExternalStorage.Create(ctx, name, o)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	if _, err = writer.Write(ctx, header); err != nil {
+		_ = writer.Close(ctx)
+		return nil, errors.Trace(err)
+	}
+	return &encryptedFileWriter{
+		inner:  writer,
+		aead:   aead,
+		buf:    make([]byte, 0, encryptChunkSize),
+		sealed: make([]byte, 0, sealedChunkSize),
+	}, nil
+}
+
+func (w *withEncryption) Open(ctx context.Context, path string, o *ReaderOption) (ExternalFileReader, error) {
+	r := &encryptedFileReader{
+		ctx:   ctx,
+		store: w,
+		name:  path,
+		end:   -1,
+	}
+	if o != nil {
+		if o.StartOffset != nil {
+			r.pos = *o.StartOffset
+		}
+		if o.EndOffset != nil {
+			r.end = *o.EndOffset
+		}
+	}
+	startIdx := r.pos / encryptChunkSize
+	if startIdx == 0 {
+		// read the header from the same stream as the first chunk.
+		inner, err := w.ExternalStorage.Open(ctx, path, nil)
+		if err != nil {
+			return nil, errors.Trace(err)
+		}
+		header := make([]byte, encryptHeaderLen)
+		if _, err = io.ReadFull(inner, header); err != nil {
+			_ = inner.Close()
+			return nil, errors.Annotatef(err, "read header of %s", path)
+		}
+		if r.aead, err = w.parseHeader(path, header); err != nil {
+			_ = inner.Close()
+			return nil, err
+		}
+		r.inner = inner
+		return r, nil
+	}
+	header := make([]byte, encryptHeaderLen)
+	if _, err := ReadDataInRange(ctx, w.ExternalStorage, path, 0, header); err != nil {
+		return nil, errors.Annotatef(err, "read header of %s", path)
+	}
+	aead, err := w.parseHeader(path, header)
+	if err != nil {
+		return nil, err
+	}
+	r.aead = aead
+	if err = r.reopen(startIdx); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (w *withEncryption) WriteFile(ctx context.Context, name string, data []byte) error {
+	header, aead, err := w.newHeader()
+	if err != nil {
+		return err
+	}
+	chunkCnt := int64(len(data))/encryptChunkSize + 1
+	buf := make([]byte, 0, int64(len(data))+encryptHeaderLen+chunkCnt*encryptTagSize)
+	buf = append(buf, header...)
+	for idx := int64(0); idx < chunkCnt; idx++ {
+		ad := encryptMiddleChunkAD
+		chunk := data[idx*encryptChunkSize:]
+		if idx == chunkCnt-1 {
+			ad = encryptLastChunkAD
+		} else {
+			chunk = chunk[:encryptChunkSize]
+		}
+		buf = aead.Seal(buf, chunkNonce(aead, idx), chunk, ad)
+	}
+	return w.ExternalStorage.WriteFile(ctx, name, buf)
+}
+
+func (w *withEncryption) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	data, err := w.ExternalStorage.ReadFile(ctx, name)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	aead, err := w.parseHeader(name, data)
+	if err != nil {
+		return nil, err
+	}
+	data = data[encryptHeaderLen:]
+	plain := make([]byte, 0, plainSize(int64(len(data))+encryptHeaderLen))
+	for idx := int64(0); ; idx++ {
+		chunk, ad, last := data, encryptLastChunkAD, true
+		if len(chunk) >= sealedChunkSize {
+			chunk, ad, last = chunk[:sealedChunkSize], encryptMiddleChunkAD, false
+		}
+		plain, err = aead.Open(plain, chunkNonce(aead, idx), chunk, ad)
+		if err != nil {
+			return nil, errors.Annotatef(err, "decrypt chunk %d of %s", idx, name)
+		}
+		if last {
+			return plain, nil
+		}
+		data = data[len(chunk):]
+	}
+}
+
+type encryptedFileWriter struct {
+	inner    ExternalFileWriter
+	aead     cipher.AEAD
+	chunkIdx int64
+	buf      []byte
+	sealed   []byte
+}
+
+func (w *encryptedFileWriter) Write(ctx context.Context, p []byte) (int, error) {
+	written := 0
+	for len(p) > 0 {
+		n := copy(w.buf[len(w.buf):cap(w.buf)], p)
+		w.buf = w.buf[:len(w.buf)+n]
+		p = p[n:]
+		written += n
+		if len(w.buf) == encryptChunkSize {
+			if err := w.flush(ctx, encryptMiddleChunkAD); err != nil {
+				return written, err
+			}
+		}
+	}
+	return written, nil
+}
+
+func (w *encryptedFileWriter) flush(ctx context.Context, ad []byte) error {
+	w.sealed = w.aead.Seal(w.sealed[:0], chunkNonce(w.aead, w.chunkIdx), w.buf, ad)
+	w.chunkIdx++
+	w.buf = w.buf[:0]
+	_, err := w.inner.Write(ctx, w.sealed)
+	return errors.Trace(err)
+}
+
+func (w *encryptedFileWriter) Close(ctx context.Context) error {
+	if err := w.flush(ctx, encryptLastChunkAD); err != nil {
+		_ = w.inner.Close(ctx)
+		return err
+	}
+	return errors.Trace(w.inner.Close(ctx))
+}
+
+type encryptedFileReader struct {
+	ctx   context.Context
+	store *withEncryption
+	name  string
+	aead  cipher.AEAD
+
+	// inner is positioned at the beginning of the nextIdx-th chunk.
+	inner   ExternalFileReader
+	nextIdx int64
+
+	// chunk is the plaintext of the (nextIdx-1)-th chunk.
+	chunk []byte
+
+	// pos is the offset of the plaintext to read, end is the exclusive end offset
+	// of the plaintext to read, -1 means to the end of the file.
+	pos    int64
+	end    int64
+	sealed []byte
+}
+
+func (r *encryptedFileReader) reopen(idx int64) error {
+	if r.inner != nil {
+		if err := r.inner.Close(); err != nil {
+			log.Warn("failed to close reader", logutil.ShortError(err))
+		}
+		r.inner = nil
+	}
+	offset := sealedOffset(idx)
+	inner, err := r.store.ExternalStorage.Open(r.ctx, r.name, &ReaderOption{StartOffset: &offset})
+	if err != nil {
+		return errors.Trace(err)
+	}
+	r.inner = inner
+	r.nextIdx = idx
+	r.chunk = r.chunk[:0]
+	return nil
+}
+
+// loadChunk makes the chunk at idx the current chunk.
+func (r *encryptedFileReader) loadChunk(idx int64) error {
+	if idx != r.nextIdx || r.inner == nil {
+		if err := r.reopen(idx); err != nil {
+			return err
+		}
+	}
+	if r.sealed == nil {
+		r.sealed = make([]byte, sealedChunkSize)
+	}
+	n, err := io.ReadFull(r.inner, r.sealed)
+	ad := encryptMiddleChunkAD
+	switch {
+	case err == io.ErrUnexpectedEOF:
+		// only the last chunk is shorter than sealedChunkSize.
+		ad = encryptLastChunkAD
+	case err == io.EOF:
+		return errors.Annotatef(berrors.ErrStorageUnknown, "encrypted file %s is truncated", r.name)
+	case err != nil:
+		return errors.Trace(err)
+	}
+	r.chunk, err = r.aead.Open(r.chunk[:0], chunkNonce(r.aead, idx), r.sealed[:n], ad)
+	if err != nil {
+		return errors.Annotatef(err, "decrypt chunk %d of %s", idx, r.name)
+	}
+	r.nextIdx = idx + 1
+	return nil
+}
+
+func (r *encryptedFileReader) Read(p []byte) (int, error) {
+	if r.end >= 0 && r.pos >= r.end {
+		return 0, io.EOF
+	}
+	if r.end >= 0 && int64(len(p)) > r.end-r.pos {
+		p = p[:r.end-r.pos]
+	}
+	idx := r.pos / encryptChunkSize
+	if idx != r.nextIdx-1 {
+		if err := r.loadChunk(idx); err != nil {
+			return 0, err
+		}
+	}
+	offsetInChunk := int(r.pos - idx*encryptChunkSize)
+	if offsetInChunk >= len(r.chunk) {
+		// only the last chunk can be shorter than encryptChunkSize.
+		return 0, io.EOF
+	}
+	n := copy(p, r.chunk[offsetInChunk:])
+	r.pos += int64(n)
+	return n, nil
+}
+
+func (r *encryptedFileReader) Seek(offset int64, whence int) (int64, error) {
+	var realOffset int64
+	switch whence {
+	case io.SeekStart:
+		realOffset = offset
+	case io.SeekCurrent:
+		realOffset = r.pos + offset
+	case io.SeekEnd:
+		size, err := r.GetFileSize()
+		if err != nil {
+			return 0, err
+		}
+		realOffset = size + offset
+	default:
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek: invalid whence '%d'", whence)
+	}
+	if realOffset < 0 {
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "Seek in '%s': invalid offset to seek '%d'.", r.name, realOffset)
+	}
+	r.pos = realOffset
+	return realOffset, nil
+}
+
+func (r *encryptedFileReader) GetFileSize() (int64, error) {
+	if r.inner == nil {
+		return 0, errors.Annotatef(berrors.ErrStorageUnknown, "reader of %s is closed", r.name)
+	}
+	size, err := r.inner.GetFileSize()
+	if err != nil {
+		return 0, errors.Trace(err)
+	}
+	return plainSize(size), nil
+}
+
+func (r *encryptedFileReader) Close() error {
+	if r.inner == nil {
+		return nil
+	}
+	err := r.inner.Close()
+	r.inner = nil
+	return errors.Trace(err)
+}
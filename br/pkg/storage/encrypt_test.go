@@ -0,0 +1,123 @@
+// Copyright 2023 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"fmt"
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithEncryption(t *testing.T) {
+	ctx := context.Background()
+	key := bytes.Repeat([]byte{1}, 32)
+	local, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	for _, inner := range []ExternalStorage{NewMemStorage(), local} {
+		store := WithEncryption(inner, key)
+		for _, size := range []int{0, 1, encryptChunkSize - 1, encryptChunkSize, 3*encryptChunkSize + 100} {
+			data := make([]byte, size)
+			_, err := rand.Read(data)
+			require.NoError(t, err)
+
+			require.NoError(t, store.WriteFile(ctx, "/whole", data))
+			got, err := store.ReadFile(ctx, "/whole")
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+			raw, err := inner.ReadFile(ctx, "/whole")
+			require.NoError(t, err)
+			require.Equal(t, int64(size), plainSize(int64(len(raw))))
+			if size > 0 {
+				require.False(t, bytes.Contains(raw, data))
+			}
+
+			name := fmt.Sprintf("/stream-%d", size)
+			w, err := store.Create(ctx, name, nil)
+			require.NoError(t, err)
+			for i := 0; i < size; i += 1000 {
+				_, err = w.Write(ctx, data[i:min(i+1000, size)])
+				require.NoError(t, err)
+			}
+			require.NoError(t, w.Close(ctx))
+			got, err = store.ReadFile(ctx, name)
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+
+			r, err := store.Open(ctx, name, nil)
+			require.NoError(t, err)
+			fileSize, err := r.GetFileSize()
+			require.NoError(t, err)
+			require.Equal(t, int64(size), fileSize)
+			got, err = io.ReadAll(r)
+			require.NoError(t, err)
+			require.Equal(t, data, got)
+			if size > 10 {
+				_, err = r.Seek(-10, io.SeekEnd)
+				require.NoError(t, err)
+				got, err = io.ReadAll(r)
+				require.NoError(t, err)
+				require.Equal(t, data[size-10:], got)
+				_, err = r.Seek(1, io.SeekStart)
+				require.NoError(t, err)
+				got = make([]byte, 5)
+				_, err = io.ReadFull(r, got)
+				require.NoError(t, err)
+				require.Equal(t, data[1:6], got)
+			}
+			require.NoError(t, r.Close())
+
+			for _, rg := range [][2]int64{{0, 0}, {0, 1}, {1, 10}, {encryptChunkSize - 1, encryptChunkSize + 1}, {encryptChunkSize, 2*encryptChunkSize + 50}} {
+				if rg[1] > int64(size) {
+					continue
+				}
+				got = make([]byte, rg[1]-rg[0])
+				_, err = ReadDataInRange(ctx, store, name, rg[0], got)
+				require.NoError(t, err)
+				require.Equal(t, data[rg[0]:rg[1]], got)
+			}
+		}
+	}
+}
+
+func TestWithEncryptionCorrupted(t *testing.T) {
+	ctx := context.Background()
+	inner, err := NewLocalStorage(t.TempDir())
+	require.NoError(t, err)
+	store := WithEncryption(inner, bytes.Repeat([]byte{1}, 32))
+	data := bytes.Repeat([]byte("0123456789"), encryptChunkSize/5)
+	require.NoError(t, store.WriteFile(ctx, "f", data))
+	raw, err := inner.ReadFile(ctx, "f")
+	require.NoError(t, err)
+
+	// wrong key
+	_, err = WithEncryption(inner, bytes.Repeat([]byte{2}, 32)).ReadFile(ctx, "f")
+	require.ErrorContains(t, err, "decrypt chunk 0")
+	// not encrypted
+	require.NoError(t, inner.WriteFile(ctx, "plain", data))
+	_, err = store.ReadFile(ctx, "plain")
+	require.ErrorContains(t, err, "is not encrypted")
+	// modified
+	modified := bytes.Clone(raw)
+	modified[len(modified)/2] ^= 1
+	require.NoError(t, inner.WriteFile(ctx, "f", modified))
+	_, err = store.ReadFile(ctx, "f")
+	require.ErrorContains(t, err, "decrypt chunk")
+	// truncated at the chunk boundary
+	require.NoError(t, inner.WriteFile(ctx, "f", raw[:sealedOffset(1)]))
+	_, err = store.ReadFile(ctx, "f")
+	require.ErrorContains(t, err, "decrypt chunk 1")
+	r, err := store.Open(ctx, "f", nil)
+	require.NoError(t, err)
+	_, err = io.ReadAll(r)
+	require.ErrorContains(t, err, "is truncated")
+	require.NoError(t, r.Close())
+
+	_, err = store.Open(ctx, filepath.Join("not", "exist"), nil)
+	require.Error(t, err)
+}
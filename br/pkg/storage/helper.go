@@ -4,7 +4,12 @@ package storage
 
 import (
 	"context"
+	"path/filepath"
 
+	"github.com/google/uuid"
+	"github.com/pingcap/errors"
+	backuppb "github.com/pingcap/kvproto/pkg/brpb"
+	berrors "github.com/pingcap/tidb/br/pkg/errors"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/intest"
 )
@@ -13,13 +18,21 @@ func init() {
 	variable.ValidateCloudStorageURI = ValidateCloudStorageURI
 }
 
+// errStopWalk is used to stop WalkDir after the first object is visited.
+var errStopWalk = errors.New("stop walk")
+
 // ValidateCloudStorageURI makes validation for tidb_cloud_storage_uri.
 func ValidateCloudStorageURI(ctx context.Context, uri string) error {
 	b, err := ParseBackend(uri, nil)
 	if err != nil {
 		return err
 	}
-	_, err = New(ctx, b, &ExternalStorageOptions{
+	if _, ok := b.Backend.(*backuppb.StorageBackend_Local); ok {
+		if err = validateSharedLocalPath(uri); err != nil {
+			return err
+		}
+	}
+	s, err := New(ctx, b, &ExternalStorageOptions{
 		CheckPermissions: []Permission{
 			ListObjects,
 			GetObject,
@@ -27,5 +40,60 @@ func ValidateCloudStorageURI(ctx context.Context, uri string) error {
 		},
 		NoCredentials: intest.InTest,
 	})
-	return err
+	if err != nil {
+		return err
+	}
+	switch b.Backend.(type) {
+	case *backuppb.StorageBackend_Local, *backuppb.StorageBackend_AzureBlobStorage:
+		// New doesn't check the permissions of these backends, so we check them by
+		// the operations global sort needs.
+		return checkListAndWrite(ctx, s)
+	}
+	return nil
+}
+
+// validateSharedLocalPath checks the local path used as the cloud storage. It's
+// read and written by all the TiDB nodes, so it must be an absolute path which is
+// mounted at the same place on every node, such as a NFS mount point. We can't
+// check whether it's really shared, so only the path format is checked here.
+func validateSharedLocalPath(rawURL string) error {
+	u, err := ParseRawURL(rawURL)
+	if err != nil {
+		return errors.Trace(err)
+	}
+	p := rawURL
+	if len(u.Scheme) > 0 {
+		if len(u.Host) > 0 {
+			return errors.Annotatef(berrors.ErrStorageInvalidConfig,
+				"host is not supported for local storage, please use %s:///path/to/dir", u.Scheme)
+		}
+		p = u.Path
+	}
+	if !filepath.IsAbs(p) {
+		return errors.Annotatef(berrors.ErrStorageInvalidConfig,
+			"local storage path must be an absolute path shared by all TiDB nodes, got %s", p)
+	}
+	return nil
+}
+
+// checkListAndWrite checks the storage can be listed, and a file can be written to
+// and deleted from it.
+func checkListAndWrite(ctx context.Context, s ExternalStorage) error {
+	err := s.WalkDir(ctx, &WalkOption{ListCount: 1}, func(string, int64) error {
+		return errStopWalk
+	})
+	if err != nil && errors.Cause(err) != errStopWalk {
+		return errors.Annotatef(berrors.ErrStorageInvalidPermission,
+			"check permission %s failed due to %v", ListObjects, err)
+	}
+	name := "tidb-storage-check-" + uuid.NewString()
+	if err = s.WriteFile(ctx, name, []byte("check")); err != nil {
+		return errors.Annotatef(berrors.ErrStorageInvalidPermission,
+			"check permission %s failed due to %v", PutAndDeleteObject, err)
+	}
+	if err = s.DeleteFile(ctx, name); err != nil {
+		return errors.Annotatef(berrors.ErrStorageInvalidPermission,
+			"check permission %s failed due to %v", PutAndDeleteObject, err)
+	}
+	return nil
 }
@@ -0,0 +1,45 @@
+// Copyright 2023 PingCAP, Inc. Licensed under Apache-2.0.
+
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateLocalCloudStorageURI(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("local path check is unix only")
+	}
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	for _, uri := range []string{
+		dir,
+		"local://" + dir,
+		"file://" + filepath.Join(dir, "not-exist"),
+	} {
+		require.NoError(t, ValidateCloudStorageURI(ctx, uri), uri)
+	}
+	// the check file is removed.
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, "not-exist", entries[0].Name())
+
+	// relative path and host are not allowed.
+	require.ErrorContains(t, ValidateCloudStorageURI(ctx, "relative/path"), "absolute path")
+	require.ErrorContains(t, ValidateCloudStorageURI(ctx, "local://relative/path"), "host is not supported")
+
+	// not writable
+	if os.Getuid() != 0 {
+		readOnly := filepath.Join(dir, "read-only")
+		require.NoError(t, os.Mkdir(readOnly, 0o500))
+		require.ErrorContains(t, ValidateCloudStorageURI(ctx, "local://"+readOnly), "PutAndDeleteObject")
+	}
+}
@@ -98,6 +98,14 @@ func (l *LocalStorage) WalkDir(_ context.Context, opt *WalkOption, fn func(strin
 		opt = &WalkOption{}
 	}
 	base := filepath.Join(l.base, opt.SubDir)
+	// like the cloud storages, ObjPrefix is the prefix of the object names under SubDir.
+	var objPrefix string
+	if len(opt.ObjPrefix) > 0 {
+		objPrefix, _ = filepath.Rel(l.base, filepath.Join(base, opt.ObjPrefix))
+		if strings.HasSuffix(opt.ObjPrefix, "/") {
+			objPrefix += "/"
+		}
+	}
 	return filepath.Walk(base, func(path string, f os.FileInfo, err error) error {
 		if os.IsNotExist(err) {
 			// if path not exists, we should return nil to continue.
@@ -121,7 +129,7 @@ func (l *LocalStorage) WalkDir(_ context.Context, opt *WalkOption, fn func(strin
 		// so use Rel to convert to relative path to l.base
 		path, _ = filepath.Rel(l.base, path)
 
-		if !strings.HasPrefix(path, opt.ObjPrefix) {
+		if !strings.HasPrefix(path, objPrefix) {
 			return nil
 		}
 
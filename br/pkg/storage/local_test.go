@@ -128,6 +128,17 @@ func TestWalkDirWithSoftLinkFile(t *testing.T) {
 		return errors.Errorf("find other file: %s", path)
 	})
 	require.NoError(t, err)
+
+	// test walk with both SubDir and ObjPrefix, ObjPrefix is relative to SubDir
+	err = store.WriteFile(context.TODO(), "/123/456/abc.txt", []byte(data))
+	require.NoError(t, err)
+	names = nil
+	err = store.WalkDir(context.TODO(), &WalkOption{SubDir: "123/456", ObjPrefix: "78"}, func(path string, size int64) error {
+		names = append(names, path)
+		return nil
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"123/456/789.txt"}, names)
 }
 
 func TestWalkDirSkipSubDir(t *testing.T) {
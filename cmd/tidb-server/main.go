@@ -742,6 +742,9 @@ func setGlobalVars() {
 	planReplayerGCLease := parseDuration(cfg.Performance.PlanReplayerGCLease)
 	session.SetPlanReplayerGCLease(planReplayerGCLease)
 	bindinfo.Lease = parseDuration(cfg.Performance.BindInfoLease)
+	bindinfo.AsyncWarmUp = cfg.Performance.BindInfoAsyncWarmUp
+	bindinfo.BindingsFile = cfg.Performance.BindInfoFile
+	bindinfo.PruneBindingsFile = cfg.Performance.BindInfoFilePrune
 	statistics.RatioOfPseudoEstimate.Store(cfg.Performance.PseudoEstimateRatio)
 	if cfg.SplitTable {
 		atomic.StoreUint32(&ddl.EnableSplitTableRegion, 1)
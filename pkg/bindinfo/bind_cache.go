@@ -15,24 +15,49 @@
 package bindinfo
 
 import (
+	"container/list"
 	"errors"
 	"sync"
+	"sync/atomic"
 
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/hack"
-	"github.com/pingcap/tidb/pkg/util/kvcache"
-	"github.com/pingcap/tidb/pkg/util/mathutil"
 	"github.com/pingcap/tidb/pkg/util/memory"
 )
 
+// bindCacheShardCount is the number of shards of the bindCache.
+const bindCacheShardCount = 16
+
 // bindCache uses the LRU cache to store the bindRecord.
 // The key of the LRU cache is original sql, the value is a slice of BindRecord.
-// Note: The bindCache should be accessed with lock.
+// The cache is split into shards by the key, every shard is protected by its own lock, so that the
+// readers of different keys don't contend with each other, and a write operation only changes the
+// shard of its key instead of copying the whole cache.
+// The LRU order is maintained across the shards by the access tick of the entries.
 type bindCache struct {
-	lock        sync.Mutex
-	cache       *kvcache.SimpleLRUCache
-	memCapacity int64
+	shards [bindCacheShardCount]*bindCacheShard
+	// writeLock serializes the write operations, so that the read-modify-write of a key and the eviction
+	// across the shards are atomic. The readers only lock the shard they access.
+	writeLock   sync.Mutex
+	memCapacity atomic.Int64
 	memTracker  *memory.Tracker // track memory usage.
+	accessTick  atomic.Uint64
+	// evictedCount is the number of the bind records evicted or rejected because of the memory quota.
+	evictedCount atomic.Int64
+}
+
+// bindCacheShard is a shard of the bindCache.
+type bindCacheShard struct {
+	lock    sync.Mutex
+	entries map[bindCacheKey]*list.Element
+	lru     *list.List // The front of the list is the most recently used entry.
+}
+
+type bindCacheEntry struct {
+	key        bindCacheKey
+	value      []*BindRecord
+	mem        int64
+	lastAccess uint64
 }
 
 type bindCacheKey string
@@ -49,29 +74,62 @@ func calcBindCacheKVMem(key bindCacheKey, value []*BindRecord) int64 {
 	return int64(len(key.Hash())) + valMem
 }
 
+var errBindCacheMemExceeded = errors.New("The memory usage of all available bindings exceeds the cache's mem quota. As a result, all available bindings cannot be held on the cache. Please increase the value of the system variable 'tidb_mem_quota_binding_cache' and execute 'admin reload bindings' to ensure that all bindings exist in the cache and can be used normally")
+
 func newBindCache() *bindCache {
-	// since bindCache controls the memory usage by itself, set the capacity of
-	// the underlying LRUCache to max to close its memory control
-	cache := kvcache.NewSimpleLRUCache(mathutil.MaxUint, 0, 0)
-	c := bindCache{
-		cache:       cache,
-		memCapacity: variable.MemQuotaBindingCache.Load(),
-		memTracker:  memory.NewTracker(memory.LabelForBindCache, -1),
+	c := &bindCache{
+		memTracker: memory.NewTracker(memory.LabelForBindCache, -1),
+	}
+	for i := range c.shards {
+		c.shards[i] = &bindCacheShard{
+			entries: make(map[bindCacheKey]*list.Element),
+			lru:     list.New(),
+		}
 	}
-	return &c
+	c.memCapacity.Store(variable.MemQuotaBindingCache.Load())
+	return c
 }
 
-// get gets a cache item according to cache key. It's not thread-safe.
+// shard returns the shard of the key, the keys are distributed by their FNV-1a hash.
+func (c *bindCache) shard(key bindCacheKey) *bindCacheShard {
+	h := uint32(2166136261)
+	for i := 0; i < len(key); i++ {
+		h ^= uint32(key[i])
+		h *= 16777619
+	}
+	return c.shards[h%bindCacheShardCount]
+}
+
+// entries returns all the entries of the cache. The entries of a shard are ordered from the most
+// recently used one to the least recently used one.
+func (c *bindCache) entries() []*bindCacheEntry {
+	var entries []*bindCacheEntry
+	for _, s := range c.shards {
+		s.lock.Lock()
+		for elem := s.lru.Front(); elem != nil; elem = elem.Next() {
+			entries = append(entries, elem.Value.(*bindCacheEntry))
+		}
+		s.lock.Unlock()
+	}
+	return entries
+}
+
+// get gets a cache item according to cache key. It's thread-safe.
 // Note: Only other functions of the bindCache file can use this function.
 // Don't use this function directly in other files in bindinfo package.
 // The return value is not read-only, but it is only can be used in other functions which are also in the bind_cache.go.
 func (c *bindCache) get(key bindCacheKey) []*BindRecord {
-	value, hit := c.cache.Get(key)
+	s := c.shard(key)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	elem, hit := s.entries[key]
 	if !hit {
 		return nil
 	}
-	typedValue := value.([]*BindRecord)
-	return typedValue
+	entry := elem.Value.(*bindCacheEntry)
+	entry.lastAccess = c.accessTick.Add(1)
+	s.lru.MoveToFront(elem)
+	return entry.value
 }
 
 // getCopiedVal gets a copied cache item according to cache key.
@@ -90,53 +148,80 @@ func (c *bindCache) getCopiedVal(key bindCacheKey) []*BindRecord {
 	return bindRecords
 }
 
-// set inserts an item to the cache. It's not thread-safe.
+// set inserts an item to the cache. It must be called with the writeLock held.
 // Only other functions of the bindCache can use this function.
 // The set operation will return error message when the memory usage of binding_cache exceeds its capacity.
 func (c *bindCache) set(key bindCacheKey, value []*BindRecord) (ok bool, err error) {
 	mem := calcBindCacheKVMem(key, value)
-	if mem > c.memCapacity { // ignore this kv pair if its size is too large
-		err = errors.New("The memory usage of all available bindings exceeds the cache's mem quota. As a result, all available bindings cannot be held on the cache. Please increase the value of the system variable 'tidb_mem_quota_binding_cache' and execute 'admin reload bindings' to ensure that all bindings exist in the cache and can be used normally")
+	if mem > c.memCapacity.Load() { // ignore this kv pair if its size is too large
+		c.evictedCount.Add(1)
+		err = errBindCacheMemExceeded
 		return
 	}
-	bindRecords := c.get(key)
-	if bindRecords != nil {
-		// Remove the origin key-value pair.
-		mem -= calcBindCacheKVMem(key, bindRecords)
-	}
-	for mem+c.memTracker.BytesConsumed() > c.memCapacity {
-		err = errors.New("The memory usage of all available bindings exceeds the cache's mem quota. As a result, all available bindings cannot be held on the cache. Please increase the value of the system variable 'tidb_mem_quota_binding_cache' and execute 'admin reload bindings' to ensure that all bindings exist in the cache and can be used normally")
-		evictedKey, evictedValue, evicted := c.cache.RemoveOldest()
-		if !evicted {
+	// Remove the origin key-value pair, so that it is neither counted nor evicted below.
+	c.delete(key)
+	for mem+c.memTracker.BytesConsumed() > c.memCapacity.Load() {
+		err = errBindCacheMemExceeded
+		if !c.evictOldest() {
 			return
 		}
-		c.memTracker.Consume(-calcBindCacheKVMem(evictedKey.(bindCacheKey), evictedValue.([]*BindRecord)))
 	}
+	s := c.shard(key)
+	s.lock.Lock()
+	s.entries[key] = s.lru.PushFront(&bindCacheEntry{key: key, value: value, mem: mem, lastAccess: c.accessTick.Add(1)})
+	s.lock.Unlock()
 	c.memTracker.Consume(mem)
-	c.cache.Put(key, value)
 	ok = true
 	return
 }
 
-// delete remove an item from the cache. It's not thread-safe.
+// evictOldest evicts the least recently used item among all the shards. It must be called with the writeLock held.
+// It returns false if the cache is empty.
+func (c *bindCache) evictOldest() bool {
+	var (
+		victimShard *bindCacheShard
+		victimKey   bindCacheKey
+		victimTick  uint64
+	)
+	for _, s := range c.shards {
+		s.lock.Lock()
+		if back := s.lru.Back(); back != nil {
+			entry := back.Value.(*bindCacheEntry)
+			if victimShard == nil || entry.lastAccess < victimTick {
+				victimShard, victimKey, victimTick = s, entry.key, entry.lastAccess
+			}
+		}
+		s.lock.Unlock()
+	}
+	if victimShard == nil {
+		return false
+	}
+	c.delete(victimKey)
+	c.evictedCount.Add(1)
+	return true
+}
+
+// delete remove an item from the cache. It must be called with the writeLock held.
 // Only other functions of the bindCache can use this function.
 func (c *bindCache) delete(key bindCacheKey) bool {
-	bindRecords := c.get(key)
-	if bindRecords != nil {
-		mem := calcBindCacheKVMem(key, bindRecords)
-		c.cache.Delete(key)
-		c.memTracker.Consume(-mem)
-		return true
+	s := c.shard(key)
+	s.lock.Lock()
+	elem, hit := s.entries[key]
+	if !hit {
+		s.lock.Unlock()
+		return false
 	}
-	return false
+	delete(s.entries, key)
+	s.lru.Remove(elem)
+	s.lock.Unlock()
+	c.memTracker.Consume(-elem.Value.(*bindCacheEntry).mem)
+	return true
 }
 
 // GetBindRecord gets the BindRecord from the cache.
 // The return value is not read-only, but it shouldn't be changed in the caller functions.
 // The function is thread-safe.
 func (c *bindCache) GetBindRecord(hash, normdOrigSQL, _ string) *BindRecord {
-	c.lock.Lock()
-	defer c.lock.Unlock()
 	bindRecords := c.get(bindCacheKey(hash))
 	for _, bindRecord := range bindRecords {
 		if bindRecord.OriginalSQL == normdOrigSQL {
@@ -150,8 +235,6 @@ func (c *bindCache) GetBindRecord(hash, normdOrigSQL, _ string) *BindRecord {
 // The return value is not read-only, but it shouldn't be changed in the caller functions.
 // The function is thread-safe.
 func (c *bindCache) GetBindRecordBySQLDigest(sqlDigest string) (*BindRecord, error) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
 	bindings := c.get(bindCacheKey(sqlDigest))
 	if len(bindings) > 1 {
 		// currently, we only allow one binding for a sql
@@ -167,13 +250,10 @@ func (c *bindCache) GetBindRecordBySQLDigest(sqlDigest string) (*BindRecord, err
 // The return value is not read-only, but it shouldn't be changed in the caller functions.
 // The function is thread-safe.
 func (c *bindCache) GetAllBindRecords() []*BindRecord {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	values := c.cache.Values()
 	//nolint: prealloc
 	var bindRecords []*BindRecord
-	for _, vals := range values {
-		bindRecords = append(bindRecords, vals.([]*BindRecord)...)
+	for _, entry := range c.entries() {
+		bindRecords = append(bindRecords, entry.value...)
 	}
 	return bindRecords
 }
@@ -181,24 +261,17 @@ func (c *bindCache) GetAllBindRecords() []*BindRecord {
 // SetBindRecord sets the BindRecord to the cache.
 // The function is thread-safe.
 func (c *bindCache) SetBindRecord(hash string, meta *BindRecord) (err error) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	cacheKey := bindCacheKey(hash)
-	metas := c.getCopiedVal(cacheKey)
-	for i := range metas {
-		if metas[i].OriginalSQL == meta.OriginalSQL {
-			metas[i] = meta
-		}
-	}
-	_, err = c.set(cacheKey, []*BindRecord{meta})
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	_, err = c.set(bindCacheKey(hash), []*BindRecord{meta})
 	return
 }
 
 // RemoveBindRecord removes the BindRecord which has same originSQL with specified BindRecord.
 // The function is thread-safe.
 func (c *bindCache) RemoveBindRecord(hash string, meta *BindRecord) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
 	metas := c.getCopiedVal(bindCacheKey(hash))
 	if metas == nil {
 		return
@@ -224,46 +297,69 @@ func (c *bindCache) RemoveBindRecord(hash string, meta *BindRecord) {
 // SetMemCapacity sets the memory capacity for the cache.
 // The function is thread-safe.
 func (c *bindCache) SetMemCapacity(capacity int64) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
 	// Only change the capacity size without affecting the cached bindRecord
-	c.memCapacity = capacity
+	c.memCapacity.Store(capacity)
+}
+
+// resize sets the memory capacity for the cache, and evicts the least recently used bind records until
+// the memory usage fits into the new capacity. It returns an error if any bind record is evicted.
+// The function is thread-safe.
+func (c *bindCache) resize(capacity int64) (err error) {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	c.memCapacity.Store(capacity)
+	for c.memTracker.BytesConsumed() > capacity {
+		err = errBindCacheMemExceeded
+		if !c.evictOldest() {
+			break
+		}
+	}
+	return err
 }
 
 // GetMemUsage get the memory Usage for the cache.
 // The function is thread-safe.
 func (c *bindCache) GetMemUsage() int64 {
-	c.lock.Lock()
-	defer c.lock.Unlock()
 	return c.memTracker.BytesConsumed()
 }
 
 // GetMemCapacity get the memory capacity for the cache.
 // The function is thread-safe.
 func (c *bindCache) GetMemCapacity() int64 {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	return c.memCapacity
+	return c.memCapacity.Load()
+}
+
+// BindCacheRecordStatus is the memory usage of the bind records of a sql digest in the bind cache.
+type BindCacheRecordStatus struct {
+	SQLDigest string
+	Records   []*BindRecord
+	MemUsage  int64
+}
+
+// BindCacheStatus is the memory usage and eviction status of the bind cache.
+type BindCacheStatus struct {
+	MemUsage     int64
+	MemCapacity  int64
+	EvictedCount int64
+	Records      []*BindCacheRecordStatus
 }
 
-// Copy copies a new bindCache from the origin cache.
+// GetStatus gets the memory usage and eviction status of the cache.
 // The function is thread-safe.
-func (c *bindCache) Copy() (newCache *bindCache, err error) {
-	c.lock.Lock()
-	defer c.lock.Unlock()
-	newCache = newBindCache()
-	if c.memTracker.BytesConsumed() > newCache.GetMemCapacity() {
-		err = errors.New("The memory usage of all available bindings exceeds the cache's mem quota. As a result, all available bindings cannot be held on the cache. Please increase the value of the system variable 'tidb_mem_quota_binding_cache' and execute 'admin reload bindings' to ensure that all bindings exist in the cache and can be used normally")
+func (c *bindCache) GetStatus() *BindCacheStatus {
+	c.writeLock.Lock()
+	defer c.writeLock.Unlock()
+	status := &BindCacheStatus{
+		MemUsage:     c.memTracker.BytesConsumed(),
+		MemCapacity:  c.memCapacity.Load(),
+		EvictedCount: c.evictedCount.Load(),
 	}
-	keys := c.cache.Keys()
-	for _, key := range keys {
-		cacheKey := key.(bindCacheKey)
-		v := c.get(cacheKey)
-		bindRecords := make([]*BindRecord, len(v))
-		copy(bindRecords, v)
-		// The memory usage of cache has been handled at the beginning of this function.
-		// So we don't need to handle the return value here.
-		_, _ = newCache.set(cacheKey, bindRecords)
+	for _, entry := range c.entries() {
+		status.Records = append(status.Records, &BindCacheRecordStatus{
+			SQLDigest: string(entry.key),
+			Records:   entry.value,
+			MemUsage:  entry.mem,
+		})
 	}
-	return newCache, err
+	return status
 }
@@ -15,8 +15,10 @@
 package bindinfo
 
 import (
+	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
@@ -77,3 +79,86 @@ func TestBindCache(t *testing.T) {
 	result = bindCache.get(bigBindCacheKey)
 	require.Nil(t, result)
 }
+
+func TestBindCacheShards(t *testing.T) {
+	variable.MemQuotaBindingCache.Store(variable.DefTiDBMemQuotaBindingCache)
+	bindCache := newBindCache()
+	const keyCount = 100
+	for i := 0; i < keyCount; i++ {
+		key := fmt.Sprintf("digest-%03d", i)
+		require.NoError(t, bindCache.SetBindRecord(key, &BindRecord{OriginalSQL: key}))
+	}
+	usedShards := 0
+	for _, s := range bindCache.shards {
+		if s.lru.Len() > 0 {
+			usedShards++
+		}
+	}
+	require.Greater(t, usedShards, 1)
+	require.Len(t, bindCache.GetAllBindRecords(), keyCount)
+	memUsage := bindCache.GetMemUsage()
+
+	// Touch the first half of the keys, so the second half are the least recently used ones across all shards.
+	for i := 0; i < keyCount/2; i++ {
+		key := fmt.Sprintf("digest-%03d", i)
+		require.NotNil(t, bindCache.GetBindRecord(key, key, ""))
+	}
+	require.Error(t, bindCache.resize(memUsage/2))
+	require.Equal(t, int64(keyCount/2), bindCache.GetStatus().EvictedCount)
+	for i := 0; i < keyCount; i++ {
+		key := fmt.Sprintf("digest-%03d", i)
+		if i < keyCount/2 {
+			require.NotNil(t, bindCache.GetBindRecord(key, key, ""))
+		} else {
+			require.Nil(t, bindCache.GetBindRecord(key, key, ""))
+		}
+	}
+
+	// Readers and writers of different keys can access the cache concurrently.
+	require.NoError(t, bindCache.resize(variable.DefTiDBMemQuotaBindingCache))
+	memUsage = bindCache.GetMemUsage()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < keyCount; j++ {
+				key := fmt.Sprintf("digest-%d-%03d", i, j)
+				require.NoError(t, bindCache.SetBindRecord(key, &BindRecord{OriginalSQL: key}))
+				require.NotNil(t, bindCache.GetBindRecord(key, key, ""))
+				bindCache.RemoveBindRecord(key, &BindRecord{OriginalSQL: key})
+				require.Nil(t, bindCache.GetBindRecord(key, key, ""))
+			}
+		}(i)
+	}
+	wg.Wait()
+	require.Len(t, bindCache.GetAllBindRecords(), keyCount/2)
+	require.Equal(t, memUsage, bindCache.GetMemUsage())
+}
+
+func TestPendingVerifyJobsInterleaved(t *testing.T) {
+	variable.MemQuotaBindingCache.Store(variable.DefTiDBMemQuotaBindingCache)
+	h := &BindHandle{}
+	h.bindInfo.Value.Store(newBindCache())
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	// Digest "a" has 3 pending bindings while digest "b" has 1, and the enabled one is not a job.
+	require.NoError(t, cache.SetBindRecord("a", &BindRecord{OriginalSQL: "a", Db: "test", Bindings: []Binding{
+		{BindSQL: "a1", Status: PendingVerify},
+		{BindSQL: "a2", Status: PendingVerify},
+		{BindSQL: "a3", Status: Enabled},
+		{BindSQL: "a4", Status: PendingVerify},
+	}}))
+	require.NoError(t, cache.SetBindRecord("b", &BindRecord{OriginalSQL: "b", Db: "test", Bindings: []Binding{
+		{BindSQL: "b1", Status: PendingVerify},
+	}}))
+
+	jobs := h.getPendingVerifyJobs()
+	require.Len(t, jobs, 4)
+	bindSQLs := make([]string, 0, len(jobs))
+	for _, job := range jobs {
+		bindSQLs = append(bindSQLs, job.binding.BindSQL)
+	}
+	// Both digests get a job in the first round, no matter which one is returned by the cache first.
+	require.ElementsMatch(t, []string{"a1", "b1"}, bindSQLs[:2])
+	require.Equal(t, []string{"a2", "a4"}, bindSQLs[2:])
+}
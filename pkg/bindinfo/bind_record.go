@@ -18,9 +18,12 @@ import (
 	"time"
 	"unsafe"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/ast"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/hack"
 	"github.com/pingcap/tidb/pkg/util/hint"
@@ -46,6 +49,9 @@ const (
 	// Rejected means that the bind has been rejected after verify process.
 	// We can retry it after certain time has passed.
 	Rejected = "rejected"
+	// Standby is the bind info's in standby status. The standby bindings are not used until the enabled
+	// binding of the same SQL regresses, then one of them is switched to the enabled status automatically.
+	Standby = "standby"
 	// Manual indicates the binding is created by SQL like "create binding for ...".
 	Manual = "manual"
 	// Capture indicates the binding is captured by TiDB automatically.
@@ -56,6 +62,8 @@ const (
 	Builtin = "builtin"
 	// History indicate the binding is created from statement summary by plan digest
 	History = "history"
+	// File indicates the binding is created from the declarative bindings file on startup.
+	File = "file"
 )
 
 // Binding stores the basic bind hint info.
@@ -76,6 +84,18 @@ type Binding struct {
 	ID         string `json:"-"`
 	SQLDigest  string
 	PlanDigest string
+	// Comment is the user-defined comment of the binding, e.g. why the binding exists, the ticket number or the owner.
+	Comment string
+	// The following fields record the provenance of the captured binding, they are only set when the
+	// Source is Capture.
+	// CaptureUsers are the users who have executed the captured statement, separated by commas.
+	CaptureUsers string
+	// CaptureSampleSQL is a sample of the captured statement text.
+	CaptureSampleSQL string
+	// CaptureAvgLatency is the average latency of the captured statement in nanoseconds.
+	CaptureAvgLatency int64
+	// CaptureExecCount is the execution count of the captured statement.
+	CaptureExecCount int64
 }
 
 func (b *Binding) isSame(rb *Binding) bool {
@@ -93,9 +113,9 @@ func (b *Binding) IsBindingEnabled() bool {
 
 // IsBindingAvailable returns whether the binding is available.
 // The available means the binding can be used or can be converted into a usable status.
-// It includes the 'Enabled', 'Using' and 'Disabled' status.
+// It includes the 'Enabled', 'Using', 'Disabled' and 'Standby' status.
 func (b *Binding) IsBindingAvailable() bool {
-	return b.IsBindingEnabled() || b.Status == Disabled
+	return b.IsBindingEnabled() || b.Status == Disabled || b.Status == Standby
 }
 
 // SinceUpdateTime returns the duration since last update time. Export for test.
@@ -138,7 +158,7 @@ func (br *BindRecord) HasEnabledBinding() bool {
 
 // HasAvailableBinding checks if there are any available bindings in bind record.
 // The available means the binding can be used or can be converted into a usable status.
-// It includes the 'Enabled', 'Using' and 'Disabled' status.
+// It includes the 'Enabled', 'Using', 'Disabled' and 'Standby' status.
 func (br *BindRecord) HasAvailableBinding() bool {
 	for _, binding := range br.Bindings {
 		if binding.IsBindingAvailable() {
@@ -182,10 +202,13 @@ func (br *BindRecord) prepareHints(sctx sessionctx.Context) error {
 		if err != nil {
 			return err
 		}
+		if err = checkSetVarHints(sctx, hintsSet); err != nil {
+			return err
+		}
 		if sctx != nil {
 			paramChecker := &paramMarkerChecker{}
 			stmt.Accept(paramChecker)
-			if !paramChecker.hasParamMarker {
+			if len(paramChecker.markers) == 0 {
 				_, err = getHintsForSQL(sctx, bind.BindSQL)
 				if err != nil {
 					return err
@@ -208,6 +231,31 @@ func (br *BindRecord) prepareHints(sctx sessionctx.Context) error {
 	return nil
 }
 
+// checkSetVarHints checks the SET_VAR hints of a binding. Only the variables which can be updated by hints
+// are allowed, and their values are validated if sctx is not nil, so that the variables pinned by the
+// binding can be applied when the binding is matched.
+func checkSetVarHints(sctx sessionctx.Context, hintsSet *hint.HintsSet) error {
+	for _, tblHint := range hintsSet.GetFirstTableHints() {
+		if tblHint.HintName.L != "set_var" {
+			continue
+		}
+		setVarHint := tblHint.HintData.(ast.HintSetVar)
+		sysVar := variable.GetSysVar(setVarHint.VarName)
+		if sysVar == nil {
+			return errors.Errorf("Unresolved name '%s' for SET_VAR hint", setVarHint.VarName)
+		}
+		if !sysVar.IsHintUpdatableVerfied {
+			return errors.Errorf("Variable '%s' cannot be set using SET_VAR hint", setVarHint.VarName)
+		}
+		if sctx != nil {
+			if _, err := sysVar.Validate(sctx.GetSessionVars(), setVarHint.Value, variable.ScopeSession); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 // `merge` merges two BindRecord. It will replace old bindings with new bindings if there are new updates.
 func merge(lBindRecord, rBindRecord *BindRecord) *BindRecord {
 	if lBindRecord == nil {
@@ -318,7 +366,8 @@ func (br *BindRecord) metrics() ([]float64, []int) {
 
 // size calculates the memory size of a bind info.
 func (b *Binding) size() float64 {
-	res := len(b.BindSQL) + len(b.Status) + 2*int(unsafe.Sizeof(b.CreateTime)) + len(b.Charset) + len(b.Collation) + len(b.ID)
+	res := len(b.BindSQL) + len(b.Status) + 2*int(unsafe.Sizeof(b.CreateTime)) + len(b.Charset) + len(b.Collation) + len(b.ID) + len(b.Comment) +
+		len(b.CaptureUsers) + len(b.CaptureSampleSQL)
 	return float64(res)
 }
 
@@ -332,3 +381,20 @@ func updateMetrics(scope string, before *BindRecord, after *BindRecord, sizeOnly
 		}
 	}
 }
+
+// NormalizeForBinding normalizes the sql for bindings. If tidb_binding_collapse_in_list is on, the whole IN lists
+// are collapsed, so a binding matches the statements differ only in the IN lists.
+func NormalizeForBinding(sql string) string {
+	if variable.BindingCollapseInList.Load() {
+		return parser.NormalizeForBindingCollapseInList(sql)
+	}
+	return parser.NormalizeForBinding(sql)
+}
+
+// NormalizeDigestForBinding combines NormalizeForBinding and parser.DigestNormalized into one method.
+func NormalizeDigestForBinding(sql string) (normalized string, digest *parser.Digest) {
+	if variable.BindingCollapseInList.Load() {
+		return parser.NormalizeDigestForBindingCollapseInList(sql)
+	}
+	return parser.NormalizeDigestForBinding(sql)
+}
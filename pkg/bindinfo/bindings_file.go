@@ -0,0 +1,164 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	utilparser "github.com/pingcap/tidb/pkg/util/parser"
+	"go.uber.org/zap"
+)
+
+var (
+	// BindingsFile is the path of the declarative bindings file. The global bindings are reconciled with it
+	// on startup if it is not empty.
+	BindingsFile = ""
+	// PruneBindingsFile indicates whether to drop the bindings which were created from the bindings file
+	// but are no longer declared in it. The bindings created by other means are never dropped.
+	PruneBindingsFile = false
+)
+
+// FileBinding is a global binding declared in the bindings file.
+type FileBinding struct {
+	// Db is the default database of the statements, it can be empty if all the tables are qualified.
+	Db          string `toml:"db" json:"db"`
+	OriginalSQL string `toml:"original-sql" json:"original_sql"`
+	BindSQL     string `toml:"bind-sql" json:"bind_sql"`
+	Comment     string `toml:"comment" json:"comment"`
+}
+
+type bindingsFile struct {
+	Bindings []FileBinding `toml:"bindings" json:"bindings"`
+}
+
+// LoadBindingsFile reads the bindings declared in the file. The file is decoded as JSON if its extension
+// is ".json", and as TOML otherwise.
+func LoadBindingsFile(path string) ([]FileBinding, error) {
+	content, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	var f bindingsFile
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		err = json.Unmarshal(content, &f)
+	} else {
+		_, err = toml.Decode(string(content), &f)
+	}
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to decode bindings file %s", path)
+	}
+	return f.Bindings, nil
+}
+
+// ReconcileBindingsFile reconciles the global bindings with the bindings file. The declared bindings which
+// are not enabled yet are created, replacing the existing bindings of the same statements. If prune is true,
+// the bindings created from the file but no longer declared in it are dropped. It returns the number of the
+// created and dropped bindings.
+func (h *BindHandle) ReconcileBindingsFile(path string, prune bool) (created, dropped int, err error) {
+	fileBindings, err := LoadBindingsFile(path)
+	if err != nil {
+		return 0, 0, err
+	}
+	h.sctx.Lock()
+	charset, collation := h.sctx.GetSessionVars().GetCharsetInfo()
+	h.sctx.Unlock()
+
+	p := parser.New()
+	// The declared bind sqls of each normalized statement, keyed by the digest of the normalized statement.
+	declared := make(map[string]map[string]struct{}, len(fileBindings))
+	for i := range fileBindings {
+		record, err := buildFileBindRecord(p, &fileBindings[i], charset, collation)
+		if err != nil {
+			return created, dropped, err
+		}
+		binding := &record.Bindings[0]
+		sqlDigest := parser.DigestNormalized(record.OriginalSQL).String()
+		if _, ok := declared[sqlDigest]; !ok {
+			declared[sqlDigest] = make(map[string]struct{})
+		}
+		declared[sqlDigest][binding.BindSQL] = struct{}{}
+
+		if oldRecord := h.GetBindRecord(sqlDigest, record.OriginalSQL, record.Db); oldRecord != nil {
+			if oldBinding := oldRecord.FindEnabledBinding(); oldBinding != nil && oldBinding.BindSQL == binding.BindSQL {
+				continue
+			}
+		}
+		// The `sctx` is not passed since the tables referred by the statements may not be created yet.
+		if err = h.CreateBindRecord(nil, record); err != nil {
+			return created, dropped, errors.Annotatef(err, "failed to create binding for %s", fileBindings[i].OriginalSQL)
+		}
+		created++
+	}
+	if !prune {
+		return created, dropped, nil
+	}
+
+	for _, record := range h.GetAllBindRecord() {
+		for i := range record.Bindings {
+			binding := &record.Bindings[i]
+			if binding.Source != File || !binding.IsBindingAvailable() {
+				continue
+			}
+			if _, ok := declared[parser.DigestNormalized(record.OriginalSQL).String()][binding.BindSQL]; ok {
+				continue
+			}
+			deletedRows, err := h.DropBindRecord(record.OriginalSQL, record.Db, binding)
+			if err != nil {
+				return created, dropped, err
+			}
+			if deletedRows > 0 {
+				logutil.BgLogger().Info("drop the binding no longer declared in the bindings file", zap.String("category", "sql-bind"),
+					zap.String("originalSQL", record.OriginalSQL), zap.String("bindSQL", binding.BindSQL))
+				dropped++
+			}
+		}
+	}
+	return created, dropped, nil
+}
+
+// buildFileBindRecord builds the bind record of a binding declared in the bindings file in the same way as
+// 'create global binding for <OriginalSQL> using <BindSQL>' executed under the database Db.
+func buildFileBindRecord(p *parser.Parser, fb *FileBinding, charset, collation string) (*BindRecord, error) {
+	originNode, err := p.ParseOneStmt(fb.OriginalSQL, charset, collation)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to parse original sql %s", fb.OriginalSQL)
+	}
+	hintedNode, err := p.ParseOneStmt(fb.BindSQL, charset, collation)
+	if err != nil {
+		return nil, errors.Annotatef(err, "failed to parse bind sql %s", fb.BindSQL)
+	}
+	db := strings.ToLower(fb.Db)
+	normdOrigSQL, sqlDigest := NormalizeDigestForBinding(utilparser.RestoreWithDefaultDB(originNode, db, fb.OriginalSQL))
+	return &BindRecord{
+		OriginalSQL: normdOrigSQL,
+		Db:          utilparser.GetDefaultDB(originNode, db),
+		Bindings: []Binding{{
+			BindSQL:   utilparser.RestoreWithDefaultDB(hintedNode, db, fb.BindSQL),
+			Status:    Enabled,
+			Charset:   charset,
+			Collation: collation,
+			Source:    File,
+			SQLDigest: sqlDigest.String(),
+			Comment:   fb.Comment,
+		}},
+	}, nil
+}
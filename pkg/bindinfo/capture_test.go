@@ -16,6 +16,7 @@ package bindinfo_test
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -401,7 +402,7 @@ func TestConcurrentCapture(t *testing.T) {
 	// Simulate an existing binding generated by concurrent CREATE BINDING, which has not been synchronized to current tidb-server yet.
 	// Actually, it is more common to be generated by concurrent baseline capture, I use Manual just for simpler test verification.
 	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t`', 'select * from `test` . `t`', '', 'enabled', '2000-01-01 09:00:00', '2000-01-01 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	tk.MustQuery("select original_sql, source from mysql.bind_info where source != 'builtin'").Check(testkit.Rows(
 		"select * from `test` . `t` manual",
 	))
@@ -916,6 +917,42 @@ func TestCaptureFilter(t *testing.T) {
 	require.Equal(t, "select * from `test` . `t` where `a` > ?", rows[0][0])
 	tk.MustExec("delete from mysql.capture_plan_baselines_blacklist")
 
+	// Valid latency and processed keys filters.
+	internal.UtilCleanBindingEnv(tk, dom)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	tk.MustExec("insert into mysql.capture_plan_baselines_blacklist(filter_type, filter_value) values('latency', '1h')")
+	tk.MustExec("select * from t where a > 10")
+	tk.MustExec("select * from t where a > 10")
+	tk.MustExec("admin capture bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 0)
+	tk.MustExec("delete from mysql.capture_plan_baselines_blacklist")
+	tk.MustExec("insert into mysql.capture_plan_baselines_blacklist(filter_type, filter_value) values('processed_keys', '1000000')")
+	tk.MustExec("admin capture bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 0)
+	tk.MustExec("delete from mysql.capture_plan_baselines_blacklist")
+	tk.MustExec("insert into mysql.capture_plan_baselines_blacklist(filter_type, filter_value) values('latency', '0s')")
+	tk.MustExec("insert into mysql.capture_plan_baselines_blacklist(filter_type, filter_value) values('processed_keys', '0')")
+	tk.MustExec("admin capture bindings")
+	rows = tk.MustQuery("show global bindings").Sort().Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, "delete from `mysql` . `capture_plan_baselines_blacklist`", rows[0][0])
+	require.Equal(t, "select * from `test` . `t` where `a` > ?", rows[1][0])
+	tk.MustExec("delete from mysql.capture_plan_baselines_blacklist")
+
+	// Invalid latency filter.
+	internal.UtilCleanBindingEnv(tk, dom)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	tk.MustExec("insert into mysql.capture_plan_baselines_blacklist(filter_type, filter_value) values('latency', 'xx')")
+	tk.MustExec("select * from t where a > 10")
+	tk.MustExec("select * from t where a > 10")
+	tk.MustExec("admin capture bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "select * from `test` . `t` where `a` > ?", rows[0][0])
+	tk.MustExec("delete from mysql.capture_plan_baselines_blacklist")
+
 	// Invalid filter type.
 	internal.UtilCleanBindingEnv(tk, dom)
 	stmtsummary.StmtSummaryByDigestMap.Clear()
@@ -1027,3 +1064,45 @@ func TestCaptureHints(t *testing.T) {
 		require.Equal(t, res[0][9], sqlDigestWithDB.String())
 	}
 }
+
+func TestCaptureProvenance(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	tk.MustExec("SET GLOBAL tidb_capture_plan_baselines = on")
+	defer func() {
+		tk.MustExec("SET GLOBAL tidb_capture_plan_baselines = off")
+	}()
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, key idx(a))")
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t use index(idx) where a = 1")
+
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("select * from t where a > 10")
+	tk.MustExec("select * from t where a > 10")
+	tk.MustExec("select * from t where a > 20")
+	tk.MustExec("admin capture bindings")
+	rows := tk.MustQuery("show global bindings").Sort().Rows()
+	require.Len(t, rows, 2)
+
+	// The manual binding has no provenance.
+	require.Equal(t, "select * from `test` . `t` where `a` = ?", rows[0][0])
+	require.Equal(t, bindinfo.Manual, rows[0][8])
+	require.Equal(t, []any{"<nil>", "<nil>", "<nil>", "<nil>"}, rows[0][12:16])
+
+	require.Equal(t, "select * from `test` . `t` where `a` > ?", rows[1][0])
+	require.Equal(t, bindinfo.Capture, rows[1][8])
+	require.Equal(t, "root", rows[1][12])
+	require.Contains(t, rows[1][13], "select * from t where a > ")
+	avgLatency, err := strconv.ParseInt(rows[1][14].(string), 10, 64)
+	require.NoError(t, err)
+	require.Greater(t, avgLatency, int64(0))
+	require.Equal(t, "3", rows[1][15])
+
+	// The provenance is kept after reloading the bindings from the storage.
+	dom.BindHandle().Clear()
+	require.NoError(t, dom.BindHandle().Update(true))
+	require.Equal(t, rows, tk.MustQuery("show global bindings").Sort().Rows())
+}
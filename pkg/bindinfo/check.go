@@ -0,0 +1,170 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+)
+
+const (
+	// ConflictContradictoryHints means there are several enabled bindings with different hints for the same statement.
+	ConflictContradictoryHints = "contradictory hints"
+	// ConflictDuplicateScope means there are bindings for the same statement in several default databases, e.g. both
+	// the universal one whose default database is empty and a db-scoped one.
+	ConflictDuplicateScope = "duplicate scope"
+	// ConflictEnabledDisabled means there are both enabled and disabled bindings for the same statement.
+	ConflictEnabledDisabled = "enabled and disabled"
+)
+
+// BindingConflict describes a group of conflicting bindings found by CheckBindings.
+type BindingConflict struct {
+	SQLDigest   string
+	OriginalSQL string
+	Type        string
+	// Bindings are the conflicting bindings, they may belong to different default databases.
+	Bindings []*BindRecord
+	Reason   string
+}
+
+// CheckBindings reads all the available global bindings from the storage and reports the conflicts among them.
+// The bindings are read from the storage instead of the cache, since the cache may not hold all of them.
+func (h *BindHandle) CheckBindings() ([]*BindingConflict, error) {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT original_sql, bind_sql, default_db, status, create_time,
+		update_time, charset, collation, source, sql_digest, plan_digest, comment,
+		capture_users, capture_sample_sql, capture_avg_latency, capture_exec_count FROM mysql.bind_info
+		WHERE original_sql != %? AND status IN (%?, %?, %?) ORDER BY update_time DESC`,
+		BuiltinPseudoSQL4BindLock, Enabled, Using, Disabled)
+	if err != nil {
+		return nil, err
+	}
+
+	// Group the bindings by the sql digest first, then by the default database.
+	groups := make(map[string]map[string]*BindRecord)
+	for _, row := range rows {
+		// The hints may fail to be prepared because of the changed schema, the bindings are compared
+		// by the bind sql in this case, so the error is ignored.
+		hash, meta, _ := h.newBindRecord(row)
+		group, ok := groups[hash]
+		if !ok {
+			group = make(map[string]*BindRecord)
+			groups[hash] = group
+		}
+		if record, ok := group[meta.Db]; ok {
+			record.Bindings = append(record.Bindings, meta.Bindings...)
+		} else {
+			group[meta.Db] = meta
+		}
+	}
+
+	conflicts := make([]*BindingConflict, 0)
+	for hash, group := range groups {
+		records := make([]*BindRecord, 0, len(group))
+		for _, record := range group {
+			records = append(records, record)
+		}
+		sort.Slice(records, func(i, j int) bool { return records[i].Db < records[j].Db })
+		for _, record := range records {
+			conflicts = append(conflicts, checkBindRecord(hash, record)...)
+		}
+		if conflict := checkBindingScope(hash, records); conflict != nil {
+			conflicts = append(conflicts, conflict)
+		}
+	}
+	sort.SliceStable(conflicts, func(i, j int) bool {
+		if conflicts[i].SQLDigest != conflicts[j].SQLDigest {
+			return conflicts[i].SQLDigest < conflicts[j].SQLDigest
+		}
+		return conflicts[i].Type < conflicts[j].Type
+	})
+	return conflicts, nil
+}
+
+// checkBindRecord checks the conflicts among the bindings of the same statement in the same default database.
+func checkBindRecord(hash string, record *BindRecord) []*BindingConflict {
+	var enabled, disabled []Binding
+	for i := range record.Bindings {
+		binding := &record.Bindings[i]
+		if binding.IsBindingEnabled() {
+			if !containsBinding(enabled, binding) {
+				enabled = append(enabled, *binding)
+			}
+		} else {
+			disabled = append(disabled, *binding)
+		}
+	}
+
+	var conflicts []*BindingConflict
+	if len(enabled) > 1 {
+		conflicts = append(conflicts, &BindingConflict{
+			SQLDigest:   hash,
+			OriginalSQL: record.OriginalSQL,
+			Type:        ConflictContradictoryHints,
+			Bindings:    []*BindRecord{{OriginalSQL: record.OriginalSQL, Db: record.Db, Bindings: enabled}},
+			Reason: fmt.Sprintf("%d enabled bindings with different hints exist in database '%s', only one of them can be used, "+
+				"drop the unexpected ones by 'DROP GLOBAL BINDING FOR ... USING ...'", len(enabled), record.Db),
+		})
+	}
+	if len(enabled) > 0 && len(disabled) > 0 {
+		bindings := make([]Binding, 0, len(enabled)+len(disabled))
+		bindings = append(bindings, enabled...)
+		bindings = append(bindings, disabled...)
+		conflicts = append(conflicts, &BindingConflict{
+			SQLDigest:   hash,
+			OriginalSQL: record.OriginalSQL,
+			Type:        ConflictEnabledDisabled,
+			Bindings:    []*BindRecord{{OriginalSQL: record.OriginalSQL, Db: record.Db, Bindings: bindings}},
+			Reason: fmt.Sprintf("both enabled and disabled bindings exist in database '%s', the disabled ones take no effect "+
+				"while the enabled ones exist, drop the bindings which are no longer needed", record.Db),
+		})
+	}
+	return conflicts
+}
+
+// checkBindingScope checks whether the statement has bindings in several default databases. Since the
+// bind cache only keeps one bind record for each statement, only one of them takes effect.
+func checkBindingScope(hash string, records []*BindRecord) *BindingConflict {
+	if len(records) < 2 {
+		return nil
+	}
+	dbs := make([]string, 0, len(records))
+	for _, record := range records {
+		dbs = append(dbs, record.Db)
+	}
+	return &BindingConflict{
+		SQLDigest:   hash,
+		OriginalSQL: records[0].OriginalSQL,
+		Type:        ConflictDuplicateScope,
+		Bindings:    records,
+		Reason: fmt.Sprintf("bindings exist in default databases '%s', only one of them is loaded and used, "+
+			"keep the expected one and drop the others", strings.Join(dbs, "', '")),
+	}
+}
+
+func containsBinding(bindings []Binding, binding *Binding) bool {
+	for i := range bindings {
+		if bindings[i].isSame(binding) {
+			return true
+		}
+	}
+	return false
+}
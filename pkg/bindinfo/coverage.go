@@ -0,0 +1,87 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"slices"
+	"time"
+
+	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
+)
+
+// BindingCoverage is the matching status of a global binding correlated with the statement summary.
+type BindingCoverage struct {
+	OriginalSQL string
+	Db          string
+	BindSQL     string
+	Status      string
+	Source      string
+	SQLDigest   string
+	// StmtDigests are the digests of the statements in the statement summary whose plans are generated by the binding.
+	StmtDigests []string
+	// ExecCount is the number of the executions whose plans are generated by the binding.
+	ExecCount int64
+	// LastMatchedTime is the last time the binding is matched, it is zero if the binding is never matched.
+	LastMatchedTime time.Time
+}
+
+// GetBindingsCoverage correlates the global bindings with the statements in the statement summary of this
+// instance, to report whether the bindings are matched by the statements executed since the specified time.
+// Only the statement summary in memory is referred to, so the time window is limited by its retention.
+func (h *BindHandle) GetBindingsCoverage(since time.Time) []*BindingCoverage {
+	matchedStmts := make(map[string][]*BindingCoverage)
+	var coverages []*BindingCoverage
+	for _, record := range h.GetAllBindRecord() {
+		for _, binding := range record.Bindings {
+			coverage := &BindingCoverage{
+				OriginalSQL: record.OriginalSQL,
+				Db:          record.Db,
+				BindSQL:     binding.BindSQL,
+				Status:      binding.Status,
+				Source:      binding.Source,
+				SQLDigest:   binding.SQLDigest,
+			}
+			matchedStmts[binding.BindSQL] = append(matchedStmts[binding.BindSQL], coverage)
+			coverages = append(coverages, coverage)
+		}
+	}
+	for _, stmt := range stmtsummaryv2.GetBindingMatchedStmts(since) {
+		for _, coverage := range matchedStmts[stmt.BindSQL] {
+			if !slices.Contains(coverage.StmtDigests, stmt.Digest) {
+				coverage.StmtDigests = append(coverage.StmtDigests, stmt.Digest)
+			}
+			coverage.ExecCount += stmt.ExecCount
+			if stmt.LastSeen.After(coverage.LastMatchedTime) {
+				coverage.LastMatchedTime = stmt.LastSeen
+			}
+		}
+	}
+	for _, coverage := range coverages {
+		slices.Sort(coverage.StmtDigests)
+	}
+	return coverages
+}
+
+// GetUnmatchedBindings returns the global bindings which are not matched by any statement executed since the
+// specified time, they are likely stale and can be cleaned.
+func (h *BindHandle) GetUnmatchedBindings(since time.Time) []*BindingCoverage {
+	var unmatched []*BindingCoverage
+	for _, coverage := range h.GetBindingsCoverage(since) {
+		if coverage.ExecCount == 0 {
+			unmatched = append(unmatched, coverage)
+		}
+	}
+	return unmatched
+}
@@ -0,0 +1,24 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"github.com/pingcap/tidb/pkg/util/stmtsummary"
+)
+
+// FailoverBindings4Test is the same as FailoverBindings, but with the specified statements of the statement summary.
+func (h *BindHandle) FailoverBindings4Test(stmts []*stmtsummary.BindingMatchedStmt, ratio float64) ([]string, error) {
+	return h.failoverBindings(stmts, ratio)
+}
@@ -0,0 +1,204 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"github.com/pingcap/tidb/pkg/util/stmtsummary"
+	stmtsummaryv2 "github.com/pingcap/tidb/pkg/util/stmtsummary/v2"
+	"go.uber.org/zap"
+)
+
+// failoverMinExecCount is the minimum number of the executions with the enabled binding in both the latest
+// summary interval and the previous ones, to avoid switching the bindings because of a few slow executions.
+var failoverMinExecCount int64 = 10
+
+// bindingLatency is the execution latency of a binding summarized from the statement summary.
+type bindingLatency struct {
+	execCount  int64
+	sumLatency time.Duration
+}
+
+func (l *bindingLatency) avgLatency() time.Duration {
+	return l.sumLatency / time.Duration(l.execCount)
+}
+
+// FailoverBindings checks the latencies of the enabled global bindings which have standby bindings. If the
+// average latency of an enabled binding in the latest statement summary interval exceeds that in the previous
+// intervals by tidb_binding_failover_latency_ratio, the binding is set to standby and the most recently
+// updated standby binding is enabled instead. Only the statement summary of this instance is referred to, and
+// the statement summary persisted to files keeps only the current interval in memory, so no failover happens
+// in that case. It returns the sql digests of the switched bindings.
+func (h *BindHandle) FailoverBindings() ([]string, error) {
+	ratio := variable.BindingFailoverLatencyRatio.Load()
+	if ratio <= 0 {
+		return nil, nil
+	}
+	return h.failoverBindings(stmtsummaryv2.GetBindingMatchedStmts(time.Time{}), ratio)
+}
+
+func (h *BindHandle) failoverBindings(stmts []*stmtsummary.BindingMatchedStmt, ratio float64) (switched []string, err error) {
+	// The latencies of each bind sql in each summary interval, the intervals are identified by the begin time.
+	latencies := make(map[string]map[int64]*bindingLatency)
+	for _, stmt := range stmts {
+		intervals, ok := latencies[stmt.BindSQL]
+		if !ok {
+			intervals = make(map[int64]*bindingLatency)
+			latencies[stmt.BindSQL] = intervals
+		}
+		latency, ok := intervals[stmt.BeginTime.Unix()]
+		if !ok {
+			latency = &bindingLatency{}
+			intervals[stmt.BeginTime.Unix()] = latency
+		}
+		latency.execCount += stmt.ExecCount
+		latency.sumLatency += stmt.SumLatency
+	}
+	for _, record := range h.GetAllBindRecord() {
+		primary := record.FindEnabledBinding()
+		standby := record.findStandbyBinding()
+		if primary == nil || standby == nil {
+			continue
+		}
+		latest, baseline := splitLatencies(latencies[primary.BindSQL])
+		if latest.execCount < failoverMinExecCount || baseline.execCount < failoverMinExecCount {
+			continue
+		}
+		if float64(latest.avgLatency()) <= float64(baseline.avgLatency())*ratio {
+			continue
+		}
+		ok, err := h.switchStandbyBinding(record, primary, standby)
+		if err != nil {
+			return switched, err
+		}
+		if !ok {
+			continue
+		}
+		logutil.BgLogger().Warn("the enabled binding regresses, switch to the standby binding", zap.String("category", "sql-bind"),
+			zap.String("originalSQL", record.OriginalSQL), zap.String("db", record.Db),
+			zap.String("regressedBindSQL", primary.BindSQL), zap.String("standbyBindSQL", standby.BindSQL),
+			zap.Duration("latency", latest.avgLatency()), zap.Duration("baselineLatency", baseline.avgLatency()))
+		switched = append(switched, parser.DigestNormalized(record.OriginalSQL).String())
+	}
+	return switched, nil
+}
+
+// splitLatencies returns the latency in the latest interval and the total latency in the previous intervals.
+func splitLatencies(intervals map[int64]*bindingLatency) (latest, baseline bindingLatency) {
+	var latestBeginTime int64
+	for beginTime := range intervals {
+		if beginTime > latestBeginTime {
+			latestBeginTime = beginTime
+		}
+	}
+	for beginTime, latency := range intervals {
+		if beginTime == latestBeginTime {
+			latest = *latency
+			continue
+		}
+		baseline.execCount += latency.execCount
+		baseline.sumLatency += latency.sumLatency
+	}
+	return latest, baseline
+}
+
+// findStandbyBinding returns the most recently updated standby binding, or nil if there is none.
+func (br *BindRecord) findStandbyBinding() *Binding {
+	var standby *Binding
+	for i := range br.Bindings {
+		if br.Bindings[i].Status != Standby {
+			continue
+		}
+		if standby == nil || br.Bindings[i].UpdateTime.Compare(standby.UpdateTime) > 0 {
+			standby = &br.Bindings[i]
+		}
+	}
+	return standby
+}
+
+// switchStandbyBinding sets the enabled binding to standby and enables the standby binding in one transaction.
+// It returns false if the bindings have been changed by others.
+func (h *BindHandle) switchStandbyBinding(record *BindRecord, primary, standby *Binding) (ok bool, err error) {
+	h.bindInfo.Lock()
+	h.sctx.Lock()
+	defer func() {
+		h.sctx.Unlock()
+		h.bindInfo.Unlock()
+	}()
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	exec, _ := h.sctx.Context.(sqlexec.SQLExecutor)
+	_, err = exec.ExecuteInternal(ctx, "BEGIN PESSIMISTIC")
+	if err != nil {
+		return
+	}
+	var updateTs types.Time
+	defer func() {
+		if err != nil || !ok {
+			_, err1 := exec.ExecuteInternal(ctx, "ROLLBACK")
+			terror.Log(err1)
+			return
+		}
+
+		_, err = exec.ExecuteInternal(ctx, "COMMIT")
+		if err != nil {
+			ok = false
+			return
+		}
+
+		newRecord := record.shallowCopy()
+		for i := range newRecord.Bindings {
+			switch newRecord.Bindings[i].BindSQL {
+			case primary.BindSQL:
+				newRecord.Bindings[i].Status = Standby
+			case standby.BindSQL:
+				newRecord.Bindings[i].Status = Enabled
+			default:
+				continue
+			}
+			newRecord.Bindings[i].UpdateTime = updateTs
+		}
+		h.setBindRecord(parser.DigestNormalized(record.OriginalSQL).String(), newRecord)
+	}()
+
+	// Lock mysql.bind_info to synchronize with SetBindingStatus on other tidb instances.
+	if err = h.lockBindInfoTable(); err != nil {
+		return
+	}
+
+	updateTs = types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3)
+	updateTsStr := updateTs.String()
+	_, err = exec.ExecuteInternal(ctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND update_time < %? AND bind_sql = %? AND status IN (%?, %?)`,
+		Standby, updateTsStr, record.OriginalSQL, updateTsStr, primary.BindSQL, Using, Enabled)
+	if err != nil || h.sctx.Context.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+		return
+	}
+	_, err = exec.ExecuteInternal(ctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND update_time < %? AND bind_sql = %? AND status = %?`,
+		Enabled, updateTsStr, record.OriginalSQL, updateTsStr, standby.BindSQL, Standby)
+	if err != nil {
+		return
+	}
+	ok = h.sctx.Context.GetSessionVars().StmtCtx.AffectedRows() > 0
+	return
+}
@@ -15,15 +15,18 @@
 package bindinfo
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"runtime"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser"
@@ -58,14 +61,14 @@ type BindHandle struct {
 	// bindInfo caches the sql bind info from storage.
 	//
 	// The Mutex protects that there is only one goroutine changes the content
-	// of atomic.Value.
+	// of atomic.Value. The bindCache is updated in place, the atomic.Value is
+	// only replaced when the bindings are reloaded.
 	//
 	// NOTE: Concurrent Value Write:
 	//
 	//    bindInfo.Lock()
-	//    newCache := bindInfo.Value.Load()
-	//    do the write operation on the newCache
-	//    bindInfo.Value.Store(newCache)
+	//    cache := bindInfo.Value.Load()
+	//    do the write operation on the cache
 	//    bindInfo.Unlock()
 	//
 	// NOTE: Concurrent Value Read:
@@ -86,11 +89,33 @@ type BindHandle struct {
 
 	// pendingVerifyBindRecordMap indicates the pending verify bind records that found during query.
 	pendingVerifyBindRecordMap tmpBindRecordMap
+
+	// warmUp records the progress of WarmUp.
+	warmUp struct {
+		total  atomic.Int64
+		loaded atomic.Int64
+	}
+
+	// healthCheckFailures records the reasons why the enabled bindings failed the latest health check.
+	healthCheckFailures struct {
+		sync.RWMutex
+		m map[bindingKey]string
+	}
+
+	// planDrifts records the bindings whose generated plans don't match their plan digests.
+	planDrifts struct {
+		sync.Mutex
+		m map[bindingKey]*BindingPlanDrift
+	}
 }
 
 // Lease influences the duration of loading bind info and handling invalid bind.
 var Lease = 3 * time.Second
 
+// AsyncWarmUp indicates whether to warm up the bind cache asynchronously on startup instead of
+// loading all the bind records synchronously.
+var AsyncWarmUp = false
+
 const (
 	// OwnerKey is the bindinfo owner path that is saved to etcd.
 	OwnerKey = "/tidb/bindinfo/owner"
@@ -124,11 +149,17 @@ func (h *BindHandle) Reset(ctx sessionctx.Context) {
 		_, err := h.DropBindRecord(record.OriginalSQL, record.Db, &record.Bindings[0])
 		return err
 	}
+	h.invalidBindRecordMap.taskType = pendingTaskDrop
+	h.invalidBindRecordMap.persistFunc = h.persistPendingTask
+	h.invalidBindRecordMap.removeFunc = h.removePendingTask
 	h.pendingVerifyBindRecordMap.Value.Store(make(map[string]*bindRecordUpdate))
 	h.pendingVerifyBindRecordMap.flushFunc = func(record *BindRecord) error {
 		// BindSQL has already been validated when coming here, so we use nil sctx parameter.
 		return h.AddBindRecord(nil, record)
 	}
+	h.pendingVerifyBindRecordMap.taskType = pendingTaskEvolve
+	h.pendingVerifyBindRecordMap.persistFunc = h.persistPendingTask
+	h.pendingVerifyBindRecordMap.removeFunc = h.removePendingTask
 	variable.RegisterStatistics(h)
 }
 
@@ -147,7 +178,8 @@ func (h *BindHandle) Update(fullLoad bool) (err error) {
 	// No need to acquire the session context lock for ExecRestrictedSQL, it
 	// uses another background session.
 	selectStmt := fmt.Sprintf(`SELECT original_sql, bind_sql, default_db, status, create_time,
-       update_time, charset, collation, source, sql_digest, plan_digest FROM mysql.bind_info
+       update_time, charset, collation, source, sql_digest, plan_digest, comment, capture_users, capture_sample_sql,
+       capture_avg_latency, capture_exec_count FROM mysql.bind_info
        %s ORDER BY update_time, create_time`, timeCondition)
 	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, selectStmt)
 
@@ -156,13 +188,27 @@ func (h *BindHandle) Update(fullLoad bool) (err error) {
 		return err
 	}
 
-	newCache, memExceededErr := h.bindInfo.Value.Load().(*bindCache).Copy()
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	memExceededErr := cache.resize(variable.MemQuotaBindingCache.Load())
 	defer func() {
 		h.bindInfo.lastUpdateTime = lastUpdateTime
-		h.bindInfo.Value.Store(newCache)
 		h.bindInfo.Unlock()
 	}()
 
+	lastUpdateTime, memExceededErr = h.loadRowsToCache(cache, rows, lastUpdateTime, memExceededErr)
+	if memExceededErr != nil {
+		// When the memory capacity of bing_cache is not enough,
+		// there will be some memory-related errors in multiple places.
+		// Only needs to be handled once.
+		logutil.BgLogger().Warn("BindHandle.Update", zap.String("category", "sql-bind"), zap.Error(memExceededErr))
+	}
+	return nil
+}
+
+// loadRowsToCache loads the bind records of rows read from mysql.bind_info into cache. It returns
+// the newest update time among lastUpdateTime and rows, and the error if the memory usage of cache
+// exceeds its capacity.
+func (h *BindHandle) loadRowsToCache(cache *bindCache, rows []chunk.Row, lastUpdateTime types.Time, memExceededErr error) (types.Time, error) {
 	for _, row := range rows {
 		// If the memory usage of the binding_cache exceeds its capacity, we will break and do not handle.
 		if memExceededErr != nil {
@@ -185,29 +231,23 @@ func (h *BindHandle) Update(fullLoad bool) (err error) {
 			continue
 		}
 
-		oldRecord := newCache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
+		oldRecord := cache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
 		newRecord := merge(oldRecord, meta).removeDeletedBindings()
 		if len(newRecord.Bindings) > 0 {
-			err = newCache.SetBindRecord(hash, newRecord)
+			err = cache.SetBindRecord(hash, newRecord)
 			if err != nil {
 				memExceededErr = err
 			}
 		} else {
-			newCache.RemoveBindRecord(hash, newRecord)
+			cache.RemoveBindRecord(hash, newRecord)
 		}
-		updateMetrics(metrics.ScopeGlobal, oldRecord, newCache.GetBindRecord(hash, meta.OriginalSQL, meta.Db), true)
-	}
-	if memExceededErr != nil {
-		// When the memory capacity of bing_cache is not enough,
-		// there will be some memory-related errors in multiple places.
-		// Only needs to be handled once.
-		logutil.BgLogger().Warn("BindHandle.Update", zap.String("category", "sql-bind"), zap.Error(memExceededErr))
+		updateMetrics(metrics.ScopeGlobal, oldRecord, cache.GetBindRecord(hash, meta.OriginalSQL, meta.Db), true)
 	}
-	return nil
+	return lastUpdateTime, memExceededErr
 }
 
 // CreateBindRecord creates a BindRecord to the storage and the cache.
-// It replaces all the exists bindings for the same normalized SQL.
+// It replaces all the exists bindings for the same normalized SQL, except the standby bindings.
 func (h *BindHandle) CreateBindRecord(sctx sessionctx.Context, record *BindRecord) (err error) {
 	err = record.prepareHints(sctx)
 	if err != nil {
@@ -215,6 +255,16 @@ func (h *BindHandle) CreateBindRecord(sctx sessionctx.Context, record *BindRecor
 	}
 
 	record.Db = strings.ToLower(record.Db)
+	sqlDigest := parser.DigestNormalized(record.OriginalSQL)
+	var standbyBindings []Binding
+	if oldRecord := h.GetBindRecord(sqlDigest.String(), record.OriginalSQL, record.Db); oldRecord != nil {
+		for _, binding := range oldRecord.Bindings {
+			if binding.Status == Standby && record.FindBinding(binding.ID) == nil {
+				standbyBindings = append(standbyBindings, binding)
+			}
+		}
+	}
+
 	h.bindInfo.Lock()
 	h.sctx.Lock()
 	defer func() {
@@ -240,8 +290,9 @@ func (h *BindHandle) CreateBindRecord(sctx sessionctx.Context, record *BindRecor
 			return
 		}
 
-		sqlDigest := parser.DigestNormalized(record.OriginalSQL)
-		h.setBindRecord(sqlDigest.String(), record)
+		cacheRecord := record.shallowCopy()
+		cacheRecord.Bindings = append(cacheRecord.Bindings, standbyBindings...)
+		h.setBindRecord(sqlDigest.String(), cacheRecord)
 	}()
 
 	// Lock mysql.bind_info to synchronize with CreateBindRecord / AddBindRecord / DropBindRecord on other tidb instances.
@@ -252,8 +303,17 @@ func (h *BindHandle) CreateBindRecord(sctx sessionctx.Context, record *BindRecor
 	now := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3)
 
 	updateTs := now.String()
-	_, err = exec.ExecuteInternal(ctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND update_time < %?`,
-		deleted, updateTs, record.OriginalSQL, updateTs)
+	if len(standbyBindings) == 0 {
+		_, err = exec.ExecuteInternal(ctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND update_time < %?`,
+			deleted, updateTs, record.OriginalSQL, updateTs)
+	} else {
+		standbySQLs := make([]string, 0, len(standbyBindings))
+		for _, binding := range standbyBindings {
+			standbySQLs = append(standbySQLs, binding.BindSQL)
+		}
+		_, err = exec.ExecuteInternal(ctx, `UPDATE mysql.bind_info SET status = %?, update_time = %? WHERE original_sql = %? AND update_time < %? AND bind_sql NOT IN (%?)`,
+			deleted, updateTs, record.OriginalSQL, updateTs, standbySQLs)
+	}
 	if err != nil {
 		return err
 	}
@@ -263,19 +323,7 @@ func (h *BindHandle) CreateBindRecord(sctx sessionctx.Context, record *BindRecor
 		record.Bindings[i].UpdateTime = now
 
 		// Insert the BindRecord to the storage.
-		_, err = exec.ExecuteInternal(ctx, `INSERT INTO mysql.bind_info VALUES (%?,%?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
-			record.OriginalSQL,
-			record.Bindings[i].BindSQL,
-			record.Db,
-			record.Bindings[i].Status,
-			record.Bindings[i].CreateTime.String(),
-			record.Bindings[i].UpdateTime.String(),
-			record.Bindings[i].Charset,
-			record.Bindings[i].Collation,
-			record.Bindings[i].Source,
-			record.Bindings[i].SQLDigest,
-			record.Bindings[i].PlanDigest,
-		)
+		err = insertBinding(ctx, exec, record, &record.Bindings[i])
 		if err != nil {
 			return err
 		}
@@ -364,19 +412,7 @@ func (h *BindHandle) AddBindRecord(sctx sessionctx.Context, record *BindRecord)
 			record.Bindings[i].SQLDigest = sqlDigestWithDB.String()
 		}
 		// Insert the BindRecord to the storage.
-		_, err = exec.ExecuteInternal(ctx, `INSERT INTO mysql.bind_info VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
-			record.OriginalSQL,
-			record.Bindings[i].BindSQL,
-			record.Db,
-			record.Bindings[i].Status,
-			record.Bindings[i].CreateTime.String(),
-			record.Bindings[i].UpdateTime.String(),
-			record.Bindings[i].Charset,
-			record.Bindings[i].Collation,
-			record.Bindings[i].Source,
-			record.Bindings[i].SQLDigest,
-			record.Bindings[i].PlanDigest,
-		)
+		err = insertBinding(ctx, exec, record, &record.Bindings[i])
 		if err != nil {
 			return err
 		}
@@ -439,6 +475,20 @@ func (h *BindHandle) DropBindRecord(originalSQL, db string, binding *Binding) (d
 	return h.sctx.Context.GetSessionVars().StmtCtx.AffectedRows(), nil
 }
 
+// DropBindingsByTable drops all the cached bind records whose statements reference the table `db.table`
+// from the storage and the cache. All the bind records referencing any table of the schema are dropped
+// if the table is empty.
+func (h *BindHandle) DropBindingsByTable(db, table string) (deletedRows uint64, err error) {
+	for _, record := range bindRecordsReferTable(h.GetAllBindRecord(), db, table) {
+		rows, err := h.DropBindRecord(record.OriginalSQL, record.Db, nil)
+		if err != nil {
+			return deletedRows, err
+		}
+		deletedRows += rows
+	}
+	return deletedRows, nil
+}
+
 // DropBindRecordByDigest drop BindRecord to the storage and BindRecord int the cache.
 func (h *BindHandle) DropBindRecordByDigest(sqlDigest string) (deletedRows uint64, err error) {
 	oldRecord, err := h.GetBindRecordBySQLDigest(sqlDigest)
@@ -473,9 +523,12 @@ func (h *BindHandle) SetBindRecordStatus(originalSQL string, binding *Binding, n
 		oldStatus0 = Using
 		oldStatus1 = Enabled
 	} else if newStatus == Enabled {
-		// In order to unify the code, two identical old statuses are set.
 		oldStatus0 = Disabled
-		oldStatus1 = Disabled
+		oldStatus1 = Standby
+	} else if newStatus == Standby {
+		// Only the bindings in use can be set to standby, the same as 'set binding disabled for <stmt>'.
+		oldStatus0 = Using
+		oldStatus1 = Enabled
 	}
 	defer func() {
 		if err != nil {
@@ -544,8 +597,8 @@ func (h *BindHandle) SetBindRecordStatusByDigest(newStatus, sqlDigest string) (o
 	return h.SetBindRecordStatus(oldRecord.OriginalSQL, nil, newStatus)
 }
 
-// GCBindRecord physically removes the deleted bind records in mysql.bind_info.
-func (h *BindHandle) GCBindRecord() (err error) {
+// GCBindRecord physically removes the deleted bind records in mysql.bind_info, and returns the number of removed rows.
+func (h *BindHandle) GCBindRecord() (deletedRows uint64, err error) {
 	h.bindInfo.Lock()
 	h.sctx.Lock()
 	defer func() {
@@ -556,7 +609,7 @@ func (h *BindHandle) GCBindRecord() (err error) {
 	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
 	_, err = exec.ExecuteInternal(ctx, "BEGIN PESSIMISTIC")
 	if err != nil {
-		return err
+		return 0, err
 	}
 	defer func() {
 		if err != nil {
@@ -573,15 +626,20 @@ func (h *BindHandle) GCBindRecord() (err error) {
 
 	// Lock mysql.bind_info to synchronize with CreateBindRecord / AddBindRecord / DropBindRecord on other tidb instances.
 	if err = h.lockBindInfoTable(); err != nil {
-		return err
+		return 0, err
 	}
 
 	// To make sure that all the deleted bind records have been acknowledged to all tidb,
-	// we only garbage collect those records with update_time before 10 leases.
-	updateTime := time.Now().Add(-(10 * Lease))
+	// we only garbage collect those records with update_time before 10 leases, or before the
+	// retention window specified by tidb_binding_gc_retention if it is longer.
+	retention := max(10*Lease, variable.BindingGCRetention.Load())
+	updateTime := time.Now().Add(-retention)
 	updateTimeStr := types.NewTime(types.FromGoTime(updateTime), mysql.TypeTimestamp, 3).String()
 	_, err = exec.ExecuteInternal(ctx, `DELETE FROM mysql.bind_info WHERE status = 'deleted' and update_time < %?`, updateTimeStr)
-	return err
+	if err != nil {
+		return 0, err
+	}
+	return h.sctx.Context.GetSessionVars().StmtCtx.AffectedRows(), nil
 }
 
 // lockBindInfoTable simulates `LOCK TABLE mysql.bind_info WRITE` by acquiring a pessimistic lock on a
@@ -613,6 +671,12 @@ type tmpBindRecordMap struct {
 	sync.Mutex
 	atomic.Value
 	flushFunc func(record *BindRecord) error
+	// taskType is the type of the pending tasks persisted in mysql.bind_pending_tasks for the bind records.
+	taskType string
+	// persistFunc and removeFunc save and delete the bind records in mysql.bind_pending_tasks, so the bind
+	// records not flushed yet can be replayed after restarts.
+	persistFunc func(taskType string, record *BindRecord) error
+	removeFunc  func(taskType string, record *BindRecord) error
 }
 
 // flushToStore calls flushFunc for items in tmpBindRecordMap and removes them with a delay.
@@ -624,7 +688,10 @@ func (tmpMap *tmpBindRecordMap) flushToStore() {
 		if bindRecord.updateTime.IsZero() {
 			err := tmpMap.flushFunc(bindRecord.bindRecord)
 			if err != nil {
+				// The pending task is kept in the storage to be retried after restarts.
 				logutil.BgLogger().Debug("flush bind record failed", zap.String("category", "sql-bind"), zap.Error(err))
+			} else if err = tmpMap.removeFunc(tmpMap.taskType, bindRecord.bindRecord); err != nil {
+				logutil.BgLogger().Warn("remove pending bind task failed", zap.String("category", "sql-bind"), zap.Error(err))
 			}
 			bindRecord.updateTime = time.Now()
 			continue
@@ -638,16 +705,25 @@ func (tmpMap *tmpBindRecordMap) flushToStore() {
 	tmpMap.Store(newMap)
 }
 
-// Add puts a BindRecord into tmpBindRecordMap.
+// Add puts a BindRecord into tmpBindRecordMap and persists it as a pending task.
 func (tmpMap *tmpBindRecordMap) Add(bindRecord *BindRecord) {
+	if tmpMap.add(bindRecord) {
+		if err := tmpMap.persistFunc(tmpMap.taskType, bindRecord); err != nil {
+			logutil.BgLogger().Warn("persist pending bind task failed", zap.String("category", "sql-bind"), zap.Error(err))
+		}
+	}
+}
+
+// add puts a BindRecord into tmpBindRecordMap, it returns false if the BindRecord exists already.
+func (tmpMap *tmpBindRecordMap) add(bindRecord *BindRecord) bool {
 	key := bindRecord.OriginalSQL + ":" + bindRecord.Db + ":" + bindRecord.Bindings[0].ID
 	if _, ok := tmpMap.Load().(map[string]*bindRecordUpdate)[key]; ok {
-		return
+		return false
 	}
 	tmpMap.Lock()
 	defer tmpMap.Unlock()
 	if _, ok := tmpMap.Load().(map[string]*bindRecordUpdate)[key]; ok {
-		return
+		return false
 	}
 	newMap := copyBindRecordUpdateMap(tmpMap.Load().(map[string]*bindRecordUpdate))
 	newMap[key] = &bindRecordUpdate{
@@ -655,6 +731,7 @@ func (tmpMap *tmpBindRecordMap) Add(bindRecord *BindRecord) {
 	}
 	tmpMap.Store(newMap)
 	updateMetrics(metrics.ScopeGlobal, nil, bindRecord, false)
+	return true
 }
 
 // DropInvalidBindRecord executes the drop BindRecord tasks.
@@ -704,6 +781,42 @@ func (h *BindHandle) GetMemCapacity() (memCapacity int64) {
 	return h.bindInfo.Load().(*bindCache).GetMemCapacity()
 }
 
+// GetBindCacheStatus returns the memory usage and eviction status of the bind cache.
+func (h *BindHandle) GetBindCacheStatus() *BindCacheStatus {
+	return h.bindInfo.Load().(*bindCache).GetStatus()
+}
+
+// insertBinding inserts the binding of the bind record into the storage.
+func insertBinding(ctx context.Context, exec sqlexec.SQLExecutor, record *BindRecord, binding *Binding) error {
+	args := []any{
+		record.OriginalSQL,
+		binding.BindSQL,
+		record.Db,
+		binding.Status,
+		binding.CreateTime.String(),
+		binding.UpdateTime.String(),
+		binding.Charset,
+		binding.Collation,
+		binding.Source,
+		binding.SQLDigest,
+		binding.PlanDigest,
+		nil, // comment
+		nil, // capture_users
+		nil, // capture_sample_sql
+		nil, // capture_avg_latency
+		nil, // capture_exec_count
+	}
+	// The empty comment and the provenance of the non-captured binding are stored as NULL.
+	if binding.Comment != "" {
+		args[11] = binding.Comment
+	}
+	if binding.Source == Capture {
+		args[12], args[13], args[14], args[15] = binding.CaptureUsers, binding.CaptureSampleSQL, binding.CaptureAvgLatency, binding.CaptureExecCount
+	}
+	_, err := exec.ExecuteInternal(ctx, `INSERT INTO mysql.bind_info VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`, args...)
+	return err
+}
+
 // newBindRecord builds BindRecord from a tuple in storage.
 func (h *BindHandle) newBindRecord(row chunk.Row) (string, *BindRecord, error) {
 	status := row.GetString(3)
@@ -721,6 +834,12 @@ func (h *BindHandle) newBindRecord(row chunk.Row) (string, *BindRecord, error) {
 		Source:     row.GetString(8),
 		SQLDigest:  row.GetString(9),
 		PlanDigest: row.GetString(10),
+		Comment:    row.GetString(11),
+
+		CaptureUsers:      row.GetString(12),
+		CaptureSampleSQL:  row.GetString(13),
+		CaptureAvgLatency: row.GetInt64(14),
+		CaptureExecCount:  row.GetInt64(15),
 	}
 	bindRecord := &BindRecord{
 		OriginalSQL: row.GetString(0),
@@ -738,47 +857,47 @@ func (h *BindHandle) newBindRecord(row chunk.Row) (string, *BindRecord, error) {
 // setBindRecord sets the BindRecord to the cache, if there already exists a BindRecord,
 // it will be overridden.
 func (h *BindHandle) setBindRecord(hash string, meta *BindRecord) {
-	newCache, err0 := h.bindInfo.Value.Load().(*bindCache).Copy()
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	err0 := cache.resize(variable.MemQuotaBindingCache.Load())
 	if err0 != nil {
 		logutil.BgLogger().Warn("BindHandle.setBindRecord", zap.String("category", "sql-bind"), zap.Error(err0))
 	}
-	oldRecord := newCache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
-	err1 := newCache.SetBindRecord(hash, meta)
+	oldRecord := cache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
+	err1 := cache.SetBindRecord(hash, meta)
 	if err1 != nil && err0 == nil {
 		logutil.BgLogger().Warn("BindHandle.setBindRecord", zap.String("category", "sql-bind"), zap.Error(err1))
 	}
-	h.bindInfo.Value.Store(newCache)
 	updateMetrics(metrics.ScopeGlobal, oldRecord, meta, false)
 }
 
 // appendBindRecord adds the BindRecord to the cache, all the stale BindRecords are
 // removed from the cache after this operation.
 func (h *BindHandle) appendBindRecord(hash string, meta *BindRecord) {
-	newCache, err0 := h.bindInfo.Value.Load().(*bindCache).Copy()
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	err0 := cache.resize(variable.MemQuotaBindingCache.Load())
 	if err0 != nil {
 		logutil.BgLogger().Warn("BindHandle.appendBindRecord", zap.String("category", "sql-bind"), zap.Error(err0))
 	}
-	oldRecord := newCache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
+	oldRecord := cache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
 	newRecord := merge(oldRecord, meta)
-	err1 := newCache.SetBindRecord(hash, newRecord)
+	err1 := cache.SetBindRecord(hash, newRecord)
 	if err1 != nil && err0 == nil {
 		// Only need to handle the error once.
 		logutil.BgLogger().Warn("BindHandle.appendBindRecord", zap.String("category", "sql-bind"), zap.Error(err1))
 	}
-	h.bindInfo.Value.Store(newCache)
 	updateMetrics(metrics.ScopeGlobal, oldRecord, newRecord, false)
 }
 
 // removeBindRecord removes the BindRecord from the cache.
 func (h *BindHandle) removeBindRecord(hash string, meta *BindRecord) {
-	newCache, err := h.bindInfo.Value.Load().(*bindCache).Copy()
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	err := cache.resize(variable.MemQuotaBindingCache.Load())
 	if err != nil {
 		logutil.BgLogger().Warn("", zap.String("category", "sql-bind"), zap.Error(err))
 	}
-	oldRecord := newCache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
-	newCache.RemoveBindRecord(hash, meta)
-	h.bindInfo.Value.Store(newCache)
-	updateMetrics(metrics.ScopeGlobal, oldRecord, newCache.GetBindRecord(hash, meta.OriginalSQL, meta.Db), false)
+	oldRecord := cache.GetBindRecord(hash, meta.OriginalSQL, meta.Db)
+	cache.RemoveBindRecord(hash, meta)
+	updateMetrics(metrics.ScopeGlobal, oldRecord, cache.GetBindRecord(hash, meta.OriginalSQL, meta.Db), false)
 }
 
 func copyBindRecordUpdateMap(oldMap map[string]*bindRecordUpdate) map[string]*bindRecordUpdate {
@@ -789,8 +908,11 @@ func copyBindRecordUpdateMap(oldMap map[string]*bindRecordUpdate) map[string]*bi
 
 type captureFilter struct {
 	frequency int64
-	tables    []tablefilter.Filter // `schema.table`
-	users     map[string]struct{}
+	// latency and processedKeys are the minimum average latency and processed keys of the captured statements.
+	latency       time.Duration
+	processedKeys int64
+	tables        []tablefilter.Filter // `schema.table`
+	users         map[string]struct{}
 
 	fail      bool
 	currentDB string
@@ -822,6 +944,38 @@ func (cf *captureFilter) isEmpty() bool {
 	return len(cf.tables) == 0 && len(cf.users) == 0
 }
 
+// bindRecordsReferTable returns the bind records whose statements reference the table `db.table`, or any
+// table of the schema `db` if the table is empty.
+func bindRecordsReferTable(records []*BindRecord, db, table string) []*BindRecord {
+	var filter tablefilter.Filter
+	if table == "" {
+		filter = tablefilter.NewSchemasFilter(strings.ToLower(db))
+	} else {
+		filter = tablefilter.NewTablesFilter(tablefilter.Table{Schema: strings.ToLower(db), Name: strings.ToLower(table)})
+	}
+	cf := &captureFilter{tables: []tablefilter.Filter{filter}}
+	p := parser.New()
+	matched := make([]*BindRecord, 0)
+	for _, record := range records {
+		if len(record.Bindings) == 0 {
+			continue
+		}
+		stmt, err := p.ParseOneStmt(record.OriginalSQL, record.Bindings[0].Charset, record.Bindings[0].Collation)
+		if err != nil {
+			logutil.BgLogger().Warn("parse error for bind record", zap.String("category", "sql-bind"),
+				zap.String("originalSQL", record.OriginalSQL), zap.Error(err))
+			continue
+		}
+		cf.fail = false
+		cf.currentDB = record.Db
+		stmt.Accept(cf)
+		if cf.fail {
+			matched = append(matched, record)
+		}
+	}
+	return matched
+}
+
 // ParseCaptureTableFilter checks whether this filter is valid and parses it.
 func ParseCaptureTableFilter(tableFilter string) (f tablefilter.Filter, valid bool) {
 	// forbid wildcards '!' and '@' for safety,
@@ -881,6 +1035,24 @@ func (h *BindHandle) extractCaptureFilterFromStorage() (filter *captureFilter) {
 			if f > filter.frequency {
 				filter.frequency = f
 			}
+		case "latency":
+			d, err := time.ParseDuration(valStr)
+			if err != nil {
+				logutil.BgLogger().Warn("failed to parse latency type value, ignore it", zap.String("category", "sql-bind"), zap.String("filter_value", valStr), zap.Error(err))
+				continue
+			}
+			if d > filter.latency {
+				filter.latency = d
+			}
+		case "processed_keys":
+			k, err := strconv.ParseInt(valStr, 10, 64)
+			if err != nil {
+				logutil.BgLogger().Warn("failed to parse processed_keys type value, ignore it", zap.String("category", "sql-bind"), zap.String("filter_value", valStr), zap.Error(err))
+				continue
+			}
+			if k > filter.processedKeys {
+				filter.processedKeys = k
+			}
 		default:
 			logutil.BgLogger().Warn("unknown capture filter type, ignore it", zap.String("category", "sql-bind"), zap.String("filter_type", filterTp))
 		}
@@ -903,6 +1075,10 @@ func (h *BindHandle) CaptureBaselines() {
 		if insertStmt, ok := stmt.(*ast.InsertStmt); ok && insertStmt.Select == nil {
 			continue
 		}
+		// Only capture the statements which are expensive enough, to skip the frequent cheap ones like point gets.
+		if bindableStmt.AvgLatency < captureFilter.latency || bindableStmt.AvgProcessedKeys < captureFilter.processedKeys {
+			continue
+		}
 		if !emptyCaptureFilter {
 			captureFilter.fail = false
 			captureFilter.currentDB = bindableStmt.Schema
@@ -929,13 +1105,18 @@ func (h *BindHandle) CaptureBaselines() {
 		if r := h.GetBindRecord(digest.String(), normalizedSQL, dbName); r != nil && r.HasAvailableBinding() {
 			continue
 		}
-		bindSQL := GenerateBindSQL(context.TODO(), stmt, bindableStmt.PlanHint, true, dbName)
+		bindSQL := GenerateBindSQL(context.TODO(), stmt, bindableStmt.PlanHint, dbName)
 		if bindSQL == "" {
 			continue
 		}
 		h.sctx.Lock()
 		charset, collation := h.sctx.GetSessionVars().GetCharsetInfo()
 		h.sctx.Unlock()
+		users := make([]string, 0, len(bindableStmt.Users))
+		for user := range bindableStmt.Users {
+			users = append(users, user)
+		}
+		slices.Sort(users)
 		binding := Binding{
 			BindSQL:   bindSQL,
 			Status:    Enabled,
@@ -943,6 +1124,11 @@ func (h *BindHandle) CaptureBaselines() {
 			Collation: collation,
 			Source:    Capture,
 			SQLDigest: digest.String(),
+
+			CaptureUsers:      strings.Join(users, ","),
+			CaptureSampleSQL:  bindableStmt.SampleSQL,
+			CaptureAvgLatency: int64(bindableStmt.AvgLatency),
+			CaptureExecCount:  bindableStmt.ExecCount,
 		}
 		// We don't need to pass the `sctx` because the BindSQL has been validated already.
 		err = h.CreateBindRecord(nil, &BindRecord{OriginalSQL: normalizedSQL, Db: dbName, Bindings: []Binding{binding}})
@@ -983,20 +1169,13 @@ func getHintsForSQL(sctx sessionctx.Context, sql string) (string, error) {
 }
 
 // GenerateBindSQL generates binding sqls from stmt node and plan hints.
-func GenerateBindSQL(ctx context.Context, stmtNode ast.StmtNode, planHint string, skipCheckIfHasParam bool, defaultDB string) string {
+// The parameter markers are kept in the binding sql, they are filled with the parameter values sampled from
+// the statement summary when the binding is verified by plan evolution.
+func GenerateBindSQL(ctx context.Context, stmtNode ast.StmtNode, planHint string, defaultDB string) string {
 	// If would be nil for very simple cases such as point get, we do not need to evolve for them.
 	if planHint == "" {
 		return ""
 	}
-	if !skipCheckIfHasParam {
-		paramChecker := &paramMarkerChecker{}
-		stmtNode.Accept(paramChecker)
-		// We need to evolve on current sql, but we cannot restore values for paramMarkers yet,
-		// so just ignore them now.
-		if paramChecker.hasParamMarker {
-			return ""
-		}
-	}
 	// We need to evolve plan based on the current sql, not the original sql which may have different parameters.
 	// So here we would remove the hint and inject the current best plan hint.
 	hint.BindHint(stmtNode, &hint.HintsSet{})
@@ -1050,12 +1229,12 @@ func GenerateBindSQL(ctx context.Context, stmtNode ast.StmtNode, planHint string
 }
 
 type paramMarkerChecker struct {
-	hasParamMarker bool
+	markers []*driver.ParamMarkerExpr
 }
 
 func (e *paramMarkerChecker) Enter(in ast.Node) (ast.Node, bool) {
-	if _, ok := in.(*driver.ParamMarkerExpr); ok {
-		e.hasParamMarker = true
+	if marker, ok := in.(*driver.ParamMarkerExpr); ok {
+		e.markers = append(e.markers, marker)
 		return in, true
 	}
 	return in, false
@@ -1065,6 +1244,24 @@ func (*paramMarkerChecker) Leave(in ast.Node) (ast.Node, bool) {
 	return in, true
 }
 
+// paramMarkerFiller replaces the parameter markers with the values.
+type paramMarkerFiller struct {
+	values map[*driver.ParamMarkerExpr]types.Datum
+}
+
+func (*paramMarkerFiller) Enter(in ast.Node) (ast.Node, bool) {
+	return in, false
+}
+
+func (f *paramMarkerFiller) Leave(in ast.Node) (ast.Node, bool) {
+	if marker, ok := in.(*driver.ParamMarkerExpr); ok {
+		if val, ok := f.values[marker]; ok {
+			return ast.NewValueExpr(val.GetValue(), marker.GetType().GetCharset(), marker.GetType().GetCollate()), true
+		}
+	}
+	return in, true
+}
+
 // AddEvolvePlanTask adds the evolve plan task into memory cache. It would be flushed to store periodically.
 func (h *BindHandle) AddEvolvePlanTask(originalSQL, db string, binding Binding) {
 	br := &BindRecord{
@@ -1128,30 +1325,55 @@ const (
 	verifyTimeoutFactor = 2.0
 	// nextVerifyDuration is the duration that we will retry the rejected plans.
 	nextVerifyDuration = 7 * 24 * time.Hour
+	// maxVerifyParamSamples is the max number of sampled parameters used to verify a parameterized binding.
+	maxVerifyParamSamples = 3
 )
 
-func (h *BindHandle) getOnePendingVerifyJob() (originalSQL, db string, binding Binding) {
+// evolveJob is a pending verify or rejected binding waiting for plan evolution.
+type evolveJob struct {
+	originalSQL string
+	db          string
+	binding     Binding
+}
+
+// getPendingVerifyJobs returns all the bindings waiting for verification. The jobs of different
+// sql digests are interleaved, so a digest with many pending bindings can't starve the others.
+func (h *BindHandle) getPendingVerifyJobs() []*evolveJob {
 	cache := h.bindInfo.Value.Load().(*bindCache)
+	var queues [][]*evolveJob
+	total := 0
 	for _, bindRecord := range cache.GetAllBindRecords() {
+		var queue []*evolveJob
 		for _, bind := range bindRecord.Bindings {
-			if bind.Status == PendingVerify {
-				return bindRecord.OriginalSQL, bindRecord.Db, bind
-			}
-			if bind.Status != Rejected {
-				continue
-			}
-			dur, err := bind.SinceUpdateTime()
-			// Should not happen.
-			if err != nil {
+			if bind.Status == Rejected {
+				dur, err := bind.SinceUpdateTime()
+				// Should not happen.
+				if err != nil {
+					continue
+				}
+				// Retry the rejected binding only after nextVerifyDuration.
+				if dur <= nextVerifyDuration {
+					continue
+				}
+			} else if bind.Status != PendingVerify {
 				continue
 			}
-			// Rejected and retry it now.
-			if dur > nextVerifyDuration {
-				return bindRecord.OriginalSQL, bindRecord.Db, bind
+			queue = append(queue, &evolveJob{originalSQL: bindRecord.OriginalSQL, db: bindRecord.Db, binding: bind})
+		}
+		if len(queue) > 0 {
+			queues = append(queues, queue)
+			total += len(queue)
+		}
+	}
+	jobs := make([]*evolveJob, 0, total)
+	for i := 0; len(jobs) < total; i++ {
+		for _, queue := range queues {
+			if i < len(queue) {
+				jobs = append(jobs, queue[i])
 			}
 		}
 	}
-	return "", "", Binding{}
+	return jobs
 }
 
 func (*BindHandle) getRunningDuration(sctx sessionctx.Context, db, sql string, maxTime time.Duration) (time.Duration, error) {
@@ -1211,55 +1433,185 @@ func runSQL(ctx context.Context, sctx sessionctx.Context, sql string, resultChan
 	resultChan <- err
 }
 
-// HandleEvolvePlanTask tries to evolve one plan task.
-// It only processes one task at a time because we want each task to use the latest parameters.
-func (h *BindHandle) HandleEvolvePlanTask(sctx sessionctx.Context, adminEvolve bool) error {
-	originalSQL, db, binding := h.getOnePendingVerifyJob()
-	if originalSQL == "" {
+// HandleEvolvePlanTask verifies the pending bindings concurrently, each session in sctxs runs one verification
+// at a time, so the concurrency is len(sctxs). The evolve parameters are read once for each round, and the
+// workers stop picking new jobs once the evolution window is over unless it is triggered by ADMIN EVOLVE BINDINGS.
+func (h *BindHandle) HandleEvolvePlanTask(sctxs []sessionctx.Context, adminEvolve bool) error {
+	jobs := h.getPendingVerifyJobs()
+	if len(jobs) == 0 {
 		return nil
 	}
-	maxTime, startTime, endTime, err := getEvolveParameters(sctx)
+	maxTime, startTime, endTime, err := getEvolveParameters(sctxs[0])
 	if err != nil {
 		return err
 	}
-	if maxTime == 0 || (!timeutil.WithinDayTimePeriod(startTime, endTime, time.Now()) && !adminEvolve) {
+	inWindow := func() bool {
+		return adminEvolve || timeutil.WithinDayTimePeriod(startTime, endTime, time.Now())
+	}
+	if maxTime == 0 || !inWindow() {
 		return nil
 	}
-	sctx.GetSessionVars().UsePlanBaselines = true
-	currentPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
-	// If we just return the error to the caller, this job will be retried again and again and cause endless logs,
-	// since it is still in the bind record. Now we just drop it and if it is actually retryable,
-	// we will hope for that we can capture this evolve task again.
-	if err != nil {
-		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
+
+	jobCh := make(chan *evolveJob, len(jobs))
+	for _, job := range jobs {
+		jobCh <- job
+	}
+	close(jobCh)
+	concurrency := len(sctxs)
+	if concurrency > len(jobs) {
+		concurrency = len(jobs)
+	}
+	errs := make([]error, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for job := range jobCh {
+				if !inWindow() {
+					return
+				}
+				if err := h.evolvePlan(sctxs[i], job, maxTime); err != nil && errs[i] == nil {
+					errs[i] = err
+				}
+			}
+		}(i)
 	}
-	// If the accepted plan timeouts, it is hard to decide the timeout for verify plan.
-	// Currently we simply mark the verify plan as `using` if it could run successfully within maxTime.
-	if currentPlanTime > 0 {
-		maxTime = time.Duration(float64(currentPlanTime) * verifyTimeoutFactor)
+	wg.Wait()
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
 	}
-	sctx.GetSessionVars().UsePlanBaselines = false
-	verifyPlanTime, err := h.getRunningDuration(sctx, db, binding.BindSQL, maxTime)
-	if err != nil {
-		_, err = h.DropBindRecord(originalSQL, db, &binding)
-		return err
+	return nil
+}
+
+// evolvePlan verifies one binding by comparing its running duration with the accepted plan. If the binding
+// sql has parameter markers, the binding is verified against each of the sampled parameters, and it is
+// accepted only if it performs better with all of them.
+func (h *BindHandle) evolvePlan(sctx sessionctx.Context, job *evolveJob, maxTime time.Duration) error {
+	originalSQL, db, binding := job.originalSQL, job.db, job.binding
+	verifySQLs := getVerifySQLs(job)
+	// The parameters of the statement are not sampled yet, verify it in the next round.
+	if len(verifySQLs) == 0 {
+		return nil
 	}
-	if verifyPlanTime == -1 || (float64(verifyPlanTime)*acceptFactor > float64(currentPlanTime)) {
-		binding.Status = Rejected
-		digestText, _ := parser.NormalizeDigest(binding.BindSQL) // for log desensitization
-		logutil.BgLogger().Debug("new plan rejected", zap.String("category", "sql-bind"),
-			zap.Duration("currentPlanTime", currentPlanTime),
-			zap.Duration("verifyPlanTime", verifyPlanTime),
-			zap.String("digestText", digestText),
-		)
-	} else {
-		binding.Status = Enabled
+	binding.Status = Enabled
+	for _, sql := range verifySQLs {
+		sctx.GetSessionVars().UsePlanBaselines = true
+		currentPlanTime, err := h.getRunningDuration(sctx, db, sql, maxTime)
+		// If we just return the error to the caller, this job will be retried again and again and cause endless logs,
+		// since it is still in the bind record. Now we just drop it and if it is actually retryable,
+		// we will hope for that we can capture this evolve task again.
+		if err != nil {
+			_, err = h.DropBindRecord(originalSQL, db, &binding)
+			return err
+		}
+		// If the accepted plan timeouts, it is hard to decide the timeout for verify plan.
+		// Currently we simply mark the verify plan as `using` if it could run successfully within maxTime.
+		verifyMaxTime := maxTime
+		if currentPlanTime > 0 {
+			verifyMaxTime = time.Duration(float64(currentPlanTime) * verifyTimeoutFactor)
+		}
+		sctx.GetSessionVars().UsePlanBaselines = false
+		verifyPlanTime, err := h.getRunningDuration(sctx, db, sql, verifyMaxTime)
+		if err != nil {
+			_, err = h.DropBindRecord(originalSQL, db, &binding)
+			return err
+		}
+		if verifyPlanTime == -1 || (float64(verifyPlanTime)*acceptFactor > float64(currentPlanTime)) {
+			binding.Status = Rejected
+			digestText, _ := parser.NormalizeDigest(binding.BindSQL) // for log desensitization
+			logutil.BgLogger().Debug("new plan rejected", zap.String("category", "sql-bind"),
+				zap.Duration("currentPlanTime", currentPlanTime),
+				zap.Duration("verifyPlanTime", verifyPlanTime),
+				zap.String("digestText", digestText),
+			)
+			break
+		}
 	}
 	// We don't need to pass the `sctx` because the BindSQL has been validated already.
 	return h.AddBindRecord(nil, &BindRecord{OriginalSQL: originalSQL, Db: db, Bindings: []Binding{binding}})
 }
 
+// getVerifySQLs returns the sqls to verify the binding. If the binding sql has parameter markers, they are filled
+// with the parameters sampled from the statement summary. Nothing is returned if no parameter is sampled.
+func getVerifySQLs(job *evolveJob) []string {
+	binding := &job.binding
+	p := parser.New()
+	stmt, err := p.ParseOneStmt(binding.BindSQL, binding.Charset, binding.Collation)
+	if err != nil {
+		// Let the verification report the error.
+		return []string{binding.BindSQL}
+	}
+	checker := &paramMarkerChecker{}
+	stmt.Accept(checker)
+	if len(checker.markers) == 0 {
+		return []string{binding.BindSQL}
+	}
+	sqls := make([]string, 0, maxVerifyParamSamples)
+	for _, params := range getParamSamples(p, job.originalSQL) {
+		sql, err := fillParamMarkers(p, binding, params)
+		if err != nil {
+			logutil.BgLogger().Debug("fill parameters of binding failed", zap.String("category", "sql-bind"), zap.Error(err))
+			continue
+		}
+		sqls = append(sqls, sql)
+		if len(sqls) >= maxVerifyParamSamples {
+			break
+		}
+	}
+	return sqls
+}
+
+// fillParamMarkers returns the binding sql whose parameter markers are replaced by the params.
+func fillParamMarkers(p *parser.Parser, binding *Binding, params []types.Datum) (string, error) {
+	stmt, err := p.ParseOneStmt(binding.BindSQL, binding.Charset, binding.Collation)
+	if err != nil {
+		return "", err
+	}
+	checker := &paramMarkerChecker{}
+	stmt.Accept(checker)
+	if len(checker.markers) != len(params) {
+		return "", errors.Errorf("%d parameters are sampled but %d are expected", len(params), len(checker.markers))
+	}
+	// The parameter markers are not visited in the order of their positions in the sql.
+	slices.SortFunc(checker.markers, func(a, b *driver.ParamMarkerExpr) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+	filler := &paramMarkerFiller{values: make(map[*driver.ParamMarkerExpr]types.Datum, len(params))}
+	for i, marker := range checker.markers {
+		filler.values[marker] = params[i]
+	}
+	stmt.Accept(filler)
+	var sb strings.Builder
+	if err := stmt.Restore(format.NewRestoreCtx(format.DefaultRestoreFlags, &sb)); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// getParamSamples returns the parameters sampled from the executions of the prepared statement whose
+// normalized sql is originalSQL.
+func getParamSamples(p *parser.Parser, originalSQL string) [][]types.Datum {
+	var samples [][]types.Datum
+	for _, bindableStmt := range stmtsummaryv2.GetMoreThanCntBindableStmt(0) {
+		if len(bindableStmt.ParamSamples) == 0 {
+			continue
+		}
+		stmt, err := p.ParseOneStmt(bindableStmt.Query, bindableStmt.Charset, bindableStmt.Collation)
+		if err != nil {
+			continue
+		}
+		dbName := utilparser.GetDefaultDB(stmt, bindableStmt.Schema)
+		normalizedSQL, _ := parser.NormalizeDigest(utilparser.RestoreWithDefaultDB(stmt, dbName, bindableStmt.Query))
+		if normalizedSQL == originalSQL {
+			samples = append(samples, bindableStmt.ParamSamples...)
+		}
+	}
+	return samples
+}
+
 // Clear resets the bind handle. It is only used for test.
 func (h *BindHandle) Clear() {
 	h.bindInfo.Lock()
@@ -17,7 +17,10 @@ package bindinfo_test
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/bindinfo"
 	"github.com/pingcap/tidb/pkg/bindinfo/internal"
@@ -25,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/testkit"
+	"github.com/pingcap/tidb/pkg/util/stmtsummary"
 	dto "github.com/prometheus/client_model/go"
 	"github.com/stretchr/testify/require"
 )
@@ -93,7 +97,7 @@ func TestBindingLastUpdateTimeWithInvalidBind(t *testing.T) {
 	require.Equal(t, updateTime0, "0000-00-00 00:00:00")
 
 	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t`', 'select * from `test` . `t` use index(`idx`)', 'test', 'enabled', '2000-01-01 09:00:00', '2000-01-01 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	tk.MustExec("use test")
 	tk.MustExec("drop table if exists t")
 	tk.MustExec("create table t(a int)")
@@ -208,7 +212,7 @@ func TestEvolveInvalidBindings(t *testing.T) {
 	tk.MustExec("create global binding for select * from t where a > 10 using select /*+ USE_INDEX(t) */ * from t where a > 10")
 	// Manufacture a rejected binding by hacking mysql.bind_info.
 	tk.MustExec("insert into mysql.bind_info values('select * from test . t where a > ?', 'SELECT /*+ USE_INDEX(t,idx_a) */ * FROM test.t WHERE a > 10', 'test', 'rejected', '2000-01-01 09:00:00', '2000-01-01 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	tk.MustQuery("select bind_sql, status from mysql.bind_info where source != 'builtin'").Sort().Check(testkit.Rows(
 		"SELECT /*+ USE_INDEX(`t` )*/ * FROM `test`.`t` WHERE `a` > 10 enabled",
 		"SELECT /*+ USE_INDEX(t,idx_a) */ * FROM test.t WHERE a > 10 rejected",
@@ -308,9 +312,9 @@ func TestSetBindingStatusWithoutBindingInCache(t *testing.T) {
 
 	// Simulate creating bindings on other machines
 	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t` where `a` > ?', 'SELECT /*+ USE_INDEX(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` > 10', 'test', 'deleted', '2000-01-01 09:00:00', '2000-01-01 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t` where `a` > ?', 'SELECT /*+ USE_INDEX(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` > 10', 'test', 'enabled', '2000-01-02 09:00:00', '2000-01-02 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	dom.BindHandle().Clear()
 	tk.MustExec("set binding disabled for select * from t where a > 10")
 	tk.MustExec("admin reload bindings")
@@ -323,9 +327,9 @@ func TestSetBindingStatusWithoutBindingInCache(t *testing.T) {
 
 	// Simulate creating bindings on other machines
 	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t` where `a` > ?', 'SELECT * FROM `test`.`t` WHERE `a` > 10', 'test', 'deleted', '2000-01-01 09:00:00', '2000-01-01 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t` where `a` > ?', 'SELECT * FROM `test`.`t` WHERE `a` > 10', 'test', 'disabled', '2000-01-02 09:00:00', '2000-01-02 09:00:00', '', '','" +
-		bindinfo.Manual + "', '', '')")
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
 	dom.BindHandle().Clear()
 	tk.MustExec("set binding enabled for select * from t where a > 10")
 	tk.MustExec("admin reload bindings")
@@ -607,3 +611,188 @@ func TestReloadBindings(t *testing.T) {
 	rows = tk.MustQuery("show global bindings").Rows()
 	require.Equal(t, 0, len(rows))
 }
+
+func TestWarmUpBindings(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx(a))")
+	tk.MustExec("create global binding for select a from t using select a from t use index(idx)")
+	tk.MustExec("create global binding for select b from t using select b from t use index(idx)")
+	tk.MustExec("create global binding for select a, b from t using select a, b from t use index(idx)")
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t use index(idx) where a = 1")
+	tk.MustExec("create global binding for select * from t where b = 1 using select * from t use index(idx) where b = 1")
+	tk.MustExec("set binding disabled for select a from t")
+
+	h := dom.BindHandle()
+	h.Clear()
+	require.Equal(t, 0, len(h.GetAllBindRecord()))
+	require.NoError(t, h.WarmUp(2, nil))
+	require.Equal(t, 5, len(h.GetAllBindRecord()))
+	tk.MustQuery("show status like 'binding_cache_warm_up%'").Sort().Check(testkit.Rows(
+		"binding_cache_warm_up_loaded 5", "binding_cache_warm_up_total 5"))
+	rows := tk.MustQuery("show global bindings where original_sql like 'select `a` from%'").Rows()
+	require.Equal(t, 1, len(rows))
+	require.Equal(t, bindinfo.Disabled, rows[0][3])
+
+	// The bindings created after warming up are loaded by Update(false).
+	tk.MustExec("insert into mysql.bind_info values('select * from `test` . `t`', 'select * from `test` . `t` use index(`idx`)', 'test', 'enabled', now(3) + interval 1 second, now(3) + interval 1 second, '', '','" +
+		bindinfo.Manual + "', '', '', NULL, NULL, NULL, NULL, NULL)")
+	require.NoError(t, h.Update(false))
+	require.Equal(t, 6, len(h.GetAllBindRecord()))
+}
+
+func TestFailoverBindings(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 and b = 1 using select /*+ use_index(t, idx_a) */ * from t where a = 1 and b = 1")
+	tk.MustExec("set binding standby for select * from t where a = 1 and b = 1")
+	require.Equal(t, bindinfo.Standby, tk.MustQuery("show global bindings").Rows()[0][3])
+	tk.MustExec("select * from t where a = 1 and b = 1")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+
+	// The standby bindings are kept when creating a new binding.
+	tk.MustExec("create global binding for select * from t where a = 1 and b = 1 using select /*+ use_index(t, idx_b) */ * from t where a = 1 and b = 1")
+	statuses := "select bind_sql, status from mysql.bind_info where original_sql = 'select * from `test` . `t` where `a` = ? and `b` = ?' order by bind_sql"
+	tk.MustQuery(statuses).Check(testkit.Rows(
+		"SELECT /*+ use_index(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` = 1 AND `b` = 1 standby",
+		"SELECT /*+ use_index(`t` `idx_b`)*/ * FROM `test`.`t` WHERE `a` = 1 AND `b` = 1 enabled"))
+	require.Len(t, dom.BindHandle().GetAllBindRecord()[0].Bindings, 2)
+	tk.MustExec("select * from t where a = 1 and b = 1")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustUseIndex("select * from t where a = 1 and b = 1", "idx_b")
+
+	primary := "SELECT /*+ use_index(`t` `idx_b`)*/ * FROM `test`.`t` WHERE `a` = 1 AND `b` = 1"
+	begin := time.Now().Add(-time.Hour)
+	stmts := []*stmtsummary.BindingMatchedStmt{
+		{BindSQL: primary, BeginTime: begin, BindingMatch: stmtsummary.BindingMatch{ExecCount: 10, SumLatency: 10 * time.Millisecond}},
+		{BindSQL: primary, BeginTime: begin.Add(30 * time.Minute), BindingMatch: stmtsummary.BindingMatch{ExecCount: 10, SumLatency: 15 * time.Millisecond}},
+	}
+	// The latency doesn't regress beyond the ratio.
+	switched, err := dom.BindHandle().FailoverBindings4Test(stmts, 2)
+	require.NoError(t, err)
+	require.Empty(t, switched)
+	// Too few executions in the latest interval.
+	switched, err = dom.BindHandle().FailoverBindings4Test(stmts[:1], 1.2)
+	require.NoError(t, err)
+	require.Empty(t, switched)
+
+	switched, err = dom.BindHandle().FailoverBindings4Test(stmts, 1.2)
+	require.NoError(t, err)
+	require.Equal(t, []string{parser.DigestNormalized("select * from `test` . `t` where `a` = ? and `b` = ?").String()}, switched)
+	tk.MustQuery(statuses).Check(testkit.Rows(
+		"SELECT /*+ use_index(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` = 1 AND `b` = 1 enabled",
+		"SELECT /*+ use_index(`t` `idx_b`)*/ * FROM `test`.`t` WHERE `a` = 1 AND `b` = 1 standby"))
+	tk.MustUseIndex("select * from t where a = 1 and b = 1", "idx_a")
+
+	// The regressed binding is not switched back by the stale latencies.
+	switched, err = dom.BindHandle().FailoverBindings4Test(stmts, 1.2)
+	require.NoError(t, err)
+	require.Empty(t, switched)
+	tk.MustExec("admin reload bindings")
+	tk.MustUseIndex("select * from t where a = 1 and b = 1", "idx_a")
+}
+
+func TestReconcileBindingsFile(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where b = 1 using select /*+ use_index(t, idx_b) */ * from t where b = 1")
+	h := dom.BindHandle()
+
+	dir := t.TempDir()
+	tomlFile := filepath.Join(dir, "bindings.toml")
+	require.NoError(t, os.WriteFile(tomlFile, []byte(`
+[[bindings]]
+db = "test"
+original-sql = "select * from t where a = 1"
+bind-sql = "select /*+ use_index(t, idx_a) */ * from t where a = 1"
+comment = "from file"
+
+[[bindings]]
+original-sql = "select * from test.t where a > 1"
+bind-sql = "select /*+ use_index(t, idx_a) */ * from test.t where a > 1"
+`), 0644))
+	created, dropped, err := h.ReconcileBindingsFile(tomlFile, true)
+	require.NoError(t, err)
+	require.Equal(t, 2, created)
+	require.Equal(t, 0, dropped)
+	tk.MustQuery("select original_sql, source, comment from mysql.bind_info where status = 'enabled' order by original_sql").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? file from file",
+		"select * from `test` . `t` where `a` > ? file <nil>",
+		"select * from `test` . `t` where `b` = ? manual <nil>"))
+	tk.MustUseIndex("select * from t where a = 1", "idx_a")
+
+	// The existing bindings are not created again.
+	created, dropped, err = h.ReconcileBindingsFile(tomlFile, true)
+	require.NoError(t, err)
+	require.Equal(t, 0, created)
+	require.Equal(t, 0, dropped)
+
+	// Only the bindings created from the file are pruned.
+	jsonFile := filepath.Join(dir, "bindings.json")
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"bindings": [
+		{"db": "test", "original_sql": "select * from t where a = 1", "bind_sql": "select /*+ use_index(t, idx_a) */ * from t where a = 1"}
+	]}`), 0644))
+	created, dropped, err = h.ReconcileBindingsFile(jsonFile, false)
+	require.NoError(t, err)
+	require.Equal(t, 0, created)
+	require.Equal(t, 0, dropped)
+	require.Len(t, h.GetAllBindRecord(), 3)
+	created, dropped, err = h.ReconcileBindingsFile(jsonFile, true)
+	require.NoError(t, err)
+	require.Equal(t, 0, created)
+	require.Equal(t, 1, dropped)
+	tk.MustQuery("select original_sql, source from mysql.bind_info where status = 'enabled' order by original_sql").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? file",
+		"select * from `test` . `t` where `b` = ? manual"))
+
+	// Invalid bindings file.
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"bindings": [{"original_sql": "select * from", "bind_sql": "select * from"}]}`), 0644))
+	_, _, err = h.ReconcileBindingsFile(jsonFile, true)
+	require.ErrorContains(t, err, "failed to parse original sql")
+}
+
+func TestReplayPendingTasks(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 using select /*+ use_index(t, idx_a) */ * from t where a = 1")
+	h := dom.BindHandle()
+	record := h.GetAllBindRecord()[0]
+
+	// The pending tasks are persisted when they are added.
+	h.AddDropInvalidBindTask(&bindinfo.BindRecord{OriginalSQL: record.OriginalSQL, Db: record.Db, Bindings: []bindinfo.Binding{record.Bindings[0]}})
+	h.AddEvolvePlanTask(record.OriginalSQL, record.Db, bindinfo.Binding{
+		BindSQL:   "SELECT /*+ use_index(`t` `idx_b`)*/ * FROM `test`.`t` WHERE `a` = 1",
+		Status:    bindinfo.PendingVerify,
+		Charset:   record.Bindings[0].Charset,
+		Collation: record.Bindings[0].Collation,
+		Source:    bindinfo.Evolve,
+		SQLDigest: record.Bindings[0].SQLDigest,
+	})
+	tk.MustQuery("select task_type, bind_sql, status from mysql.bind_pending_tasks order by task_type").Check(testkit.Rows(
+		"drop SELECT /*+ use_index(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` = 1 enabled",
+		"evolve SELECT /*+ use_index(`t` `idx_b`)*/ * FROM `test`.`t` WHERE `a` = 1 pending verify"))
+
+	// Simulate a restart, the pending tasks in memory are lost and replayed from the storage.
+	h.Clear()
+	require.NoError(t, h.Update(true))
+	require.NoError(t, h.ReplayPendingTasks())
+	h.DropInvalidBindRecord()
+	h.SaveEvolveTasksToStore()
+	tk.MustQuery("select bind_sql, status, source from mysql.bind_info where source != 'builtin' order by bind_sql").Check(testkit.Rows(
+		"SELECT /*+ use_index(`t` `idx_a`)*/ * FROM `test`.`t` WHERE `a` = 1 deleted manual",
+		"SELECT /*+ use_index(`t` `idx_b`)*/ * FROM `test`.`t` WHERE `a` = 1 pending verify evolve"))
+	tk.MustQuery("select count(*) from mysql.bind_pending_tasks").Check(testkit.Rows("0"))
+
+	// The invalid pending tasks are removed when replayed.
+	tk.MustExec("insert into mysql.bind_pending_tasks values ('evolve', 'select * from `test` . `t` where `a` = ?', 'test', 'select /*+ non_exist_hint() */ * from t where a = 1', 'pending verify', 'utf8mb4', 'utf8mb4_bin', 'evolve', '', now())")
+	require.NoError(t, h.ReplayPendingTasks())
+	tk.MustQuery("select count(*) from mysql.bind_pending_tasks").Check(testkit.Rows("0"))
+}
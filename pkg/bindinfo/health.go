@@ -0,0 +1,88 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"strings"
+	"time"
+)
+
+// HealthCheckInterval is the interval of verifying whether the hints of the enabled global bindings can
+// still be applied.
+var HealthCheckInterval = 10 * time.Minute
+
+// bindingKey identifies a binding in the bind records.
+type bindingKey struct {
+	originalSQL string
+	db          string
+	bindSQL     string
+}
+
+// CheckBindingsHealth verifies whether the hints of the enabled global bindings in the cache can still be
+// applied, by preparing the hints and explaining the bind sql again. A binding is unhealthy if it fails to
+// be prepared or explained, or the optimizer reports warnings for it, e.g. the hinted index is renamed.
+// The failure reasons are kept in memory of this instance and shown by SHOW GLOBAL BINDINGS, they are
+// replaced by the result of the next check. It returns the number of the unhealthy bindings.
+func (h *BindHandle) CheckBindingsHealth() int {
+	failures := make(map[bindingKey]string)
+	for _, record := range h.GetAllBindRecord() {
+		for _, binding := range record.Bindings {
+			if !binding.IsBindingEnabled() {
+				continue
+			}
+			if reason := h.checkBindingHealth(record, binding); reason != "" {
+				failures[bindingKey{originalSQL: record.OriginalSQL, db: record.Db, bindSQL: binding.BindSQL}] = reason
+			}
+		}
+	}
+	h.healthCheckFailures.Lock()
+	h.healthCheckFailures.m = failures
+	h.healthCheckFailures.Unlock()
+	return len(failures)
+}
+
+// checkBindingHealth checks a binding and returns the reason if it is unhealthy, otherwise an empty string.
+func (h *BindHandle) checkBindingHealth(record *BindRecord, binding Binding) string {
+	h.sctx.Lock()
+	defer h.sctx.Unlock()
+	h.sctx.GetSessionVars().CurrentDB = record.Db
+	// The bind sql isn't explained if it has parameter markers, make sure no stale warning is reported.
+	h.sctx.GetSessionVars().StmtCtx.SetWarnings(nil)
+	// Clear the prepared hints to make prepareHints parse and explain the bind sql again.
+	binding.Hint, binding.ID = nil, ""
+	checked := &BindRecord{OriginalSQL: record.OriginalSQL, Db: record.Db, Bindings: []Binding{binding}}
+	if err := checked.prepareHints(h.sctx.Context); err != nil {
+		return err.Error()
+	}
+	// The statement context is the one of the internal EXPLAIN statement executed by prepareHints, it
+	// holds the warnings reported by the optimizer for the hints.
+	warns := h.sctx.GetSessionVars().StmtCtx.GetWarnings()
+	if len(warns) == 0 {
+		return ""
+	}
+	reasons := make([]string, 0, len(warns))
+	for _, warn := range warns {
+		reasons = append(reasons, warn.Err.Error())
+	}
+	return strings.Join(reasons, "; ")
+}
+
+// GetBindingHealthCheckFailure returns the reason why the binding failed the latest health check, it returns
+// an empty string if the binding is healthy or hasn't been checked.
+func (h *BindHandle) GetBindingHealthCheckFailure(originalSQL, db, bindSQL string) string {
+	h.healthCheckFailures.RLock()
+	defer h.healthCheckFailures.RUnlock()
+	return h.healthCheckFailures.m[bindingKey{originalSQL: originalSQL, db: db, bindSQL: bindSQL}]
+}
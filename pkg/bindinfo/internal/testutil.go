@@ -27,6 +27,7 @@ import (
 // UtilCleanBindingEnv cleans the binding environment.
 func UtilCleanBindingEnv(tk *testkit.TestKit, dom *domain.Domain) {
 	tk.MustExec("delete from mysql.bind_info where source != 'builtin'")
+	tk.MustExec("delete from mysql.bind_pending_tasks")
 	dom.BindHandle().Clear()
 }
 
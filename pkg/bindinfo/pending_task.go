@@ -0,0 +1,106 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+const (
+	// pendingTaskDrop is the task type of dropping an invalid binding.
+	pendingTaskDrop = "drop"
+	// pendingTaskEvolve is the task type of adding a binding to be verified by the plan evolution.
+	pendingTaskEvolve = "evolve"
+)
+
+// persistPendingTask saves the bind record to be flushed into mysql.bind_pending_tasks.
+func (h *BindHandle) persistPendingTask(taskType string, record *BindRecord) error {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	binding := &record.Bindings[0]
+	createTime := types.NewTime(types.FromGoTime(time.Now()), mysql.TypeTimestamp, 3)
+	// No need to acquire the session context lock for ExecRestrictedSQL, it
+	// uses another background session.
+	_, _, err := exec.ExecRestrictedSQL(ctx, nil, `INSERT INTO mysql.bind_pending_tasks(task_type, original_sql, default_db, bind_sql, status, charset, collation, source, sql_digest, create_time)
+		VALUES (%?, %?, %?, %?, %?, %?, %?, %?, %?, %?)`,
+		taskType, record.OriginalSQL, record.Db, binding.BindSQL, binding.Status, binding.Charset, binding.Collation, binding.Source, binding.SQLDigest, createTime.String())
+	return err
+}
+
+// removePendingTask deletes the flushed bind record from mysql.bind_pending_tasks.
+func (h *BindHandle) removePendingTask(taskType string, record *BindRecord) error {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	_, _, err := exec.ExecRestrictedSQL(ctx, nil, `DELETE FROM mysql.bind_pending_tasks WHERE task_type = %? AND original_sql = %? AND default_db = %? AND bind_sql = %?`,
+		taskType, record.OriginalSQL, record.Db, record.Bindings[0].BindSQL)
+	return err
+}
+
+// ReplayPendingTasks loads the pending tasks persisted in mysql.bind_pending_tasks into memory, so the invalid
+// bindings found and the evolve tasks added before restarts are flushed into mysql.bind_info by the bind worker.
+func (h *BindHandle) ReplayPendingTasks() error {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT task_type, original_sql, default_db, bind_sql, status, charset, collation, source, sql_digest
+		FROM mysql.bind_pending_tasks ORDER BY create_time`)
+	if err != nil {
+		return err
+	}
+	for _, row := range rows {
+		taskType := row.GetString(0)
+		record := &BindRecord{
+			OriginalSQL: row.GetString(1),
+			Db:          row.GetString(2),
+			Bindings: []Binding{{
+				BindSQL:   row.GetString(3),
+				Status:    row.GetString(4),
+				Charset:   row.GetString(5),
+				Collation: row.GetString(6),
+				Source:    row.GetString(7),
+				SQLDigest: row.GetString(8),
+			}},
+		}
+		var tmpMap *tmpBindRecordMap
+		switch taskType {
+		case pendingTaskDrop:
+			tmpMap = &h.invalidBindRecordMap
+		case pendingTaskEvolve:
+			tmpMap = &h.pendingVerifyBindRecordMap
+		}
+		if tmpMap == nil {
+			logutil.BgLogger().Warn("unknown pending bind task type, ignore it", zap.String("category", "sql-bind"), zap.String("taskType", taskType))
+			continue
+		}
+		// The ID of the binding is needed to identify the bind record in memory.
+		if err = record.prepareHints(nil); err != nil {
+			logutil.BgLogger().Warn("failed to replay pending bind task, remove it", zap.String("category", "sql-bind"),
+				zap.String("taskType", taskType), zap.String("bindSQL", record.Bindings[0].BindSQL), zap.Error(err))
+			if err = h.removePendingTask(taskType, record); err != nil {
+				return err
+			}
+			continue
+		}
+		tmpMap.add(record)
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"sort"
+	"time"
+)
+
+// BindingPlanDrift records that the plans generated by a binding don't match the plan digest of the binding.
+type BindingPlanDrift struct {
+	OriginalSQL string
+	Db          string
+	BindSQL     string
+	Scope       string
+	// ExpectedPlanDigest is the plan digest of the binding.
+	ExpectedPlanDigest string
+	// ActualPlanDigest is the digest of the plan generated by the binding last time.
+	ActualPlanDigest string
+	DriftCount       int64
+	LastDriftTime    time.Time
+}
+
+// RecordPlanDrift records that the plan generated by the binding doesn't match the plan digest of the binding.
+func (h *BindHandle) RecordPlanDrift(record *BindRecord, binding *Binding, scope string, actualPlanDigest string) {
+	key := bindingKey{originalSQL: record.OriginalSQL, db: record.Db, bindSQL: binding.BindSQL}
+	h.planDrifts.Lock()
+	defer h.planDrifts.Unlock()
+	if h.planDrifts.m == nil {
+		h.planDrifts.m = make(map[bindingKey]*BindingPlanDrift)
+	}
+	drift, ok := h.planDrifts.m[key]
+	if !ok {
+		drift = &BindingPlanDrift{
+			OriginalSQL: record.OriginalSQL,
+			Db:          record.Db,
+			BindSQL:     binding.BindSQL,
+			Scope:       scope,
+		}
+		h.planDrifts.m[key] = drift
+	}
+	drift.ExpectedPlanDigest = binding.PlanDigest
+	drift.ActualPlanDigest = actualPlanDigest
+	drift.DriftCount++
+	drift.LastDriftTime = time.Now()
+}
+
+// GetPlanDrifts returns the bindings whose generated plans don't match their plan digests on this instance,
+// the latest drifts come first.
+func (h *BindHandle) GetPlanDrifts() []*BindingPlanDrift {
+	h.planDrifts.Lock()
+	drifts := make([]*BindingPlanDrift, 0, len(h.planDrifts.m))
+	for _, drift := range h.planDrifts.m {
+		copied := *drift
+		drifts = append(drifts, &copied)
+	}
+	h.planDrifts.Unlock()
+	sort.Slice(drifts, func(i, j int) bool {
+		return drifts[i].LastDriftTime.After(drifts[j].LastDriftTime)
+	})
+	return drifts
+}
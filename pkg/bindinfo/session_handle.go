@@ -106,6 +106,17 @@ func (h *SessionHandle) DropBindRecordByDigest(sqlDigest string) error {
 	return h.DropBindRecord(oldRecord.OriginalSQL, strings.ToLower(oldRecord.Db), nil)
 }
 
+// DropBindingsByTable drops all the bind records whose statements reference the table `db.table` in the cache.
+// All the bind records referencing any table of the schema are dropped if the table is empty.
+func (h *SessionHandle) DropBindingsByTable(db, table string) error {
+	for _, record := range bindRecordsReferTable(h.GetAllBindRecord(), db, table) {
+		if err := h.DropBindRecord(record.OriginalSQL, record.Db, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetBindRecord return the BindMeta of the (normdOrigSQL,db) if BindMeta exist.
 func (h *SessionHandle) GetBindRecord(hash, normdOrigSQL, db string) *BindRecord {
 	return h.ch.GetBindRecord(hash, normdOrigSQL, db)
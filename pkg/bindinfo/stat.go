@@ -20,6 +20,8 @@ import (
 
 var (
 	lastPlanBindingUpdateTime = "last_plan_binding_update_time"
+	bindCacheWarmUpTotal      = "binding_cache_warm_up_total"
+	bindCacheWarmUpLoaded     = "binding_cache_warm_up_loaded"
 )
 
 // GetScope gets the status variables scope.
@@ -35,6 +37,8 @@ func (h *BindHandle) Stats(_ *variable.SessionVars) (map[string]interface{}, err
 	}()
 	m := make(map[string]interface{})
 	m[lastPlanBindingUpdateTime] = h.bindInfo.lastUpdateTime.String()
+	m[bindCacheWarmUpTotal] = h.warmUp.total.Load()
+	m[bindCacheWarmUpLoaded] = h.warmUp.loaded.Load()
 
 	return m, nil
 }
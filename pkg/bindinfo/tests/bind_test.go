@@ -17,8 +17,10 @@ package tests
 import (
 	"context"
 	"fmt"
+	"slices"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/pingcap/tidb/pkg/bindinfo"
 	"github.com/pingcap/tidb/pkg/bindinfo/internal"
@@ -654,6 +656,43 @@ func TestAddEvolveTasks(t *testing.T) {
 	require.True(t, status == bindinfo.Enabled || status == bindinfo.Rejected)
 }
 
+func TestEvolveTasksWithParams(t *testing.T) {
+	originalVal := config.CheckTableBeforeDrop
+	config.CheckTableBeforeDrop = true
+	defer func() {
+		config.CheckTableBeforeDrop = originalVal
+	}()
+
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("set tidb_enable_prepared_plan_cache = 0")
+	tk.MustExec("create table t(a int, b int, c int, index idx_a(a), index idx_b(b), index idx_c(c))")
+	tk.MustExec("insert into t values (1,1,1), (2,2,2), (3,3,3), (4,4,4), (5,5,5)")
+	tk.MustExec("analyze table t")
+	tk.MustExec("create global binding for select * from t where a >= 1 and b >= 1 and c = 0 using select * from t use index(idx_a) where a >= 1 and b >= 1 and c = 0")
+	tk.MustExec("set @@tidb_evolve_plan_baselines=1")
+	tk.MustExec("prepare stmt from 'select * from t where a >= ? and b >= ? and c = ?'")
+	tk.MustExec("set @a = 4, @b = 1, @c = 0")
+	tk.MustQuery("execute stmt using @a, @b, @c")
+	require.Equal(t, "t:idx_a", tk.Session().GetSessionVars().StmtCtx.IndexNames[0])
+	tk.MustExec("admin flush bindings")
+	// The parameter markers are kept in the binding sql.
+	rows := tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, "SELECT /*+ use_index(@`sel_1` `test`.`t` )*/ * FROM `test`.`t` WHERE `a` >= ? AND `b` >= ? AND `c` = ?", rows[0][1])
+	require.Equal(t, bindinfo.PendingVerify, rows[0][3])
+
+	// The binding is verified with the parameters sampled from the statement summary.
+	tk.MustExec("admin evolve bindings")
+	rows = tk.MustQuery("show global bindings").Rows()
+	require.Len(t, rows, 2)
+	status := rows[0][3].(string)
+	require.True(t, status == bindinfo.Enabled || status == bindinfo.Rejected)
+}
+
 func TestRuntimeHintsInEvolveTasks(t *testing.T) {
 	originalVal := config.CheckTableBeforeDrop
 	config.CheckTableBeforeDrop = true
@@ -1146,7 +1185,9 @@ func TestGCBindRecord(t *testing.T) {
 
 	h := dom.BindHandle()
 	// bindinfo.Lease is set to 0 for test env in SetUpSuite.
-	require.NoError(t, h.GCBindRecord())
+	deletedRows, err := h.GCBindRecord()
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), deletedRows)
 	rows = tk.MustQuery("show global bindings").Rows()
 	require.Len(t, rows, 1)
 	require.Equal(t, "select * from `test` . `t` where `a` = ?", rows[0][0])
@@ -1160,11 +1201,52 @@ func TestGCBindRecord(t *testing.T) {
 	tk.MustQuery("select status from mysql.bind_info where original_sql = 'select * from `test` . `t` where `a` = ?'").Check(testkit.Rows(
 		"deleted",
 	))
-	require.NoError(t, h.GCBindRecord())
+	deletedRows, err = h.GCBindRecord()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), deletedRows)
 	tk.MustQuery("show global bindings").Check(testkit.Rows())
 	tk.MustQuery("select status from mysql.bind_info where original_sql = 'select * from `test` . `t` where `a` = ?'").Check(testkit.Rows())
 }
 
+func TestAdminGCBindInfo(t *testing.T) {
+	originLease := bindinfo.Lease
+	bindinfo.Lease = 0
+	defer func() {
+		bindinfo.Lease = originLease
+	}()
+
+	store := testkit.CreateMockStore(t)
+
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("drop table if exists t")
+	tk.MustExec("create table t(a int, b int, key(a))")
+	tk.MustQuery("select @@global.tidb_binding_gc_retention").Check(testkit.Rows("0s"))
+	tk.MustExec("set @@global.tidb_binding_gc_retention = '1h'")
+	defer tk.MustExec("set @@global.tidb_binding_gc_retention = default")
+
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t use index(a) where a = 1")
+	tk.MustExec("drop global binding for select * from t where a = 1")
+	tk.MustQuery("select status from mysql.bind_info where original_sql = 'select * from `test` . `t` where `a` = ?'").Check(testkit.Rows(
+		"deleted",
+	))
+
+	// The deleted binding is still within the retention window.
+	tk.MustExec("admin gc bindinfo")
+	require.Equal(t, uint64(0), tk.Session().AffectedRows())
+	tk.MustQuery("select status from mysql.bind_info where original_sql = 'select * from `test` . `t` where `a` = ?'").Check(testkit.Rows(
+		"deleted",
+	))
+
+	tk.MustExec("set @@global.tidb_binding_gc_retention = '0s'")
+	tk.MustExec("admin gc bindinfo")
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	tk.MustQuery("select status from mysql.bind_info where original_sql = 'select * from `test` . `t` where `a` = ?'").Check(testkit.Rows())
+
+	tk.MustExec("admin gc bindinfo")
+	require.Equal(t, uint64(0), tk.Session().AffectedRows())
+}
+
 func TestBindSQLDigest(t *testing.T) {
 	store, dom := testkit.CreateMockStoreAndDomain(t)
 	tk := testkit.NewTestKit(t, store)
@@ -1217,7 +1299,7 @@ func TestBindSQLDigest(t *testing.T) {
 		sql := "create global binding for " + c.origin + " using " + c.hint
 		tk.MustExec(sql)
 		res := tk.MustQuery(`show global bindings`).Rows()
-		require.Equal(t, len(res[0]), 11)
+		require.Equal(t, len(res[0]), 17)
 
 		parser4binding := parser.New()
 		originNode, err := parser4binding.ParseOneStmt(c.origin, "utf8mb4", "utf8mb4_general_ci")
@@ -1284,10 +1366,11 @@ func TestDropBindBySQLDigest(t *testing.T) {
 		res := tk.MustQuery(`show global bindings`).Rows()
 
 		require.Equal(t, len(res), 1)
-		require.Equal(t, len(res[0]), 11)
+		require.Equal(t, len(res[0]), 17)
 		drop := fmt.Sprintf("drop global binding for sql digest '%s'", res[0][9])
 		tk.MustExec(drop)
-		require.NoError(t, h.GCBindRecord())
+		_, err := h.GCBindRecord()
+		require.NoError(t, err)
 		h.ReloadBindings()
 		tk.MustQuery("show global bindings").Check(testkit.Rows())
 	}
@@ -1300,10 +1383,11 @@ func TestDropBindBySQLDigest(t *testing.T) {
 		res := tk.MustQuery(`show bindings`).Rows()
 
 		require.Equal(t, len(res), 1)
-		require.Equal(t, len(res[0]), 11)
+		require.Equal(t, len(res[0]), 17)
 		drop := fmt.Sprintf("drop binding for sql digest '%s'", res[0][9])
 		tk.MustExec(drop)
-		require.NoError(t, h.GCBindRecord())
+		_, err := h.GCBindRecord()
+		require.NoError(t, err)
 		tk.MustQuery("show bindings").Check(testkit.Rows())
 	}
 
@@ -1311,3 +1395,393 @@ func TestDropBindBySQLDigest(t *testing.T) {
 	tk.MustGetErrMsg(fmt.Sprintf("drop binding for sql digest '%s'", "1"), "can't find any binding for '1'")
 	tk.MustGetErrMsg(fmt.Sprintf("drop binding for sql digest '%s'", ""), "sql digest is empty")
 }
+
+func TestBindingChangeInvalidatesCachedPointPlan(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`set tidb_enable_prepared_plan_cache=1`)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b int, key idx_b(b))")
+	tk.MustExec("prepare stmt from 'select * from t where a = ?'")
+	tk.MustExec("set @a = 1")
+	tk.MustExec("execute stmt using @a")
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The point plan cached before the binding is created is not reused.
+	tk.MustExec("create global binding for select * from t where a = 1 using select /*+ use_index(t, idx_b) */ * from t where a = 1")
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("0"))
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+
+	// The plan cached before the binding was created can be used again.
+	tk.MustExec("drop global binding for select * from t where a = 1")
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache").Check(testkit.Rows("1"))
+}
+
+func TestDisabledOrDroppedBindingStopsMatchingAtOnce(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec(`set tidb_enable_prepared_plan_cache=1`)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int, b int, key idx_a(a), key idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 and b = 1 using select /*+ use_index(t, idx_b) */ * from t where a = 1 and b = 1")
+	tk.MustExec("prepare stmt from 'select * from t where a = ? and b = ?'")
+	tk.MustExec("set @a = 1, @b = 1")
+	tk.MustExec("execute stmt using @a, @b")
+	tk.MustExec("execute stmt using @a, @b")
+	tk.MustQuery("select @@last_plan_from_binding, @@last_plan_from_cache").Check(testkit.Rows("1 1"))
+
+	// The disabled binding is not used by the cached plan any more.
+	tk.MustExec("set binding disabled for select * from t where a = 1 and b = 1")
+	tk.MustExec("execute stmt using @a, @b")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+	tk.MustExec("set binding enabled for select * from t where a = 1 and b = 1")
+	tk.MustExec("execute stmt using @a, @b")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+
+	sqlDigest := tk.MustQuery("show global bindings").Rows()[0][9].(string)
+	tk.MustExec(fmt.Sprintf("drop global binding for sql digest '%s'", sqlDigest))
+	tk.MustExec("execute stmt using @a, @b")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+	tk.MustQuery("select status from mysql.bind_info where sql_digest = ?", sqlDigest).Check(testkit.Rows("deleted"))
+}
+
+func TestAdminCheckBindings(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t use index(idx_a) where a = 1")
+	tk.MustQuery("admin check bindings").Check(testkit.Rows())
+
+	insertBinding := func(bindSQL, db, status string) {
+		tk.MustExec(fmt.Sprintf("insert into mysql.bind_info values('select * from `test` . `t` where `a` = ?', '%s', '%s', '%s', now(3), now(3), '', '', 'manual', '', '', NULL, NULL, NULL, NULL, NULL)",
+			bindSQL, db, status))
+	}
+	// Another enabled binding with different hints.
+	insertBinding("SELECT * FROM `test`.`t` USE INDEX (`idx_b`) WHERE `a` = 1", "test", bindinfo.Enabled)
+	rows := tk.MustQuery("admin check bindings").Rows()
+	require.Len(t, rows, 2)
+	for _, row := range rows {
+		require.Equal(t, bindinfo.ConflictContradictoryHints, row[2])
+		require.Equal(t, "test", row[3])
+		require.Equal(t, bindinfo.Enabled, row[5])
+	}
+
+	// A disabled binding together with the enabled ones.
+	insertBinding("SELECT * FROM `test`.`t` IGNORE INDEX (`idx_a`) WHERE `a` = 1", "test", bindinfo.Disabled)
+	conflicts := make(map[string]int)
+	for _, row := range tk.MustQuery("admin check bindings").Rows() {
+		conflicts[fmt.Sprintf("%v %v", row[2], row[5])]++
+	}
+	require.Equal(t, map[string]int{
+		"contradictory hints enabled":   2,
+		"enabled and disabled enabled":  2,
+		"enabled and disabled disabled": 1,
+	}, conflicts)
+
+	// The same statement with a binding in another default database.
+	tk.MustExec("delete from mysql.bind_info where status = 'disabled' or bind_sql like '%idx_b%'")
+	insertBinding("SELECT * FROM `test`.`t` USE INDEX (`idx_b`) WHERE `a` = 1", "", bindinfo.Enabled)
+	rows = tk.MustQuery("admin check bindings").Sort().Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, bindinfo.ConflictDuplicateScope, rows[0][2])
+	require.Equal(t, "", rows[0][3])
+	require.Equal(t, "test", rows[1][3])
+	require.Contains(t, rows[0][6], "bindings exist in default databases '', 'test'")
+
+	tk.MustExec("delete from mysql.bind_info where default_db = ''")
+	tk.MustQuery("admin check bindings").Check(testkit.Rows())
+
+	tk.MustExec("create user test_user")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "test_user", Hostname: "localhost"}, nil, nil, nil))
+	tk1.MustGetErrMsg("admin check bindings", "[planner:8121]privilege check for 'Super' fail")
+}
+
+func TestBindingsCacheStatusTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a))")
+	tk.MustQuery("select * from information_schema.bindings_cache_status").Check(testkit.Rows())
+
+	tk.MustExec("create global binding for select * from t where a = 1 using select * from t use index(idx_a) where a = 1")
+	tk.MustExec("create global binding for select * from t where b = 1 using select * from t use index() where b = 1")
+	handle := dom.BindHandle()
+	status := handle.GetBindCacheStatus()
+	require.Len(t, status.Records, 2)
+	require.Equal(t, handle.GetMemUsage(), status.MemUsage)
+	require.Equal(t, int64(0), status.EvictedCount)
+	tk.MustQuery("select original_sql, default_db, binding_count, cache_evicted_count from information_schema.bindings_cache_status order by original_sql").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? test 1 0",
+		"select * from `test` . `t` where `b` = ? test 1 0"))
+	tk.MustQuery(fmt.Sprintf("select sum(memory_usage) <= %d, max(cache_memory_usage), max(cache_memory_capacity) from information_schema.bindings_cache_status",
+		status.MemUsage)).Check(testkit.Rows(fmt.Sprintf("1 %d %d", status.MemUsage, handle.GetMemCapacity())))
+
+	// Only one bind record can be held by the cache, the other one is evicted.
+	tk.MustExec(fmt.Sprintf("set global tidb_mem_quota_binding_cache = %d", status.MemUsage*2/3))
+	tk.MustExec("admin reload bindings")
+	tk.MustQuery("select count(*), max(cache_evicted_count) > 0 from information_schema.bindings_cache_status").Check(testkit.Rows("1 1"))
+	tk.MustExec("set global tidb_mem_quota_binding_cache = default")
+}
+
+func TestBindingComment(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding comment 'ticket-123, owner: dba' for select * from t where a = 1 using select * from t use index(idx_a) where a = 1")
+	tk.MustExec("create global binding for select * from t where b = 1 using select * from t use index(idx_b) where b = 1")
+	tk.MustExec("create session binding comment 'session' for select * from t where a = 1 using select * from t use index(idx_a) where a = 1")
+
+	tk.MustQuery("select original_sql, comment from mysql.bind_info where source != 'builtin' order by original_sql").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? ticket-123, owner: dba",
+		"select * from `test` . `t` where `b` = ? <nil>"))
+	checkComments := func() {
+		rows := tk.MustQuery("show global bindings").Sort().Rows()
+		require.Len(t, rows, 2)
+		require.Equal(t, "ticket-123, owner: dba", rows[0][11])
+		require.Equal(t, "<nil>", rows[1][11])
+	}
+	checkComments()
+	rows := tk.MustQuery("show session bindings").Rows()
+	require.Len(t, rows, 1)
+	require.Equal(t, "session", rows[0][11])
+
+	// The comment is kept after reloading the bindings from the storage.
+	dom.BindHandle().Clear()
+	require.NoError(t, dom.BindHandle().Update(true))
+	checkComments()
+
+	// The comment is replaced when the binding is created again.
+	tk.MustExec("create global binding comment 'ticket-456' for select * from t where a = 1 using select * from t use index(idx_a) where a = 1")
+	rows = tk.MustQuery("show global bindings").Sort().Rows()
+	require.Equal(t, "ticket-456", rows[0][11])
+}
+
+func TestDropBindingsByTable(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("create database test2")
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(a int, index idx(a))")
+	tk.MustExec("create table t2(a int, index idx(a))")
+	tk.MustExec("create table test2.t1(a int, index idx(a))")
+	tk.MustExec("create global binding for select * from t1 where a = 1 using select * from t1 use index(idx) where a = 1")
+	tk.MustExec("create global binding for select * from t1, t2 where t1.a = t2.a using select /*+ hash_join(t1) */ * from t1, t2 where t1.a = t2.a")
+	tk.MustExec("create global binding for select * from t2 where a = 1 using select * from t2 use index(idx) where a = 1")
+	tk.MustExec("create global binding for select * from test2.t1 where a = 1 using select * from test2.t1 use index(idx) where a = 1")
+	tk.MustExec("create session binding for select * from t1 where a = 1 using select * from t1 use index(idx) where a = 1")
+	tk.MustExec("create session binding for select * from t2 where a = 1 using select * from t2 use index(idx) where a = 1")
+
+	originalSQLs := func(scope string) []string {
+		rows := tk.MustQuery(fmt.Sprintf("show %s bindings", scope)).Rows()
+		sqls := make([]string, 0, len(rows))
+		for _, row := range rows {
+			sqls = append(sqls, row[0].(string))
+		}
+		slices.Sort(sqls)
+		return sqls
+	}
+
+	// The bindings referencing test.t1 are dropped, including the join one.
+	tk.MustExec("drop global bindings for table t1")
+	require.Equal(t, uint64(2), tk.Session().AffectedRows())
+	require.Equal(t, []string{
+		"select * from `test2` . `t1` where `a` = ?",
+		"select * from `test` . `t2` where `a` = ?",
+	}, originalSQLs("global"))
+	require.Len(t, dom.BindHandle().GetAllBindRecord(), 2)
+	// The session bindings are not affected.
+	require.Len(t, originalSQLs("session"), 2)
+
+	tk.MustExec("drop session bindings for table test.t2")
+	require.Equal(t, []string{"select * from `test` . `t1` where `a` = ?"}, originalSQLs("session"))
+
+	// The table may have been dropped already.
+	tk.MustExec("drop table test2.t1")
+	tk.MustExec("drop global bindings for table test2.t1")
+	require.Equal(t, uint64(1), tk.Session().AffectedRows())
+	require.Equal(t, []string{"select * from `test` . `t2` where `a` = ?"}, originalSQLs("global"))
+
+	tk.MustExec("drop global bindings for table test.not_exist")
+	require.Equal(t, uint64(0), tk.Session().AffectedRows())
+	tk.MustExec("use mysql")
+	tk.MustExec("drop global bindings for table t2")
+	require.Equal(t, uint64(0), tk.Session().AffectedRows())
+	require.Len(t, originalSQLs("global"), 1)
+}
+
+func TestBindingWithSetVarHint(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a))")
+
+	tk.MustGetErrMsg("create global binding for select * from t where a = 1 using select /*+ set_var(no_such_var=1) */ * from t where a = 1",
+		"Unresolved name 'no_such_var' for SET_VAR hint")
+	tk.MustGetErrMsg("create global binding for select * from t where a = 1 using select /*+ set_var(autocommit=0) */ * from t where a = 1",
+		"Variable 'autocommit' cannot be set using SET_VAR hint")
+	tk.MustGetErrMsg("create global binding for select * from t where a = 1 using select /*+ set_var(max_execution_time='abc') */ * from t where a = 1",
+		"[variable:1232]Incorrect argument type to variable 'max_execution_time'")
+	tk.MustQuery("show global bindings").Check(testkit.Rows())
+
+	tk.MustExec("create global binding for select @@tidb_index_lookup_size, a from t where a = 1 using " +
+		"select /*+ set_var(tidb_index_lookup_size=1000) use_index(t, idx_a) */ @@tidb_index_lookup_size, a from t where a = 1")
+	tk.MustExec("insert into t values (1, 1)")
+	// The variable is pinned by the binding during the statement, and restored after it.
+	tk.MustQuery("select @@tidb_index_lookup_size, a from t where a = 1").Check(testkit.Rows("1000 1"))
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustQuery("select @@tidb_index_lookup_size").Check(testkit.Rows("20000"))
+
+	// The hints of the query are ignored, and the variable is restored to the value before the query hint.
+	tk.MustQuery("select /*+ set_var(tidb_index_lookup_size=7) */ @@tidb_index_lookup_size, a from t where a = 1").Check(testkit.Rows("1000 1"))
+	tk.MustQuery("select @@tidb_index_lookup_size").Check(testkit.Rows("20000"))
+}
+
+func TestBindingHealthCheck(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 using select /*+ use_index(t, idx_a) */ * from t where a = 1")
+	tk.MustExec("create global binding for select * from t where b = 1 using select /*+ use_index(t, idx_b) */ * from t where b = 1")
+	require.Equal(t, 0, dom.BindHandle().CheckBindingsHealth())
+
+	tk.MustExec("alter table t rename index idx_a to idx_c")
+	require.Equal(t, 1, dom.BindHandle().CheckBindingsHealth())
+	rows := tk.MustQuery("show global bindings").Sort().Rows()
+	require.Len(t, rows, 2)
+	require.Equal(t, "select * from `test` . `t` where `a` = ?", rows[0][0])
+	require.Contains(t, rows[0][16], "idx_a")
+	require.Equal(t, "select * from `test` . `t` where `b` = ?", rows[1][0])
+	require.Equal(t, "<nil>", rows[1][16])
+	// The health of the session bindings isn't checked.
+	tk.MustExec("create session binding for select * from t where a = 1 using select /*+ use_index(t, idx_a) */ * from t where a = 1")
+	require.Equal(t, "<nil>", tk.MustQuery("show session bindings").Rows()[0][16])
+
+	tk.MustExec("alter table t rename index idx_c to idx_a")
+	require.Equal(t, 0, dom.BindHandle().CheckBindingsHealth())
+	for _, row := range tk.MustQuery("show global bindings").Rows() {
+		require.Equal(t, "<nil>", row[16])
+	}
+}
+
+func TestBindingsCoverage(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 using select /*+ use_index(t, idx_a) */ * from t where a = 1")
+	tk.MustExec("create global binding for select * from t where b = 1 using select /*+ use_index(t, idx_b) */ * from t where b = 1")
+	tk.MustQuery("select original_sql, matched_exec_count, last_matched_time from information_schema.bindings_coverage order by original_sql").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? 0 <nil>",
+		"select * from `test` . `t` where `b` = ? 0 <nil>"))
+
+	tk.MustExec("select * from t where a = 1")
+	tk.MustExec("select * from t where a = 2")
+	// The plans fetched from the plan cache are generated by the binding as well.
+	tk.MustExec("prepare stmt from 'select * from t where a = ?'")
+	tk.MustExec("set @a = 1")
+	tk.MustExec("execute stmt using @a")
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache, @@last_plan_from_binding").Check(testkit.Rows("1 1"))
+	digest := tk.MustQuery("select distinct digest from information_schema.statements_summary where digest_text = 'select * from `t` where `a` = ?'").Rows()[0][0].(string)
+	tk.MustQuery("select original_sql, matched_digests, matched_exec_count, last_matched_time is not null from information_schema.bindings_coverage order by original_sql").Check(testkit.Rows(
+		fmt.Sprintf("select * from `test` . `t` where `a` = ? %s 4 1", digest),
+		"select * from `test` . `t` where `b` = ?  0 0"))
+
+	handle := dom.BindHandle()
+	unmatched := handle.GetUnmatchedBindings(time.Time{})
+	require.Len(t, unmatched, 1)
+	require.Equal(t, "select * from `test` . `t` where `b` = ?", unmatched[0].OriginalSQL)
+	require.Len(t, handle.GetUnmatchedBindings(time.Now().Add(time.Hour)), 2)
+}
+
+func TestBindingCollapseInList(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustQuery("select @@global.tidb_binding_collapse_in_list").Check(testkit.Rows("0"))
+
+	// The IN lists of literals are always collapsed.
+	tk.MustExec("create global binding for select * from t where a in (1, 2) using select /*+ use_index(t, idx_b) */ * from t where a in (1, 2)")
+	for _, sql := range []string{"select * from t where a in (1)", "select * from t where a in (1, 2, 3)"} {
+		tk.MustExec(sql)
+		tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	}
+	// The prepared statements with a single parameter in the IN list match the binding as well.
+	tk.MustExec("prepare stmt from 'select * from t where a in (?)'")
+	tk.MustExec("set @a = 1")
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustExec("execute stmt using @a")
+	tk.MustQuery("select @@last_plan_from_cache, @@last_plan_from_binding").Check(testkit.Rows("1 1"))
+	tk.MustExec("drop global binding for select * from t where a in (1, 2)")
+
+	// The IN lists which contain non-literal items are collapsed only if tidb_binding_collapse_in_list is on.
+	tk.MustExec("create global binding for select * from t where a in (b, 1) using select /*+ use_index(t, idx_b) */ * from t where a in (b, 1)")
+	tk.MustExec("select * from t where a in (b, 1, 2)")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+	tk.MustExec("drop global binding for select * from t where a in (b, 1)")
+
+	tk.MustExec("set @@global.tidb_binding_collapse_in_list = on")
+	defer tk.MustExec("set @@global.tidb_binding_collapse_in_list = default")
+	tk.MustExec("create global binding for select * from t where a in (b, 1) using select /*+ use_index(t, idx_b) */ * from t where a in (b, 1)")
+	tk.MustQuery("select original_sql from information_schema.bindings_coverage").Check(testkit.Rows("select * from `test` . `t` where `a` in ( ... )"))
+	for _, sql := range []string{"select * from t where a in (b, 1, 2)", "select * from t where a in (b + 1)", "select * from t where a in (3)"} {
+		tk.MustExec(sql)
+		tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	}
+	// The subqueries are not collapsed.
+	tk.MustExec("select * from t where a in (select b from t)")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("0"))
+}
+
+func TestBindingPlanDigestCheck(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	stmtsummary.StmtSummaryByDigestMap.Clear()
+	require.NoError(t, tk.Session().Auth(&auth.UserIdentity{Username: "root", Hostname: "%"}, nil, nil, nil))
+	tk.MustExec("use test")
+	tk.MustExec("create table t(a int, b int, index idx_a(a), index idx_b(b))")
+	tk.MustExec("create global binding for select * from t where a = 1 using select /*+ use_index(t, idx_b) */ * from t where a = 1")
+	tk.MustExec("update mysql.bind_info set plan_digest = 'unexpected' where original_sql = 'select * from `test` . `t` where `a` = ?'")
+	tk.MustExec("admin reload bindings")
+
+	// The plan digest isn't checked by default.
+	tk.MustQuery("select @@global.tidb_binding_plan_digest_check").Check(testkit.Rows("OFF"))
+	tk.MustExec("select * from t where a = 1")
+	tk.MustQuery("select @@last_plan_from_binding").Check(testkit.Rows("1"))
+	tk.MustQuery("select * from information_schema.bindings_plan_drift").Check(testkit.Rows())
+
+	defer tk.MustExec("set @@global.tidb_binding_plan_digest_check = default")
+	tk.MustExec("set @@global.tidb_binding_plan_digest_check = 'ON'")
+	tk.MustExec("select * from t where a = 1")
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 0)
+	tk.MustExec("set @@global.tidb_binding_plan_digest_check = 'WARN'")
+	tk.MustExec("select * from t where a = 1")
+	warnings := tk.Session().GetSessionVars().StmtCtx.GetWarnings()
+	require.Len(t, warnings, 1)
+	require.Contains(t, warnings[0].Err.Error(), "doesn't match the plan digest unexpected of the binding")
+	tk.MustQuery("select original_sql, scope, expected_plan_digest, drift_count from information_schema.bindings_plan_drift").Check(testkit.Rows(
+		"select * from `test` . `t` where `a` = ? global unexpected 2"))
+
+	// The actual plan digest is the same as the one in the statement summary.
+	actualPlanDigest := tk.MustQuery("select actual_plan_digest from information_schema.bindings_plan_drift").Rows()[0][0].(string)
+	tk.MustQuery("select distinct plan_digest from information_schema.statements_summary where digest_text = 'select * from `t` where `a` = ?'").Check(testkit.Rows(actualPlanDigest))
+	tk.MustExec(fmt.Sprintf("update mysql.bind_info set plan_digest = '%s' where original_sql = 'select * from `test` . `t` where `a` = ?'", actualPlanDigest))
+	tk.MustExec("admin reload bindings")
+	tk.MustExec("select * from t where a = 1")
+	require.Len(t, tk.Session().GetSessionVars().StmtCtx.GetWarnings(), 0)
+	tk.MustQuery("select drift_count from information_schema.bindings_plan_drift").Check(testkit.Rows("2"))
+}
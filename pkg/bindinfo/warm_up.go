@@ -0,0 +1,118 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package bindinfo
+
+import (
+	"context"
+
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/types"
+	"github.com/pingcap/tidb/pkg/util/chunk"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// WarmUpBatchSize is the number of bind records loaded in one batch when warming up the bind cache.
+const WarmUpBatchSize = 1000
+
+// warmUpPhases are the conditions of the bind records loaded in each phase of WarmUp.
+// The enabled bind records are loaded first since only they can be used by queries.
+var warmUpPhases = []string{"status IN (%?, %?)", "status NOT IN (%?, %?)"}
+
+// WarmUp loads all the bind records in mysql.bind_info into the cache batch by batch. It is used
+// instead of Update(true) on startup, so that TiDB can serve requests before all the bind records
+// are loaded. The enabled bind records are loaded before the others, and the recently updated ones
+// are loaded first in each phase. The bind records changed after WarmUp starts are loaded by Update(false).
+// WarmUp returns when all the bind records are loaded or exit is closed.
+func (h *BindHandle) WarmUp(batchSize int, exit <-chan struct{}) error {
+	exec := h.sctx.Context.(sqlexec.RestrictedSQLExecutor)
+	ctx := kv.WithInternalSourceType(context.Background(), kv.InternalTxnBindInfo)
+
+	h.bindInfo.Lock()
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, `SELECT COUNT(*), MAX(update_time) FROM mysql.bind_info WHERE original_sql != %?`, BuiltinPseudoSQL4BindLock)
+	if err != nil {
+		h.bindInfo.Unlock()
+		return err
+	}
+	h.warmUp.total.Store(rows[0].GetInt64(0))
+	h.warmUp.loaded.Store(0)
+	if rows[0].IsNull(1) {
+		h.bindInfo.Unlock()
+		return nil
+	}
+	// The bind records updated later than maxUpdateTime are left to Update(false).
+	maxUpdateTime := rows[0].GetTime(1)
+	if maxUpdateTime.Compare(h.bindInfo.lastUpdateTime) > 0 {
+		h.bindInfo.lastUpdateTime = maxUpdateTime
+	}
+	h.bindInfo.Unlock()
+
+	for _, phase := range warmUpPhases {
+		var cursor *chunk.Row
+		for {
+			select {
+			case <-exit:
+				return nil
+			default:
+			}
+			rows, err := h.warmUpBatch(ctx, exec, phase, maxUpdateTime, cursor, batchSize)
+			if err != nil {
+				return err
+			}
+			if len(rows) == 0 {
+				break
+			}
+			cursor = &rows[len(rows)-1]
+		}
+	}
+	logutil.BgLogger().Info("warm up bind cache finished", zap.String("category", "sql-bind"), zap.Int64("bindings", h.warmUp.loaded.Load()))
+	return nil
+}
+
+// warmUpBatch loads the next batch of bind records after cursor into the cache. The bind records are
+// ordered by (update_time, original_sql, bind_sql) in descending order, which identifies a bind record,
+// so that no bind record is skipped or loaded twice even if some records are deleted concurrently.
+func (h *BindHandle) warmUpBatch(ctx context.Context, exec sqlexec.RestrictedSQLExecutor, phase string,
+	maxUpdateTime types.Time, cursor *chunk.Row, batchSize int) ([]chunk.Row, error) {
+	sql := `SELECT original_sql, bind_sql, default_db, status, create_time, update_time, charset, collation, source, sql_digest, plan_digest, comment,
+		capture_users, capture_sample_sql, capture_avg_latency, capture_exec_count
+		FROM mysql.bind_info WHERE update_time <= %? AND original_sql != %? AND ` + phase
+	args := []interface{}{maxUpdateTime.String(), BuiltinPseudoSQL4BindLock, Enabled, Using}
+	if cursor != nil {
+		sql += " AND (update_time, original_sql, bind_sql) < (%?, %?, %?)"
+		args = append(args, cursor.GetTime(5).String(), cursor.GetString(0), cursor.GetString(1))
+	}
+	sql += " ORDER BY update_time DESC, original_sql DESC, bind_sql DESC LIMIT %?"
+	args = append(args, batchSize)
+
+	// Reading and loading a batch are done with the lock held, so that the bind records loaded by
+	// Update(false) concurrently are never overwritten by their stale versions.
+	h.bindInfo.Lock()
+	defer h.bindInfo.Unlock()
+	rows, _, err := exec.ExecRestrictedSQL(ctx, nil, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	cache := h.bindInfo.Value.Load().(*bindCache)
+	memExceededErr := cache.resize(variable.MemQuotaBindingCache.Load())
+	_, memExceededErr = h.loadRowsToCache(cache, rows, types.ZeroTimestamp, memExceededErr)
+	if memExceededErr != nil {
+		logutil.BgLogger().Warn("BindHandle.WarmUp", zap.String("category", "sql-bind"), zap.Error(memExceededErr))
+	}
+	h.warmUp.loaded.Add(int64(len(rows)))
+	return rows, nil
+}
@@ -17,6 +17,7 @@ package config
 import (
 	"bytes"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -597,6 +598,9 @@ type Security struct {
 	SessionTokenSigningKey  string `toml:"session-token-signing-key" json:"session-token-signing-key"`
 	// If set to "plaintext", the spilled files will not be encrypted.
 	SpilledFileEncryptionMethod string `toml:"spilled-file-encryption-method" json:"spilled-file-encryption-method"`
+	// MasterKeyPath is the path of the file which contains the hex encoded 256-bit master key.
+	// If set, the intermediate files written to the cloud storage by global sort are encrypted.
+	MasterKeyPath string `toml:"master-key-path" json:"master-key-path"`
 	// EnableSEM prevents SUPER users from having full access.
 	EnableSEM bool `toml:"enable-sem" json:"enable-sem"`
 	// Allow automatic TLS certificate generation
@@ -665,6 +669,27 @@ func (s *Security) ClusterSecurity() tikvcfg.Security {
 	return tikvcfg.NewSecurity(s.ClusterSSLCA, s.ClusterSSLCert, s.ClusterSSLKey, s.ClusterVerifyCN)
 }
 
+// LoadMasterKey reads the master key from MasterKeyPath. It returns nil if
+// MasterKeyPath is not set.
+func (s *Security) LoadMasterKey() ([]byte, error) {
+	if len(s.MasterKeyPath) == 0 {
+		return nil, nil
+	}
+	content, err := os.ReadFile(s.MasterKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	key, err := hex.DecodeString(strings.TrimSpace(string(content)))
+	if err != nil {
+		return nil, fmt.Errorf("invalid [security]master-key-path %s: %v", s.MasterKeyPath, err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("invalid [security]master-key-path %s: the master key must be 256 bits, got %d bits",
+			s.MasterKeyPath, len(key)*8)
+	}
+	return key, nil
+}
+
 // Status is the status section of the config.
 type Status struct {
 	StatusHost      string `toml:"status-host" json:"status-host"`
@@ -738,6 +763,20 @@ type Performance struct {
 	// If ForceInitStats is false, tidb can provide service before init stats is finished. Note that during the period
 	// of init stats the optimizer may make bad decisions due to pseudo stats.
 	ForceInitStats bool `toml:"force-init-stats" json:"force-init-stats"`
+
+	// If BindInfoAsyncWarmUp is true, when tidb starts up, it loads the bindings into the cache batch by batch in
+	// background and provides service before all the bindings are loaded. The enabled and recently updated bindings
+	// are loaded first, queries may not use their bindings until they are loaded.
+	BindInfoAsyncWarmUp bool `toml:"bind-info-async-warm-up" json:"bind-info-async-warm-up"`
+	// BindInfoEvolveConcurrency is the number of pending bindings verified concurrently by the plan evolution
+	// on this instance. Each of them uses a dedicated internal session.
+	BindInfoEvolveConcurrency uint `toml:"bind-info-evolve-concurrency" json:"bind-info-evolve-concurrency"`
+	// BindInfoFile is the path of a JSON or TOML file declaring the global bindings. When tidb starts up, it creates
+	// the declared bindings which are missing from mysql.bind_info.
+	BindInfoFile string `toml:"bind-info-file" json:"bind-info-file"`
+	// If BindInfoFilePrune is true, the bindings created from BindInfoFile but no longer declared in it are dropped
+	// when tidb starts up.
+	BindInfoFilePrune bool `toml:"bind-info-file-prune" json:"bind-info-file-prune"`
 }
 
 // PlanCache is the PlanCache section of the config.
@@ -1007,6 +1046,7 @@ var defaultConf = Config{
 		EnableLoadFMSketch:                false,
 		LiteInitStats:                     true,
 		ForceInitStats:                    true,
+		BindInfoEvolveConcurrency:         1,
 	},
 	ProxyProtocol: ProxyProtocol{
 		Networks:      "",
@@ -1376,6 +1416,9 @@ func (c *Config) Valid() error {
 		return fmt.Errorf("unsupported [security]spilled-file-encryption-method %v, TiDB only supports [%v, %v]",
 			c.Security.SpilledFileEncryptionMethod, SpilledFileEncryptionMethodPlaintext, SpilledFileEncryptionMethodAES128CTR)
 	}
+	if _, err := c.Security.LoadMasterKey(); err != nil {
+		return err
+	}
 
 	// check stats load config
 	if c.Performance.StatsLoadConcurrency < DefStatsLoadConcurrencyLimit || c.Performance.StatsLoadConcurrency > DefMaxOfStatsLoadConcurrencyLimit {
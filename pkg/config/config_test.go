@@ -16,6 +16,7 @@ package config
 
 import (
 	"bytes"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
@@ -1250,6 +1251,31 @@ func TestSecurityValid(t *testing.T) {
 	}
 }
 
+func TestLoadMasterKey(t *testing.T) {
+	c1 := NewConfig()
+	key, err := c1.Security.LoadMasterKey()
+	require.NoError(t, err)
+	require.Nil(t, key)
+
+	dir := t.TempDir()
+	c1.Security.MasterKeyPath = filepath.Join(dir, "not-exist")
+	require.Error(t, c1.Valid())
+
+	keyPath := filepath.Join(dir, "master.key")
+	c1.Security.MasterKeyPath = keyPath
+	require.NoError(t, os.WriteFile(keyPath, []byte("0123"), 0600))
+	require.ErrorContains(t, c1.Valid(), "the master key must be 256 bits")
+	require.NoError(t, os.WriteFile(keyPath, []byte("xyz"), 0600))
+	require.ErrorContains(t, c1.Valid(), "invalid [security]master-key-path")
+
+	expected := bytes.Repeat([]byte{0xab}, 32)
+	require.NoError(t, os.WriteFile(keyPath, []byte(hex.EncodeToString(expected)+"\n"), 0600))
+	require.NoError(t, c1.Valid())
+	key, err = c1.Security.LoadMasterKey()
+	require.NoError(t, err)
+	require.Equal(t, expected, key)
+}
+
 func TestTcpNoDelay(t *testing.T) {
 	c1 := NewConfig()
 	// check default value
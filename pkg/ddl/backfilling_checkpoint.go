@@ -0,0 +1,157 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/kv"
+)
+
+// readIndexCheckpointInterval is the minimum interval between two checkpoints of a read index subtask.
+var readIndexCheckpointInterval = 10 * time.Minute
+
+// errReadIndexParked is returned by the table scan source after the checkpoint of a parked subtask is made.
+var errReadIndexParked = errors.New("read index subtask is parked")
+
+// readIndexCheckpoint tracks the progress of the table scan tasks of a read index subtask. The table scan
+// source calls maybeCheckpoint between the tasks; once the interval is reached, it stops generating tasks
+// until all the generated ones are written, makes the written index records durable with flush, and then
+// records the end key of the last task with persist.
+type readIndexCheckpoint struct {
+	// flush makes the index records written so far durable. It is called when no task is running.
+	flush func() error
+	// persist records the checkpoint key, all the records before which are durable.
+	persist func(key kv.Key) error
+
+	lastCheckpoint time.Time
+	parking        atomic.Bool
+
+	mu struct {
+		sync.Mutex
+		tasks map[int]*tableScanTaskProgress // task ID -> progress
+		// All the tasks with ID <= finishedID are finished, and finishedKey is the end key of the last one.
+		finishedID  int
+		finishedKey kv.Key
+		drained     chan struct{}
+	}
+}
+
+type tableScanTaskProgress struct {
+	end           kv.Key
+	scannedChunks int
+	writtenChunks int
+	lastScanned   bool
+}
+
+func newReadIndexCheckpoint(persist func(key kv.Key) error) *readIndexCheckpoint {
+	cp := &readIndexCheckpoint{
+		persist:        persist,
+		lastCheckpoint: time.Now(),
+	}
+	cp.mu.tasks = make(map[int]*tableScanTaskProgress)
+	return cp
+}
+
+// register registers a table scan task before it is sent.
+func (cp *readIndexCheckpoint) register(task TableScanTask) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.mu.tasks[task.ID] = &tableScanTaskProgress{end: task.End}
+}
+
+// onChunkScanned is called by the table scan worker before a chunk of the task is sent.
+func (cp *readIndexCheckpoint) onChunkScanned(taskID int, last bool) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	p := cp.mu.tasks[taskID]
+	p.scannedChunks++
+	p.lastScanned = last
+}
+
+// onChunkWritten is called by the result sink after a chunk of the task is written.
+func (cp *readIndexCheckpoint) onChunkWritten(taskID int) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+	cp.mu.tasks[taskID].writtenChunks++
+	for {
+		p := cp.mu.tasks[cp.mu.finishedID+1]
+		if p == nil || !p.lastScanned || p.writtenChunks < p.scannedChunks {
+			break
+		}
+		cp.mu.finishedID++
+		cp.mu.finishedKey = p.end
+		delete(cp.mu.tasks, cp.mu.finishedID)
+	}
+	if len(cp.mu.tasks) == 0 && cp.mu.drained != nil {
+		close(cp.mu.drained)
+		cp.mu.drained = nil
+	}
+}
+
+// park asks the subtask to stop at the next checkpoint, which is made without waiting for the interval.
+func (cp *readIndexCheckpoint) park() {
+	cp.parking.Store(true)
+}
+
+// maybeCheckpoint makes a checkpoint if the interval is reached. It waits for all the registered tasks to be
+// finished, so it must be called by the goroutine generating the tasks. errReadIndexParked is returned after
+// the checkpoint is made if the subtask is parking.
+func (cp *readIndexCheckpoint) maybeCheckpoint(ctx context.Context) error {
+	interval := readIndexCheckpointInterval
+	failpoint.Inject("mockReadIndexCheckpointInterval", func(val failpoint.Value) {
+		//nolint:forcetypeassert
+		interval = time.Duration(val.(int)) * time.Millisecond
+	})
+	parking := cp.parking.Load()
+	if !parking && time.Since(cp.lastCheckpoint) < interval {
+		return nil
+	}
+	cp.mu.Lock()
+	if len(cp.mu.tasks) > 0 {
+		drained := make(chan struct{})
+		cp.mu.drained = drained
+		cp.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-drained:
+		}
+		cp.mu.Lock()
+	}
+	key := cp.mu.finishedKey
+	cp.mu.Unlock()
+
+	cp.lastCheckpoint = time.Now()
+	if len(key) > 0 {
+		if cp.flush != nil {
+			if err := cp.flush(); err != nil {
+				return err
+			}
+		}
+		if err := cp.persist(key); err != nil {
+			return err
+		}
+	}
+	if parking {
+		return errReadIndexParked
+	}
+	return nil
+}
@@ -19,11 +19,14 @@ import (
 	"encoding/json"
 	"strconv"
 
-	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/disttask/framework/dispatcher"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/ast"
+	"github.com/pingcap/tidb/pkg/util"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
 )
@@ -44,28 +47,67 @@ func (*BackfillCleanUpS3) CleanUp(ctx context.Context, task *proto.Task) error {
 	if err := json.Unmarshal(task.Meta, &gTaskMeta); err != nil {
 		return err
 	}
+	cloudStorageURI := gTaskMeta.CloudStorageURI
+	if len(cloudStorageURI) == 0 {
+		cloudStorageURI = gTaskMeta.FallbackCloudStorageURI
+	}
 	// Not use cloud storage, no need to cleanUp.
-	if len(gTaskMeta.CloudStorageURI) == 0 {
+	if len(cloudStorageURI) == 0 {
 		return nil
 	}
-	backend, err := storage.ParseBackend(gTaskMeta.CloudStorageURI, nil)
+	logger := logutil.Logger(ctx).With(zap.Int64("task-id", task.ID), zap.Int64("job-id", gTaskMeta.Job.ID))
+	leaked, err := cleanUpGlobalSortFiles(ctx, cloudStorageURI, gTaskMeta.Job.ID)
 	if err != nil {
-		logutil.Logger(ctx).Warn("failed to parse cloud storage uri", zap.Error(err))
-		return err
+		// The files are given up after retrying, so that the clean up of the other tasks are not blocked.
+		metrics.GlobalSortLeakedBytes.Add(float64(leaked))
+		logger.Warn("cannot cleanup cloud storage files", zap.Int64("leaked-bytes", leaked), zap.Error(err))
 	}
-	extStore, err := storage.NewWithDefaultOpt(ctx, backend)
+	redactCloudStorageURI(ctx, task, &gTaskMeta)
+	return nil
+}
+
+const (
+	globalSortCleanUpRetryTimes = 3
+	// globalSortCleanUpBackoff is the backoff in milliseconds between two retries.
+	globalSortCleanUpBackoff = 1000
+)
+
+// cleanUpGlobalSortFiles deletes all the objects written by the global sort of the job, which are all under
+// the prefix of the job ID. It retries on failure, and returns the size of the objects that are left.
+func cleanUpGlobalSortFiles(ctx context.Context, cloudStorageURI string, jobID int64) (leaked int64, err error) {
+	backend, err := storage.ParseBackend(cloudStorageURI, nil)
 	if err != nil {
-		logutil.Logger(ctx).Warn("failed to create cloud storage", zap.Error(err))
-		return err
+		return 0, err
 	}
-	prefix := strconv.Itoa(int(gTaskMeta.Job.ID))
-	err = external.CleanUpFiles(ctx, extStore, prefix)
+	extStore, err := storage.NewWithDefaultOpt(ctx, backend)
 	if err != nil {
-		logutil.Logger(ctx).Warn("cannot cleanup cloud storage files", zap.Error(err))
-		return err
+		return 0, err
 	}
-	redactCloudStorageURI(ctx, task, &gTaskMeta)
-	return nil
+	prefix := strconv.Itoa(int(jobID))
+	err = util.RunWithRetry(globalSortCleanUpRetryTimes, globalSortCleanUpBackoff, func() (bool, error) {
+		failpoint.Inject("mockCleanUpGlobalSortFilesErr", func() {
+			failpoint.Return(true, errors.New("mock clean up global sort files error"))
+		})
+		var names []string
+		leaked = 0
+		err := extStore.WalkDir(ctx, &storage.WalkOption{SubDir: prefix}, func(path string, size int64) error {
+			names = append(names, path)
+			leaked += size
+			return nil
+		})
+		if err != nil {
+			return true, err
+		}
+		if len(names) == 0 {
+			return false, nil
+		}
+		if err := extStore.DeleteFiles(ctx, names); err != nil {
+			return true, err
+		}
+		leaked = 0
+		return false, nil
+	})
+	return leaked, err
 }
 
 func redactCloudStorageURI(
@@ -74,6 +116,7 @@ func redactCloudStorageURI(
 	origin *BackfillGlobalMeta,
 ) {
 	origin.CloudStorageURI = ast.RedactURL(origin.CloudStorageURI)
+	origin.FallbackCloudStorageURI = ast.RedactURL(origin.FallbackCloudStorageURI)
 	metaBytes, err := json.Marshal(origin)
 	if err != nil {
 		logutil.Logger(ctx).Warn("failed to marshal task meta", zap.Error(err))
@@ -16,29 +16,42 @@ package ddl
 
 import (
 	"bytes"
+	"cmp"
 	"context"
 	"encoding/hex"
 	"encoding/json"
 	"math"
+	"slices"
 	"sort"
+	"time"
 
+	"github.com/coreos/go-semver/semver"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
-	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/br/pkg/version"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
 	"github.com/pingcap/tidb/pkg/disttask/framework/dispatcher"
+	"github.com/pingcap/tidb/pkg/disttask/framework/planner"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	diststorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/infoschema"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/parser/terror"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/store/helper"
 	"github.com/pingcap/tidb/pkg/table"
+	"github.com/pingcap/tidb/pkg/util/backoff"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
 	disttaskutil "github.com/pingcap/tidb/pkg/util/disttask"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/memory"
 	"github.com/tikv/client-go/v2/tikv"
 	"go.uber.org/zap"
 )
@@ -48,8 +61,25 @@ type BackfillingDispatcherExt struct {
 	d                    *ddl
 	previousSchedulerIDs []string
 	GlobalSort           bool
+	progress             backfillProgressEstimator
+
+	// planRetryCnt is the count of the consecutive failures to generate the subtasks of planRetryStep.
+	planRetryStep  proto.Step
+	planRetryCnt   int
+	planRetryAfter time.Time
+	planBackoffer  *backoff.Exponential
+
+	lastRebalance time.Time
 }
 
+const (
+	// backfillPlanMaxRetryCnt is the retry budget of generating the subtasks of a step,
+	// the task fails when the subtasks still can't be generated after that many retries.
+	backfillPlanMaxRetryCnt      = 10
+	backfillPlanRetryInterval    = time.Second
+	backfillPlanRetryMaxInterval = 30 * time.Second
+)
+
 // NewBackfillingDispatcherExt creates a new backfillingDispatcherExt, only used for test now.
 func NewBackfillingDispatcherExt(d DDL) (dispatcher.Extension, error) {
 	ddl, ok := d.(*ddl)
@@ -64,7 +94,275 @@ func NewBackfillingDispatcherExt(d DDL) (dispatcher.Extension, error) {
 var _ dispatcher.Extension = (*BackfillingDispatcherExt)(nil)
 
 // OnTick implements dispatcher.Extension interface.
-func (*BackfillingDispatcherExt) OnTick(_ context.Context, _ *proto.Task) {
+// It rebalances the pending subtasks when the nodes change, propagates the change of
+// tidb_ddl_reorg_worker_cnt to the running subtasks through the task meta, and estimates
+// the progress of the read index step.
+func (dsp *BackfillingDispatcherExt) OnTick(ctx context.Context, task *proto.Task) {
+	if task.State != proto.TaskStateRunning {
+		return
+	}
+	var backfillMeta BackfillGlobalMeta
+	if err := json.Unmarshal(task.Meta, &backfillMeta); err != nil {
+		return
+	}
+	logger := logutil.BgLogger().With(
+		zap.String("category", "ddl"),
+		zap.Int64("task-id", task.ID),
+	)
+	taskMgr, err := diststorage.GetTaskManager()
+	if err != nil {
+		logger.Warn("failed to get task manager", zap.Error(err))
+		return
+	}
+	dsp.rebalanceSubtasks(ctx, taskMgr, task, time.Now(), logger)
+	workerCntChanged := updateWorkerCnt(&backfillMeta, logger)
+	progressChanged := dsp.updateProgress(ctx, taskMgr, task, &backfillMeta, time.Now(), logger)
+	if !workerCntChanged && !progressChanged {
+		return
+	}
+	metaBytes, err := json.Marshal(&backfillMeta)
+	if err == nil {
+		err = taskMgr.UpdateGlobalTaskMeta(task.ID, metaBytes)
+	}
+	if err != nil {
+		logger.Warn("failed to update the meta of backfill task", zap.Error(err))
+		return
+	}
+	task.Meta = metaBytes
+	if workerCntChanged {
+		logger.Info("update the worker count of backfill task", zap.Int("worker-cnt", backfillMeta.WorkerCnt))
+	}
+}
+
+// updateWorkerCnt updates the worker count in the task meta if tidb_ddl_reorg_worker_cnt changes.
+func updateWorkerCnt(backfillMeta *BackfillGlobalMeta, logger *zap.Logger) bool {
+	workerCnt := int(variable.GetDDLReorgWorkerCounter())
+	// WorkerCnt is 0 if the task is submitted by the old version, which doesn't support changing it.
+	if backfillMeta.WorkerCnt == 0 || backfillMeta.WorkerCnt == workerCnt {
+		return false
+	}
+	logger.Info("worker count of backfill task changes",
+		zap.Int("old-worker-cnt", backfillMeta.WorkerCnt), zap.Int("new-worker-cnt", workerCnt))
+	backfillMeta.WorkerCnt = workerCnt
+	return true
+}
+
+// updateProgress samples the row count of the read index step, and updates the estimated throughput and ETA
+// in the task meta. It returns whether the task meta is changed.
+func (dsp *BackfillingDispatcherExt) updateProgress(
+	ctx context.Context,
+	taskMgr *diststorage.TaskManager,
+	task *proto.Task,
+	backfillMeta *BackfillGlobalMeta,
+	now time.Time,
+	logger *zap.Logger,
+) bool {
+	if task.Step != StepReadIndex {
+		// Only the read index step counts the rows.
+		changed := backfillMeta.Throughput != 0 || backfillMeta.ETA != 0
+		backfillMeta.Throughput, backfillMeta.ETA = 0, 0
+		return changed
+	}
+	if !dsp.progress.needSample(now) {
+		return false
+	}
+	rowCount, err := taskMgr.GetSubtaskRowCount(task.ID, StepReadIndex)
+	if err != nil {
+		logger.Warn("failed to get the row count of backfill task", zap.Error(err))
+		return false
+	}
+	changed := false
+	if dsp.progress.totalRows == 0 {
+		dsp.progress.totalRows = backfillMeta.TotalRows
+	}
+	if dsp.progress.totalRows == 0 {
+		tblInfo, err := getTblInfo(dsp.d, &backfillMeta.Job)
+		if err != nil {
+			logger.Warn("failed to get the table of backfill task", zap.Error(err))
+			return false
+		}
+		dsp.progress.totalRows = getTableTotalCount(ctx, dsp.d.sessPool, tblInfo)
+		backfillMeta.TotalRows = dsp.progress.totalRows
+		changed = true
+	}
+	throughput, eta := dsp.progress.update(now, rowCount)
+	if len(dsp.progress.samples) < 2 {
+		// Keep the estimation of the previous dispatcher owner until there are enough samples.
+		return changed
+	}
+	if throughput == backfillMeta.Throughput && eta == backfillMeta.ETA {
+		return changed
+	}
+	backfillMeta.Throughput, backfillMeta.ETA = throughput, eta
+	return true
+}
+
+const (
+	// backfillProgressSampleInterval is the interval to sample the row count of the read index step.
+	backfillProgressSampleInterval = 5 * time.Second
+	// backfillThroughputWindow is the time window to calculate the current throughput.
+	backfillThroughputWindow = time.Minute
+)
+
+type rowCountSample struct {
+	time     time.Time
+	rowCount int64
+}
+
+// backfillProgressEstimator estimates the throughput and the ETA of the read index step from the row count
+// sampled periodically.
+type backfillProgressEstimator struct {
+	// totalRows is the estimated row count of the table.
+	totalRows int64
+	samples   []rowCountSample
+}
+
+func (e *backfillProgressEstimator) needSample(now time.Time) bool {
+	return len(e.samples) == 0 || now.Sub(e.samples[len(e.samples)-1].time) >= backfillProgressSampleInterval
+}
+
+// update adds a sample, and returns the throughput in rows per second within the window and the estimated
+// unix time the step finishes. The ETA is 0 if it can't be estimated.
+func (e *backfillProgressEstimator) update(now time.Time, rowCount int64) (throughput float64, eta int64) {
+	e.samples = append(e.samples, rowCountSample{time: now, rowCount: rowCount})
+	expired := 0
+	for expired < len(e.samples)-2 && now.Sub(e.samples[expired+1].time) >= backfillThroughputWindow {
+		expired++
+	}
+	e.samples = e.samples[expired:]
+	first := e.samples[0]
+	elapsed := now.Sub(first.time).Seconds()
+	if elapsed <= 0 || rowCount <= first.rowCount {
+		return 0, 0
+	}
+	throughput = float64(rowCount-first.rowCount) / elapsed
+	if e.totalRows > rowCount {
+		remaining := time.Duration(float64(e.totalRows-rowCount) / throughput * float64(time.Second))
+		eta = now.Add(remaining).Unix()
+	}
+	return throughput, eta
+}
+
+// backfillRebalanceInterval is the interval to check whether the pending subtasks need to be rebalanced.
+const backfillRebalanceInterval = 10 * time.Second
+
+// rebalanceSubtasks moves the pending subtasks of the current step to the nodes joined after the subtasks
+// are generated, and away from the nodes which are not eligible any more, such as the drained ones.
+// The subtasks of the dead nodes are rescheduled by the dispatcher framework.
+func (dsp *BackfillingDispatcherExt) rebalanceSubtasks(
+	ctx context.Context,
+	taskMgr *diststorage.TaskManager,
+	task *proto.Task,
+	now time.Time,
+	logger *zap.Logger,
+) {
+	if now.Sub(dsp.lastRebalance) < backfillRebalanceInterval {
+		return
+	}
+	dsp.lastRebalance = now
+	// The write&ingest subtasks of local sort ingest the data sorted on the nodes running them.
+	if task.Step == StepWriteAndIngest && !dsp.GlobalSort {
+		return
+	}
+	serverInfos, err := dsp.GetEligibleInstances(ctx, task)
+	if err != nil {
+		logger.Warn("failed to get the eligible nodes of backfill task", zap.Error(err))
+		return
+	}
+	nodes := make([]string, 0, len(serverInfos))
+	for _, info := range serverInfos {
+		nodes = append(nodes, disttaskutil.GenerateExecID(info.IP, info.Port))
+	}
+	subtasks, err := taskMgr.GetSubtasksByStep(task.ID, task.Step)
+	if err != nil {
+		logger.Warn("failed to get the subtasks of backfill task", zap.Error(err))
+		return
+	}
+	for _, m := range planSubtaskRebalance(subtasks, nodes) {
+		moved, err := taskMgr.MoveSubtask(m.subtask.ID, m.subtask.SchedulerID, m.target)
+		if err != nil {
+			logger.Warn("failed to move the subtask of backfill task", zap.Int64("subtask-id", m.subtask.ID), zap.Error(err))
+			return
+		}
+		if moved {
+			logger.Info("move the pending subtask of backfill task", zap.Int64("subtask-id", m.subtask.ID),
+				zap.String("from", m.subtask.SchedulerID), zap.String("to", m.target))
+		}
+	}
+}
+
+type subtaskMove struct {
+	subtask *proto.Subtask
+	target  string
+}
+
+// planSubtaskRebalance plans to move the pending subtasks, so that the subtasks are only on the given nodes,
+// and the unfinished subtask counts of the nodes differ by at most one. The running subtasks are not moved,
+// nor the speculative copies, which must run on nodes other than their origins.
+func planSubtaskRebalance(subtasks []*proto.Subtask, nodes []string) []subtaskMove {
+	if len(nodes) == 0 {
+		return nil
+	}
+	nodes = slices.Clone(nodes)
+	slices.Sort(nodes)
+	subtasks = slices.Clone(subtasks)
+	slices.SortFunc(subtasks, func(a, b *proto.Subtask) int { return cmp.Compare(a.ID, b.ID) })
+	load := make(map[string]int, len(nodes))
+	pending := make(map[string][]*proto.Subtask, len(nodes))
+	for _, node := range nodes {
+		load[node] = 0
+	}
+	var orphans []*proto.Subtask
+	for _, st := range subtasks {
+		if st.State != proto.TaskStatePending && st.State != proto.TaskStateRunning {
+			continue
+		}
+		movable := st.State == proto.TaskStatePending && st.OriginID == 0
+		if _, ok := load[st.SchedulerID]; !ok {
+			if movable {
+				orphans = append(orphans, st)
+			}
+			continue
+		}
+		load[st.SchedulerID]++
+		if movable {
+			pending[st.SchedulerID] = append(pending[st.SchedulerID], st)
+		}
+	}
+	leastLoaded := func() string {
+		target := nodes[0]
+		for _, node := range nodes[1:] {
+			if load[node] < load[target] {
+				target = node
+			}
+		}
+		return target
+	}
+
+	var moves []subtaskMove
+	for _, st := range orphans {
+		target := leastLoaded()
+		load[target]++
+		moves = append(moves, subtaskMove{subtask: st, target: target})
+	}
+	for {
+		target := leastLoaded()
+		source := ""
+		for _, node := range nodes {
+			if len(pending[node]) > 0 && (source == "" || load[node] > load[source]) {
+				source = node
+			}
+		}
+		if source == "" || load[source]-load[target] <= 1 {
+			return moves
+		}
+		// Move the last generated subtask, the earlier ones are more likely to be started soon.
+		st := pending[source][len(pending[source])-1]
+		pending[source] = pending[source][:len(pending[source])-1]
+		load[source]--
+		load[target]++
+		moves = append(moves, subtaskMove{subtask: st, target: target})
+	}
 }
 
 // OnNextSubtasksBatch generate batch of next step's plan.
@@ -80,78 +378,219 @@ func (dsp *BackfillingDispatcherExt) OnNextSubtasksBatch(
 		zap.String("curr-step", StepStr(gTask.Step)),
 		zap.String("next-step", StepStr(nextStep)),
 	)
-	var backfillMeta BackfillGlobalMeta
-	if err := json.Unmarshal(gTask.Meta, &backfillMeta); err != nil {
+	if err := dsp.waitPlanRetry(ctx, nextStep); err != nil {
 		return nil, err
 	}
-	job := &backfillMeta.Job
-	tblInfo, err := getTblInfo(dsp.d, job)
+	defer func() {
+		err = dsp.classifyPlanErr(nextStep, err, logger)
+	}()
+	logicalPlan := &BackfillingLogicalPlan{
+		dsp:        dsp,
+		taskHandle: taskHandle,
+		task:       gTask,
+		logger:     logger,
+	}
+	if err := logicalPlan.FromTaskMeta(gTask.Meta); err != nil {
+		return nil, err
+	}
+	job := &logicalPlan.Meta.Job
+	// Don't dispatch the subtasks of the next step if the DDL job is paused, the task will be paused soon.
+	if rc := dsp.d.getReorgCtx(job.ID); rc != nil && rc.isReorgPaused() {
+		return nil, dbterror.ErrPausedDDLJob.GenWithStackByArgs(job.ID)
+	}
+	logicalPlan.tblInfo, err = getTblInfo(dsp.d, job)
 	if err != nil {
 		return nil, err
 	}
 	logger.Info("on next subtasks batch")
+	hook := getBackfillingDispatcherHook()
+	if err := hook.BeforeStepTransition(gTask, nextStep); err != nil {
+		return nil, err
+	}
 
-	// TODO: use planner.
-	switch nextStep {
-	case StepReadIndex:
-		if tblInfo.Partition != nil {
-			return generatePartitionPlan(tblInfo)
+	if nextStep == proto.StepDone {
+		if dsp.GlobalSort {
+			// All the index records are ingested, the sorted KV files are no longer needed.
+			cleanUpGlobalSortFilesOfTask(ctx, &logicalPlan.Meta, logger)
 		}
-		return generateNonPartitionPlan(dsp.d, tblInfo, job)
-	case StepMergeSort:
-		res, err := generateMergePlan(taskHandle, gTask, logger)
-		if err != nil {
+		return nil, nil
+	}
+
+	planCtx := planner.PlanCtx{
+		Ctx:          ctx,
+		TaskID:       gTask.ID,
+		GlobalSort:   dsp.GlobalSort,
+		NextTaskStep: nextStep,
+	}
+	physicalPlan, err := logicalPlan.ToPhysicalPlan(planCtx)
+	if err != nil {
+		return nil, err
+	}
+	if nextStep == StepMergeSort && logicalPlan.Meta.UseMergeSort {
+		// The ingest step reads the sorted KV files written by the merge sort step.
+		if gTask.Meta, err = logicalPlan.ToTaskMeta(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	metas, err := physicalPlan.ToSubtaskMetas(planCtx, nextStep)
+	if err != nil {
+		return nil, err
+	}
+	if err := hook.AfterSubtaskMetaGenerated(gTask, nextStep, metas); err != nil {
+		return nil, err
+	}
+	if len(logicalPlan.Meta.PreviousSchedulerIDs) > 0 {
+		// The nodes are persisted together with the subtasks for a new dispatcher owner.
+		if gTask.Meta, err = logicalPlan.ToTaskMeta(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	if dsp.GlobalSort {
+		meta := &logicalPlan.Meta
+		if meta.GlobalSortStats == nil {
+			meta.GlobalSortStats = &model.GlobalSortStats{}
+		}
+		if err := updateGlobalSortStats(taskHandle, gTask, nextStep, metas, meta.GlobalSortStats); err != nil {
 			return nil, err
 		}
-		if len(res) > 0 {
-			backfillMeta.UseMergeSort = true
-			if err := updateMeta(gTask, &backfillMeta); err != nil {
-				return nil, err
+		// The statistics are reported to the DDL job by the task meta.
+		if gTask.Meta, err = logicalPlan.ToTaskMeta(); err != nil {
+			return nil, errors.Trace(err)
+		}
+	}
+	return metas, nil
+}
+
+// updateGlobalSortStats updates the statistics of the global sort when the subtasks of the next step are
+// generated from the sorted KV written by the subtasks of the current step.
+func updateGlobalSortStats(
+	taskHandle dispatcher.TaskHandle,
+	task *proto.Task,
+	nextStep proto.Step,
+	nextMetas [][]byte,
+	stats *model.GlobalSortStats,
+) error {
+	switch task.Step {
+	case StepReadIndex:
+		subTaskMetas, err := taskHandle.GetPreviousSubtaskMetas(task.ID, task.Step)
+		if err != nil {
+			return errors.Trace(err)
+		}
+		stats.TotalKVSize, stats.DataFiles, stats.StatFiles = 0, 0, 0
+		for _, subTaskMeta := range subTaskMetas {
+			var subtask BackfillSubTaskMeta
+			if err := json.Unmarshal(subTaskMeta, &subtask); err != nil {
+				return errors.Trace(err)
+			}
+			groups := subtask.MetaGroups
+			if len(groups) == 0 {
+				groups = []*external.SortedKVMeta{&subtask.SortedKVMeta}
+			}
+			for _, m := range groups {
+				stats.TotalKVSize += m.TotalKVSize
+				stats.DataFiles += len(m.DataFiles)
+				stats.StatFiles += len(m.StatFiles)
 			}
 		}
-		return res, nil
-	case StepWriteAndIngest:
-		if dsp.GlobalSort {
-			prevStep := StepReadIndex
-			if backfillMeta.UseMergeSort {
-				prevStep = StepMergeSort
+	case StepMergeSort:
+		// The sorted KV are merged by the merge sort step only once.
+		stats.MergeSortPasses = 1
+	}
+	if nextStep == StepWriteAndIngest {
+		stats.RangeSplits = 0
+		for _, subTaskMeta := range nextMetas {
+			var subtask BackfillSubTaskMeta
+			if err := json.Unmarshal(subTaskMeta, &subtask); err != nil {
+				return errors.Trace(err)
 			}
+			stats.RangeSplits += len(subtask.RangeSplitKeys)
+		}
+	}
+	return nil
+}
 
-			failpoint.Inject("mockWriteIngest", func() {
-				m := &BackfillSubTaskMeta{
-					SortedKVMeta: external.SortedKVMeta{},
-				}
-				metaBytes, _ := json.Marshal(m)
-				metaArr := make([][]byte, 0, 16)
-				metaArr = append(metaArr, metaBytes)
-				failpoint.Return(metaArr, nil)
-			})
-			return generateGlobalSortIngestPlan(
-				ctx,
-				taskHandle,
-				gTask,
-				job.ID,
-				backfillMeta.CloudStorageURI,
-				prevStep,
-				logger)
-		}
-		// for partition table, no subtasks for write and ingest step.
-		if tblInfo.Partition != nil {
-			return nil, nil
-		}
-		return generateIngestTaskPlan(ctx, dsp, taskHandle, gTask)
-	default:
-		return nil, nil
+// waitPlanRetry waits for the backoff of the last failure to generate the subtasks of the step.
+func (dsp *BackfillingDispatcherExt) waitPlanRetry(ctx context.Context, step proto.Step) error {
+	if step != dsp.planRetryStep {
+		dsp.planRetryStep = step
+		dsp.planRetryCnt = 0
+		return nil
+	}
+	wait := time.Until(dsp.planRetryAfter)
+	if dsp.planRetryCnt == 0 || wait <= 0 {
+		return nil
+	}
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-time.After(wait):
+		return nil
 	}
 }
 
-func updateMeta(gTask *proto.Task, taskMeta *BackfillGlobalMeta) error {
-	bs, err := json.Marshal(taskMeta)
+// classifyPlanErr classifies the error of generating the subtasks of the step. The permanent errors are returned
+// as is to fail the task immediately. The other errors are retried with exponential backoff, until the retry budget
+// runs out.
+func (dsp *BackfillingDispatcherExt) classifyPlanErr(step proto.Step, err error, logger *zap.Logger) error {
+	if err == nil {
+		dsp.planRetryCnt = 0
+		return nil
+	}
+	if isPermanentBackfillErr(err) {
+		logger.Warn("generate subtasks failed with permanent error", zap.Error(err))
+		return err
+	}
+	// The task will be paused soon, it doesn't consume the retry budget.
+	if dbterror.ErrPausedDDLJob.Equal(err) {
+		return err
+	}
+	if dsp.planBackoffer == nil {
+		dsp.planBackoffer = backoff.NewExponential(backfillPlanRetryInterval, 2, backfillPlanRetryMaxInterval)
+	}
+	dsp.planRetryCnt++
+	if dsp.planRetryCnt > backfillPlanMaxRetryCnt {
+		return errors.Errorf("failed to generate the subtasks of step %s after %d retries: %s",
+			StepStr(step), backfillPlanMaxRetryCnt, err.Error())
+	}
+	wait := dsp.planBackoffer.Backoff(dsp.planRetryCnt - 1)
+	dsp.planRetryAfter = time.Now().Add(wait)
+	logger.Warn("generate subtasks failed, retry later",
+		zap.Int("retry-cnt", dsp.planRetryCnt), zap.Duration("backoff", wait), zap.Error(err))
+	return err
+}
+
+// isPermanentBackfillErr checks whether the error can't be resolved by retrying, such as the unique key conflicts
+// and the schema mismatch.
+func isPermanentBackfillErr(err error) bool {
+	for _, e := range []*terror.Error{
+		kv.ErrKeyExists,
+		meta.ErrDBNotExists,
+		meta.ErrTableNotExists,
+		infoschema.ErrDatabaseNotExists,
+		infoschema.ErrTableNotExists,
+		dbterror.ErrCancelledDDLJob,
+	} {
+		if e.Equal(err) {
+			return true
+		}
+	}
+	switch errors.Cause(err).(type) {
+	case *json.SyntaxError, *json.UnmarshalTypeError:
+		// The task meta or the subtask meta is broken.
+		return true
+	}
+	return false
+}
+
+// cleanUpGlobalSortFilesOfTask deletes the global sort files of the task. It's best effort, the files left
+// are cleaned up again by BackfillCleanUpS3 after the task is finished.
+func cleanUpGlobalSortFilesOfTask(ctx context.Context, taskMeta *BackfillGlobalMeta, logger *zap.Logger) {
+	leaked, err := cleanUpGlobalSortFiles(ctx, taskMeta.CloudStorageURI, taskMeta.Job.ID)
 	if err != nil {
-		return errors.Trace(err)
+		logger.Warn("failed to clean up global sort files", zap.Int64("left-bytes", leaked), zap.Error(err))
+		return
 	}
-	gTask.Meta = bs
-	return nil
+	logger.Info("global sort files cleaned up")
 }
 
 // GetNextStep implements dispatcher.Extension interface.
@@ -181,7 +620,7 @@ func skipMergeSort(stats []external.MultipleFilesStat) bool {
 }
 
 // OnErrStage generate error handling stage's plan.
-func (*BackfillingDispatcherExt) OnErrStage(_ context.Context, _ dispatcher.TaskHandle, task *proto.Task, receiveErrs []error) (meta []byte, err error) {
+func (dsp *BackfillingDispatcherExt) OnErrStage(ctx context.Context, _ dispatcher.TaskHandle, task *proto.Task, receiveErrs []error) (meta []byte, err error) {
 	// We do not need extra meta info when rolling back
 	logger := logutil.BgLogger().With(
 		zap.Stringer("type", task.Type),
@@ -192,15 +631,31 @@ func (*BackfillingDispatcherExt) OnErrStage(_ context.Context, _ dispatcher.Task
 	firstErr := receiveErrs[0]
 	task.Error = firstErr
 
+	if dsp.GlobalSort {
+		var backfillMeta BackfillGlobalMeta
+		if err := json.Unmarshal(task.Meta, &backfillMeta); err != nil {
+			return nil, err
+		}
+		// The task is going to be reverted, the sorted KV files written so far are useless.
+		cleanUpGlobalSortFilesOfTask(ctx, &backfillMeta, logger)
+	}
+
 	return nil, nil
 }
 
 // GetEligibleInstances implements dispatcher.Extension interface.
-func (dsp *BackfillingDispatcherExt) GetEligibleInstances(ctx context.Context, _ *proto.Task) ([]*infosync.ServerInfo, error) {
+func (dsp *BackfillingDispatcherExt) GetEligibleInstances(ctx context.Context, task *proto.Task) ([]*infosync.ServerInfo, error) {
 	serverInfos, err := dispatcher.GenerateSchedulerNodes(ctx)
 	if err != nil {
 		return nil, err
 	}
+	if task != nil && len(task.Meta) > 0 {
+		var taskMeta BackfillGlobalMeta
+		if err := json.Unmarshal(task.Meta, &taskMeta); err != nil {
+			return nil, errors.Trace(err)
+		}
+		serverInfos = filterByMinVersion(ctx, serverInfos, taskMeta.MinVersion)
+	}
 	if len(dsp.previousSchedulerIDs) > 0 {
 		// Only the nodes that executed step one can have step two.
 		involvedServerInfos := make([]*infosync.ServerInfo, 0, len(serverInfos))
@@ -214,9 +669,144 @@ func (dsp *BackfillingDispatcherExt) GetEligibleInstances(ctx context.Context, _
 	return serverInfos, nil
 }
 
+// parseTiDBVersion extracts the TiDB version from the server version, such as "8.0.11-TiDB-v7.5.0-alpha".
+// The pre-release and build parts are ignored, nil is returned if the version is unknown, e.g. a dev build.
+func parseTiDBVersion(serverVersion string) *semver.Version {
+	v, err := version.ExtractTiDBVersion(serverVersion)
+	if err != nil {
+		return nil
+	}
+	return &semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+}
+
+// getBackfillMinVersion returns the version of the current node, which is the minimum version of
+// the nodes to run the backfilling subtasks.
+func getBackfillMinVersion() string {
+	if v := parseTiDBVersion(mysql.ServerVersion); v != nil {
+		return v.String()
+	}
+	return ""
+}
+
+// filterByMinVersion filters out the nodes whose version is lower than minVersion, they may lack the
+// executors of the steps during rolling upgrades. The nodes of unknown versions are kept.
+func filterByMinVersion(ctx context.Context, serverInfos []*infosync.ServerInfo, minVersion string) []*infosync.ServerInfo {
+	if minVersion == "" {
+		return serverInfos
+	}
+	minVer, err := semver.NewVersion(minVersion)
+	if err != nil {
+		return serverInfos
+	}
+	eligible := make([]*infosync.ServerInfo, 0, len(serverInfos))
+	for _, info := range serverInfos {
+		v := parseTiDBVersion(info.Version)
+		if v != nil && v.LessThan(*minVer) {
+			logutil.Logger(ctx).Info("skip the node of lower version", zap.String("category", "ddl"),
+				zap.String("node", disttaskutil.GenerateExecID(info.IP, info.Port)),
+				zap.String("version", info.Version), zap.String("minVersion", minVersion))
+			continue
+		}
+		eligible = append(eligible, info)
+	}
+	return eligible
+}
+
 // IsRetryableErr implements dispatcher.Extension.IsRetryableErr interface.
-func (*BackfillingDispatcherExt) IsRetryableErr(error) bool {
-	return true
+func (dsp *BackfillingDispatcherExt) IsRetryableErr(err error) bool {
+	return !isPermanentBackfillErr(err) && dsp.planRetryCnt <= backfillPlanMaxRetryCnt
+}
+
+var _ dispatcher.SpeculativeExtension = (*BackfillingDispatcherExt)(nil)
+
+// IsSpeculativeStep implements dispatcher.SpeculativeExtension interface.
+// Only the read index step with global sort can run speculatively, its output is the sorted KV files
+// recorded in the subtask meta, so the files of the discarded subtask are simply not used. Without
+// global sort, the index records are written to the local engine of the node directly.
+func (dsp *BackfillingDispatcherExt) IsSpeculativeStep(task *proto.Task) bool {
+	return dsp.GlobalSort && task.Step == StepReadIndex
+}
+
+var _ dispatcher.ReplanExtension = (*BackfillingDispatcherExt)(nil)
+
+// ReplanOnErr implements dispatcher.ReplanExtension interface.
+// If the read index step of global sort fails since the cloud storage is unavailable, the task falls
+// back to local sort instead of failing the DDL job, i.e. the read index step is planned again to write
+// the index records to the local engines, which are ingested by the write&ingest step then.
+func (dsp *BackfillingDispatcherExt) ReplanOnErr(
+	ctx context.Context,
+	taskHandle dispatcher.TaskHandle,
+	task *proto.Task,
+	receiveErrs []error,
+) ([][]byte, bool, error) {
+	if !dsp.GlobalSort || task.Step != StepReadIndex || !isGlobalSortStorageErrs(receiveErrs) {
+		return nil, false, nil
+	}
+	logger := logutil.BgLogger().With(
+		zap.Stringer("type", task.Type),
+		zap.Int64("task-id", task.ID),
+		zap.String("step", StepStr(task.Step)),
+	)
+	logicalPlan := &BackfillingLogicalPlan{
+		dsp:        dsp,
+		taskHandle: taskHandle,
+		task:       task,
+		logger:     logger,
+	}
+	if err := logicalPlan.FromTaskMeta(task.Meta); err != nil {
+		return nil, false, err
+	}
+	meta := &logicalPlan.Meta
+	var err error
+	logicalPlan.tblInfo, err = getTblInfo(dsp.d, &meta.Job)
+	if err != nil {
+		return nil, false, err
+	}
+	logger.Warn("cloud storage of global sort is unavailable, fall back to local sort", zap.Errors("errors", receiveErrs))
+	// The files written so far are cleaned up when the task finishes, the storage may be back then.
+	meta.FallbackCloudStorageURI = meta.CloudStorageURI
+	meta.CloudStorageURI = ""
+	meta.GlobalSortStats = nil
+	if meta.Job.ReorgMeta != nil {
+		meta.Job.ReorgMeta.UseCloudStorage = false
+	}
+	planCtx := planner.PlanCtx{
+		Ctx:          ctx,
+		TaskID:       task.ID,
+		GlobalSort:   false,
+		NextTaskStep: StepReadIndex,
+	}
+	physicalPlan, err := logicalPlan.ToPhysicalPlan(planCtx)
+	if err != nil {
+		return nil, false, err
+	}
+	metas, err := physicalPlan.ToSubtaskMetas(planCtx, StepReadIndex)
+	if err != nil {
+		return nil, false, err
+	}
+	taskMeta, err := logicalPlan.ToTaskMeta()
+	if err != nil {
+		return nil, false, errors.Trace(err)
+	}
+	task.Meta = taskMeta
+	dsp.GlobalSort = false
+	return metas, true, nil
+}
+
+// isGlobalSortStorageErrs checks whether the subtasks fail only because the cloud storage of global sort is
+// unavailable. The canceled subtasks have no error.
+func isGlobalSortStorageErrs(errs []error) bool {
+	found := false
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+		if !errGlobalSortStorage.Equal(err) {
+			return false
+		}
+		found = true
+	}
+	return found
 }
 
 // LitBackfillDispatcher wraps BaseDispatcher.
@@ -240,9 +830,14 @@ func (dsp *LitBackfillDispatcher) Init() (err error) {
 	if err = json.Unmarshal(dsp.BaseDispatcher.Task.Meta, taskMeta); err != nil {
 		return errors.Annotate(err, "unmarshal task meta failed")
 	}
+	// The state of the extension is restored from the task meta, in case the task is taken over from
+	// the previous dispatcher owner.
 	dsp.BaseDispatcher.Extension = &BackfillingDispatcherExt{
-		d:          dsp.d,
-		GlobalSort: len(taskMeta.CloudStorageURI) > 0}
+		d:                    dsp.d,
+		GlobalSort:           len(taskMeta.CloudStorageURI) > 0,
+		previousSchedulerIDs: taskMeta.PreviousSchedulerIDs,
+		progress:             backfillProgressEstimator{totalRows: taskMeta.TotalRows},
+	}
 	return dsp.BaseDispatcher.Init()
 }
 
@@ -254,6 +849,9 @@ func (dsp *LitBackfillDispatcher) Close() {
 func getTblInfo(d *ddl, job *model.Job) (tblInfo *model.TableInfo, err error) {
 	err = kv.RunInNewTxn(d.ctx, d.store, true, func(ctx context.Context, txn kv.Transaction) error {
 		tblInfo, err = meta.NewMeta(txn).GetTable(job.SchemaID, job.TableID)
+		if err == nil && tblInfo == nil {
+			err = meta.ErrTableNotExists.GenWithStack("table doesn't exist")
+		}
 		return err
 	})
 	if err != nil {
@@ -263,30 +861,47 @@ func getTblInfo(d *ddl, job *model.Job) (tblInfo *model.TableInfo, err error) {
 	return tblInfo, nil
 }
 
-func generatePartitionPlan(tblInfo *model.TableInfo) (metas [][]byte, err error) {
+func generatePartitionPlan(tblInfo *model.TableInfo) ([]planner.PipelineSpec, error) {
 	defs := tblInfo.Partition.Definitions
 	physicalIDs := make([]int64, len(defs))
 	for i := range defs {
 		physicalIDs[i] = defs[i].ID
 	}
 
-	subTaskMetas := make([][]byte, 0, len(physicalIDs))
+	specs := make([]planner.PipelineSpec, 0, len(physicalIDs))
 	for _, physicalID := range physicalIDs {
-		subTaskMeta := &BackfillSubTaskMeta{
-			PhysicalTableID: physicalID,
-		}
+		specs = append(specs, &ReadIndexSpec{
+			BackfillSubTaskMeta: &BackfillSubTaskMeta{
+				PhysicalTableID: physicalID,
+			},
+		})
+	}
+	return specs, nil
+}
 
-		metaBytes, err := json.Marshal(subTaskMeta)
-		if err != nil {
-			return nil, err
-		}
+const (
+	// backfillSubtaskCntPerNode is the expected number of the read index subtasks dispatched to each node when
+	// the region batch is computed automatically. More subtasks balance the load better among the nodes, while
+	// each subtask has its own scheduling and initialization overhead.
+	backfillSubtaskCntPerNode = 4
+	// backfillMaxRegionBatch is the upper bound of the automatically computed region batch, which bounds the
+	// amount of the data to be read again when a subtask is retried.
+	backfillMaxRegionBatch = 1000
+)
 
-		subTaskMetas = append(subTaskMetas, metaBytes)
+// getRegionBatch returns the number of regions scanned by one read index subtask. It is specified by
+// tidb_ddl_backfill_region_batch, or computed from the region count of the table and the number of the
+// eligible nodes so that every node gets about backfillSubtaskCntPerNode subtasks.
+func getRegionBatch(regionCnt, nodeCnt int) int {
+	if batch := variable.DDLBackfillRegionBatch.Load(); batch > 0 {
+		return int(batch)
 	}
-	return subTaskMetas, nil
+	subtaskCnt := backfillSubtaskCntPerNode * max(nodeCnt, 1)
+	batch := (regionCnt + subtaskCnt - 1) / subtaskCnt
+	return min(max(batch, 1), backfillMaxRegionBatch)
 }
 
-func generateNonPartitionPlan(d *ddl, tblInfo *model.TableInfo, job *model.Job) (metas [][]byte, err error) {
+func generateNonPartitionPlan(d *ddl, tblInfo *model.TableInfo, job *model.Job, nodeCnt int) ([]planner.PipelineSpec, error) {
 	tbl, err := getTable(d.store, job.SchemaID, tblInfo)
 	if err != nil {
 		return nil, err
@@ -309,8 +924,10 @@ func generateNonPartitionPlan(d *ddl, tblInfo *model.TableInfo, job *model.Job)
 		return nil, err
 	}
 
-	subTaskMetas := make([][]byte, 0, 100)
-	regionBatch := 20
+	regionBatch := getRegionBatch(len(recordRegionMetas), nodeCnt)
+	logutil.BgLogger().Info("generate read index subtasks", zap.String("category", "ddl"), zap.Int64("job-id", job.ID),
+		zap.Int("region-cnt", len(recordRegionMetas)), zap.Int("node-cnt", nodeCnt), zap.Int("region-batch", regionBatch))
+	specs := make([]planner.PipelineSpec, 0, (len(recordRegionMetas)+regionBatch-1)/regionBatch)
 	sort.Slice(recordRegionMetas, func(i, j int) bool {
 		return bytes.Compare(recordRegionMetas[i].StartKey(), recordRegionMetas[j].StartKey()) < 0
 	})
@@ -327,13 +944,12 @@ func generateNonPartitionPlan(d *ddl, tblInfo *model.TableInfo, job *model.Job)
 		if end == len(recordRegionMetas) {
 			subTaskMeta.EndKey = endKey
 		}
-		metaBytes, err := json.Marshal(subTaskMeta)
-		if err != nil {
+		if err := getBackfillingDispatcherHook().OnSplitRange(job.ID, StepReadIndex, subTaskMeta.StartKey, subTaskMeta.EndKey); err != nil {
 			return nil, err
 		}
-		subTaskMetas = append(subTaskMetas, metaBytes)
+		specs = append(specs, &ReadIndexSpec{BackfillSubTaskMeta: subTaskMeta})
 	}
-	return subTaskMetas, nil
+	return specs, nil
 }
 
 func generateIngestTaskPlan(
@@ -341,7 +957,7 @@ func generateIngestTaskPlan(
 	h *BackfillingDispatcherExt,
 	taskHandle dispatcher.TaskHandle,
 	gTask *proto.Task,
-) ([][]byte, error) {
+) ([]planner.PipelineSpec, error) {
 	// We dispatch dummy subtasks because the rest data in local engine will be imported
 	// in the initialization of subtask executor.
 	var ingestSubtaskCnt int
@@ -360,16 +976,11 @@ func generateIngestTaskPlan(
 		ingestSubtaskCnt = len(schedulerIDs)
 	}
 
-	subTaskMetas := make([][]byte, 0, ingestSubtaskCnt)
-	dummyMeta := &BackfillSubTaskMeta{}
-	metaBytes, err := json.Marshal(dummyMeta)
-	if err != nil {
-		return nil, err
-	}
+	specs := make([]planner.PipelineSpec, 0, ingestSubtaskCnt)
 	for i := 0; i < ingestSubtaskCnt; i++ {
-		subTaskMetas = append(subTaskMetas, metaBytes)
+		specs = append(specs, &WriteIngestSpec{BackfillSubTaskMeta: &BackfillSubTaskMeta{}})
 	}
-	return subTaskMetas, nil
+	return specs, nil
 }
 
 func generateGlobalSortIngestPlan(
@@ -379,9 +990,11 @@ func generateGlobalSortIngestPlan(
 	jobID int64,
 	cloudStorageURI string,
 	step proto.Step,
+	tblInfo *model.TableInfo,
+	eleIDs []int64,
 	logger *zap.Logger,
-) ([][]byte, error) {
-	firstKey, lastKey, totalSize, dataFiles, statFiles, err := getSummaryFromLastStep(taskHandle, task.ID, step)
+) ([]planner.PipelineSpec, error) {
+	kvGroups, err := getSummaryFromLastStep(taskHandle, task.ID, step, tblInfo, eleIDs)
 	if err != nil {
 		return nil, err
 	}
@@ -389,8 +1002,33 @@ func generateGlobalSortIngestPlan(
 	if err != nil {
 		return nil, err
 	}
+	specs := make([]planner.PipelineSpec, 0, 16)
+	for _, g := range kvGroups {
+		if len(g.DataFiles) == 0 {
+			continue
+		}
+		groupSpecs, err := splitSubtaskMetaForOneGroup(ctx, cloudStorageURI, jobID, g, len(instanceIDs), logger)
+		if err != nil {
+			return nil, err
+		}
+		specs = append(specs, groupSpecs...)
+	}
+	return specs, nil
+}
+
+// splitSubtaskMetaForOneGroup splits the sorted KV of an index in a physical table into the subtasks
+// of the ingest step, so the range of a subtask never crosses the boundary of the physical tables.
+func splitSubtaskMetaForOneGroup(
+	ctx context.Context,
+	cloudStorageURI string,
+	jobID int64,
+	kvMeta *sortedKVGroup,
+	instanceCnt int,
+	logger *zap.Logger,
+) ([]planner.PipelineSpec, error) {
 	splitter, err := getRangeSplitter(
-		ctx, cloudStorageURI, jobID, int64(totalSize), int64(len(instanceIDs)), dataFiles, statFiles, logger)
+		ctx, cloudStorageURI, jobID, int64(kvMeta.TotalKVSize), int64(instanceCnt),
+		kvMeta.DataFiles, kvMeta.StatFiles, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -401,8 +1039,8 @@ func generateGlobalSortIngestPlan(
 		}
 	}()
 
-	metaArr := make([][]byte, 0, 16)
-	startKey := firstKey
+	specs := make([]planner.PipelineSpec, 0, 16)
+	startKey := kv.Key(kvMeta.MinKey)
 	var endKey kv.Key
 	for {
 		endKeyOfGroup, dataFiles, statFiles, rangeSplitKeys, err := splitter.SplitOneRangesGroup()
@@ -410,34 +1048,37 @@ func generateGlobalSortIngestPlan(
 			return nil, err
 		}
 		if len(endKeyOfGroup) == 0 {
-			endKey = lastKey.Next()
+			endKey = kv.Key(kvMeta.MaxKey).Next()
 		} else {
 			endKey = kv.Key(endKeyOfGroup).Clone()
 		}
 		logger.Info("split subtask range",
+			zap.Int64("eleID", kvMeta.eleID),
+			zap.Int64("physicalTableID", kvMeta.physicalTableID),
 			zap.String("startKey", hex.EncodeToString(startKey)),
 			zap.String("endKey", hex.EncodeToString(endKey)))
 		if startKey.Cmp(endKey) >= 0 {
 			return nil, errors.Errorf("invalid range, startKey: %s, endKey: %s",
 				hex.EncodeToString(startKey), hex.EncodeToString(endKey))
 		}
+		if err := getBackfillingDispatcherHook().OnSplitRange(jobID, StepWriteAndIngest, startKey, endKey); err != nil {
+			return nil, err
+		}
 		m := &BackfillSubTaskMeta{
 			SortedKVMeta: external.SortedKVMeta{
 				MinKey:      startKey,
 				MaxKey:      endKey,
 				DataFiles:   dataFiles,
 				StatFiles:   statFiles,
-				TotalKVSize: totalSize / uint64(len(instanceIDs)),
+				TotalKVSize: kvMeta.TotalKVSize / uint64(instanceCnt),
 			},
-			RangeSplitKeys: rangeSplitKeys,
+			PhysicalTableID: kvMeta.physicalTableID,
+			RangeSplitKeys:  rangeSplitKeys,
+			EleID:           kvMeta.eleID,
 		}
-		metaBytes, err := json.Marshal(m)
-		if err != nil {
-			return nil, err
-		}
-		metaArr = append(metaArr, metaBytes)
+		specs = append(specs, &WriteIngestSpec{BackfillSubTaskMeta: m})
 		if len(endKeyOfGroup) == 0 {
-			return metaArr, nil
+			return specs, nil
 		}
 		startKey = endKey
 	}
@@ -446,56 +1087,123 @@ func generateGlobalSortIngestPlan(
 func generateMergePlan(
 	taskHandle dispatcher.TaskHandle,
 	task *proto.Task,
+	tblInfo *model.TableInfo,
+	eleIDs []int64,
+	nodeCnt int,
+	compressData bool,
 	logger *zap.Logger,
-) ([][]byte, error) {
+) ([]planner.PipelineSpec, error) {
 	// check data files overlaps,
 	// if data files overlaps too much, we need a merge step.
-	subTaskMetas, err := taskHandle.GetPreviousSubtaskMetas(task.ID, StepReadIndex)
+	kvGroups, err := getSummaryFromLastStep(taskHandle, task.ID, StepReadIndex, tblInfo, eleIDs)
 	if err != nil {
 		return nil, err
 	}
 	multiStats := make([]external.MultipleFilesStat, 0, 100)
-	for _, bs := range subTaskMetas {
-		var subtask BackfillSubTaskMeta
-		err = json.Unmarshal(bs, &subtask)
-		if err != nil {
-			return nil, err
-		}
-		multiStats = append(multiStats, subtask.MultipleFilesStats...)
+	for _, g := range kvGroups {
+		multiStats = append(multiStats, g.MultipleFilesStats...)
 	}
 	if skipMergeSort(multiStats) {
 		logger.Info("skip merge sort")
 		return nil, nil
 	}
 
-	// generate merge sort plan.
-	_, _, _, dataFiles, _, err := getSummaryFromLastStep(taskHandle, task.ID, StepReadIndex)
+	memTotal, err := memory.MemTotal()
 	if err != nil {
 		return nil, err
 	}
-
-	start := 0
-	step := external.MergeSortFileCountStep
-	metaArr := make([][]byte, 0, 16)
-	for start < len(dataFiles) {
-		end := start + step
-		if end > len(dataFiles) {
-			end = len(dataFiles)
+	maxFileCnt := getMergeSortMaxFileCount(memTotal, compressData)
+	// generate merge sort plan, each subtask merges the files of one index in a physical table.
+	specs := make([]planner.PipelineSpec, 0, 16)
+	for _, g := range kvGroups {
+		subtaskCnt := (len(g.DataFiles) + maxFileCnt - 1) / maxFileCnt
+		overlap := external.GetMaxOverlappingTotal(g.MultipleFilesStats)
+		// The files which barely overlap are cheap to merge, only the heavily overlapped
+		// ones are spread over the nodes.
+		if overlap > external.MergeSortOverlapThreshold {
+			subtaskCnt = max(subtaskCnt, nodeCnt)
 		}
-		m := &BackfillSubTaskMeta{
-			SortedKVMeta: external.SortedKVMeta{
-				DataFiles: dataFiles[start:end],
-			},
+		fileGroups := splitMergeSortFiles(g.fileSizes, maxFileCnt, subtaskCnt)
+		for _, r := range fileGroups {
+			specs = append(specs, &MergeSortSpec{
+				BackfillSubTaskMeta: &BackfillSubTaskMeta{
+					PhysicalTableID: g.physicalTableID,
+					SortedKVMeta: external.SortedKVMeta{
+						DataFiles: g.DataFiles[r[0]:r[1]],
+					},
+					EleID: g.eleID,
+				},
+			})
 		}
-		metaBytes, err := json.Marshal(m)
-		if err != nil {
-			return nil, err
+		logger.Info("generate merge sort plan",
+			zap.Int64("element-id", g.eleID),
+			zap.Int64("physical-table-id", g.physicalTableID),
+			zap.Int("file-count", len(g.DataFiles)),
+			zap.Uint64("total-kv-size", g.TotalKVSize),
+			zap.Int64("max-overlapping", overlap),
+			zap.Int("max-file-count", maxFileCnt),
+			zap.Int("subtask-count", len(fileGroups)))
+	}
+	return specs, nil
+}
+
+// getMergeSortMaxFileCount returns the max count of the data files merged by a subtask. Like the read
+// index step, half of the node memory is used by a subtask. Each data file is read with its own buffer,
+// and the decoder of a compressed data file also holds a frame, which is a range property of the file.
+func getMergeSortMaxFileCount(memTotal uint64, compressData bool) int {
+	budget := memTotal / 2
+	reserved := mergeSortWriterMemLimit +
+		uint64(external.ConcurrentReaderBufferSizePerConc*external.ConcurrentReaderConcurrency)
+	perFile := uint64(mergeSortReadBufferSize)
+	if compressData {
+		perFile += 2 * mergeSortPropSizeDist
+	}
+	// At least 2 files are merged, otherwise the merge sort never ends.
+	if budget <= reserved+2*perFile {
+		return 2
+	}
+	return min(external.MergeSortFileCountStep, int((budget-reserved)/perFile))
+}
+
+// splitMergeSortFiles splits the data files into at least subtaskCnt contiguous groups, each of which
+// has no more than maxFileCnt files. The groups are balanced by the file sizes, so a subtask merging
+// the large files doesn't last much longer than the others. It returns the [start, end) of the groups.
+func splitMergeSortFiles(fileSizes []uint64, maxFileCnt, subtaskCnt int) [][2]int {
+	if len(fileSizes) == 0 {
+		return nil
+	}
+	var totalSize uint64
+	for _, sz := range fileSizes {
+		totalSize += sz
+	}
+	// The file sizes are unknown, balance the groups by the file count.
+	sizeUnknown := totalSize == 0
+	if sizeUnknown {
+		totalSize = uint64(len(fileSizes))
+	}
+	weight := func(i int) uint64 {
+		if sizeUnknown {
+			return 1
 		}
-		metaArr = append(metaArr, metaBytes)
+		return fileSizes[i]
+	}
+	subtaskCnt = min(max(subtaskCnt, 1), len(fileSizes))
+	targetSize := (totalSize + uint64(subtaskCnt) - 1) / uint64(subtaskCnt)
 
-		start = end
+	groups := make([][2]int, 0, subtaskCnt)
+	start := 0
+	var curSize uint64
+	for i := range fileSizes {
+		curSize += weight(i)
+		if i+1-start >= maxFileCnt || curSize >= targetSize {
+			groups = append(groups, [2]int{start, i + 1})
+			start, curSize = i+1, 0
+		}
+	}
+	if start < len(fileSizes) {
+		groups = append(groups, [2]int{start, len(fileSizes)})
 	}
-	return metaArr, nil
+	return groups
 }
 
 func getRangeSplitter(
@@ -507,17 +1215,11 @@ func getRangeSplitter(
 	dataFiles, statFiles []string,
 	logger *zap.Logger,
 ) (*external.RangeSplitter, error) {
-	backend, err := storage.ParseBackend(cloudStorageURI, nil)
-	if err != nil {
-		return nil, err
-	}
-	extStore, err := storage.NewWithDefaultOpt(ctx, backend)
+	extStore, err := newGlobalSortStore(ctx, cloudStorageURI)
 	if err != nil {
 		return nil, err
 	}
 
-	rangeGroupSize := totalSize / instanceCnt
-	rangeGroupKeys := int64(math.MaxInt64)
 	bcCtx, ok := ingest.LitBackCtxMgr.Load(jobID)
 	if !ok {
 		return nil, errors.Errorf("backend context not found")
@@ -531,45 +1233,128 @@ func getRangeSplitter(
 	if err != nil {
 		logger.Warn("fail to get region split keys and size", zap.Error(err))
 	}
-	maxSizePerRange = max(maxSizePerRange, int64(config.SplitRegionSize))
-	maxKeysPerRange = max(maxKeysPerRange, int64(config.SplitRegionKeys))
+	rangeGroupSize, rangeGroupKeys, maxSizePerRange, maxKeysPerRange := getRangeSplitterSizeKeys(
+		totalSize, instanceCnt, maxSizePerRange, maxKeysPerRange)
+	logger.Info("get range splitter",
+		zap.Int64("rangeGroupSize", rangeGroupSize),
+		zap.Int64("rangeGroupKeys", rangeGroupKeys),
+		zap.Int64("maxSizePerRange", maxSizePerRange),
+		zap.Int64("maxKeysPerRange", maxKeysPerRange))
 
 	return external.NewRangeSplitter(ctx, dataFiles, statFiles, extStore,
 		rangeGroupSize, rangeGroupKeys, maxSizePerRange, maxKeysPerRange)
 }
 
+// getRangeSplitterSizeKeys returns the size and keys limits of a range group, i.e. a subtask, and of
+// a range in it. By default, the sorted KV is divided evenly by the nodes and a range is at most as
+// large as a region, they can be tuned by tidb_ddl_global_sort_range_group_size,
+// tidb_ddl_global_sort_max_keys_per_range and tidb_ddl_global_sort_max_ranges_per_subtask.
+func getRangeSplitterSizeKeys(
+	totalSize, instanceCnt, regionSplitSize, regionSplitKeys int64,
+) (rangeGroupSize, rangeGroupKeys, maxSizePerRange, maxKeysPerRange int64) {
+	rangeGroupSize = totalSize / instanceCnt
+	if size := variable.DDLGlobalSortRangeGroupSize.Load(); size > 0 {
+		rangeGroupSize = size
+	}
+	rangeGroupKeys = int64(math.MaxInt64)
+
+	maxSizePerRange = max(regionSplitSize, int64(config.SplitRegionSize))
+	maxKeysPerRange = max(regionSplitKeys, int64(config.SplitRegionKeys))
+	if keys := variable.DDLGlobalSortMaxKeysPerRange.Load(); keys > 0 {
+		maxKeysPerRange = keys
+	}
+
+	// The ranges are split by the size and keys limits of a range, so limiting the size and keys of
+	// the range group limits the number of ranges in it.
+	if maxRanges := variable.DDLGlobalSortMaxRangesPerSubtask.Load(); maxRanges > 0 {
+		if maxSizePerRange <= math.MaxInt64/maxRanges {
+			rangeGroupSize = min(rangeGroupSize, maxRanges*maxSizePerRange)
+		}
+		if maxKeysPerRange <= math.MaxInt64/maxRanges {
+			rangeGroupKeys = maxRanges * maxKeysPerRange
+		}
+	}
+	return rangeGroupSize, rangeGroupKeys, maxSizePerRange, maxKeysPerRange
+}
+
+// sortedKVGroup is the sorted KV of an index in a physical table. The keys of different physical tables
+// are merged and ingested by different subtasks.
+type sortedKVGroup struct {
+	eleID           int64
+	physicalTableID int64
+	external.SortedKVMeta
+	// fileSizes are the estimated sizes of DataFiles.
+	fileSizes []uint64
+}
+
+// merge merges the sorted KV written by a subtask into the group. The sizes of the data files are not
+// recorded, they're estimated evenly from the total KV size written by the subtask.
+func (g *sortedKVGroup) merge(m *external.SortedKVMeta) {
+	g.Merge(m)
+	for range m.DataFiles {
+		g.fileSizes = append(g.fileSizes, m.TotalKVSize/uint64(len(m.DataFiles)))
+	}
+}
+
+// getSummaryFromLastStep groups the sorted KV written by the subtasks of the step by the index and the physical table.
 func getSummaryFromLastStep(
 	taskHandle dispatcher.TaskHandle,
 	gTaskID int64,
 	step proto.Step,
-) (min, max kv.Key, totalKVSize uint64, dataFiles, statFiles []string, err error) {
+	tblInfo *model.TableInfo,
+	eleIDs []int64,
+) ([]*sortedKVGroup, error) {
 	subTaskMetas, err := taskHandle.GetPreviousSubtaskMetas(gTaskID, step)
 	if err != nil {
-		return nil, nil, 0, nil, nil, errors.Trace(err)
+		return nil, errors.Trace(err)
+	}
+	kvGroups := make([]*sortedKVGroup, 0, len(eleIDs))
+	groupMap := make(map[[2]int64]*sortedKVGroup, len(eleIDs))
+	getGroup := func(eleID, physicalTableID int64) *sortedKVGroup {
+		idxInfo := model.FindIndexInfoByID(tblInfo.Indices, eleID)
+		if idxInfo != nil && idxInfo.Global {
+			// The keys of a global index are encoded with the logical table ID.
+			physicalTableID = tblInfo.ID
+		}
+		key := [2]int64{eleID, physicalTableID}
+		g, ok := groupMap[key]
+		if !ok {
+			g = &sortedKVGroup{eleID: eleID, physicalTableID: physicalTableID}
+			groupMap[key] = g
+			kvGroups = append(kvGroups, g)
+		}
+		return g
 	}
-	var minKey, maxKey kv.Key
-	allDataFiles := make([]string, 0, 16)
-	allStatFiles := make([]string, 0, 16)
 	for _, subTaskMeta := range subTaskMetas {
 		var subtask BackfillSubTaskMeta
 		err := json.Unmarshal(subTaskMeta, &subtask)
 		if err != nil {
-			return nil, nil, 0, nil, nil, errors.Trace(err)
-		}
-		// Skip empty subtask.MinKey/MaxKey because it means
-		// no records need to be written in this subtask.
-		minKey = external.NotNilMin(minKey, subtask.MinKey)
-		maxKey = external.NotNilMax(maxKey, subtask.MaxKey)
-		totalKVSize += subtask.TotalKVSize
-
-		for _, stat := range subtask.MultipleFilesStats {
-			for i := range stat.Filenames {
-				allDataFiles = append(allDataFiles, stat.Filenames[i][0])
-				allStatFiles = append(allStatFiles, stat.Filenames[i][1])
+			return nil, errors.Trace(err)
+		}
+		// The subtasks of the read index step write the sorted KV of all the indexes.
+		if len(subtask.MetaGroups) > 0 {
+			if len(subtask.MetaGroups) != len(eleIDs) {
+				return nil, errors.Errorf("unexpected meta groups count %d, expected %d",
+					len(subtask.MetaGroups), len(eleIDs))
+			}
+			for i, m := range subtask.MetaGroups {
+				// Empty MinKey/MaxKey are skipped when merging because it means
+				// no records need to be written in this subtask.
+				getGroup(eleIDs[i], subtask.PhysicalTableID).merge(m)
+			}
+			continue
+		}
+		// The subtasks of the merge sort step write the sorted KV of one index.
+		eleID := eleIDs[0]
+		if subtask.EleID != 0 {
+			if !slices.Contains(eleIDs, subtask.EleID) {
+				return nil, errors.Errorf("unknown element ID %d", subtask.EleID)
 			}
+			eleID = subtask.EleID
 		}
+		getGroup(eleID, subtask.PhysicalTableID).merge(&subtask.SortedKVMeta)
 	}
-	return minKey, maxKey, totalKVSize, allDataFiles, allStatFiles, nil
+	return kvGroups, nil
 }
 
 // StepStr convert proto.Step to string.
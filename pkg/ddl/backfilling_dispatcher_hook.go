@@ -0,0 +1,81 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"sync"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/util/intest"
+)
+
+// BackfillingDispatcherHook is used to inject the failures into the backfilling dispatcher at the given
+// points, so the tests can exercise the crash and retry paths deterministically. The error returned by
+// the hook is handled as if the dispatcher failed at that point. It's only used for test.
+type BackfillingDispatcherHook interface {
+	// BeforeStepTransition is called before generating the subtasks of the next step.
+	BeforeStepTransition(task *proto.Task, nextStep proto.Step) error
+	// AfterSubtaskMetaGenerated is called after the subtask metas of the next step are generated.
+	AfterSubtaskMetaGenerated(task *proto.Task, nextStep proto.Step, metas [][]byte) error
+	// OnSplitRange is called when the range of a subtask of the step is split out.
+	OnSplitRange(jobID int64, step proto.Step, startKey, endKey kv.Key) error
+}
+
+// BaseBackfillingDispatcherHook implements BackfillingDispatcherHook, it injects nothing.
+type BaseBackfillingDispatcherHook struct{}
+
+var _ BackfillingDispatcherHook = BaseBackfillingDispatcherHook{}
+
+// BeforeStepTransition implements BackfillingDispatcherHook.BeforeStepTransition interface.
+func (BaseBackfillingDispatcherHook) BeforeStepTransition(*proto.Task, proto.Step) error {
+	return nil
+}
+
+// AfterSubtaskMetaGenerated implements BackfillingDispatcherHook.AfterSubtaskMetaGenerated interface.
+func (BaseBackfillingDispatcherHook) AfterSubtaskMetaGenerated(*proto.Task, proto.Step, [][]byte) error {
+	return nil
+}
+
+// OnSplitRange implements BackfillingDispatcherHook.OnSplitRange interface.
+func (BaseBackfillingDispatcherHook) OnSplitRange(int64, proto.Step, kv.Key, kv.Key) error {
+	return nil
+}
+
+var backfillingDispatcherHook struct {
+	sync.RWMutex
+	hook BackfillingDispatcherHook
+}
+
+// SetBackfillingDispatcherHook sets the hook of the backfilling dispatcher, nil removes it.
+// It's only used for test.
+func SetBackfillingDispatcherHook(hook BackfillingDispatcherHook) {
+	intest.Assert(intest.InTest, "the backfilling dispatcher hook is only used for test")
+	backfillingDispatcherHook.Lock()
+	defer backfillingDispatcherHook.Unlock()
+	backfillingDispatcherHook.hook = hook
+}
+
+func getBackfillingDispatcherHook() BackfillingDispatcherHook {
+	if !intest.InTest {
+		return BaseBackfillingDispatcherHook{}
+	}
+	backfillingDispatcherHook.RLock()
+	defer backfillingDispatcherHook.RUnlock()
+	if backfillingDispatcherHook.hook == nil {
+		return BaseBackfillingDispatcherHook{}
+	}
+	return backfillingDispatcherHook.hook
+}
@@ -17,6 +17,7 @@ package ddl_test
 import (
 	"context"
 	"encoding/json"
+	"math"
 	"testing"
 	"time"
 
@@ -29,9 +30,11 @@ import (
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain"
+	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/parser/mysql"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/stretchr/testify/require"
@@ -75,16 +78,17 @@ func TestBackfillingDispatcherLocalMode(t *testing.T) {
 		var subTask ddl.BackfillSubTaskMeta
 		require.NoError(t, json.Unmarshal(metas[i], &subTask))
 		require.Equal(t, par.ID, subTask.PhysicalTableID)
+		require.Equal(t, "default", subTask.ResourceGroupName)
 	}
 
 	// 1.2 test partition table OnNextSubtasksBatch after StepReadIndex
 	gTask.State = proto.TaskStateRunning
 	gTask.Step = dsp.GetNextStep(gTask)
 	require.Equal(t, ddl.StepWriteAndIngest, gTask.Step)
-	// for partition table, we will not generate subtask for StepWriteAndIngest.
+	// for partition table, each node ingests the partitions it read in a subtask of StepWriteAndIngest.
 	metas, err = dsp.OnNextSubtasksBatch(context.Background(), nil, gTask, gTask.Step)
 	require.NoError(t, err)
-	require.Len(t, metas, 0)
+	require.Len(t, metas, 1)
 	gTask.Step = dsp.GetNextStep(gTask)
 	require.Equal(t, proto.StepDone, gTask.Step)
 	metas, err = dsp.OnNextSubtasksBatch(context.Background(), nil, gTask, gTask.Step)
@@ -185,7 +189,7 @@ func TestBackfillingDispatcherGlobalSortMode(t *testing.T) {
 
 	// update meta, same as import into.
 	sortStepMeta := &ddl.BackfillSubTaskMeta{
-		SortedKVMeta: external.SortedKVMeta{
+		MetaGroups: []*external.SortedKVMeta{{
 			MinKey:      []byte("ta"),
 			MaxKey:      []byte("tc"),
 			TotalKVSize: 12,
@@ -198,7 +202,7 @@ func TestBackfillingDispatcherGlobalSortMode(t *testing.T) {
 					},
 				},
 			},
-		},
+		}},
 	}
 	sortStepMetaBytes, err := json.Marshal(sortStepMeta)
 	require.NoError(t, err)
@@ -215,6 +219,12 @@ func TestBackfillingDispatcherGlobalSortMode(t *testing.T) {
 	require.Len(t, subtaskMetas, 1)
 	task.Step = ext.GetNextStep(task)
 	require.Equal(t, ddl.StepMergeSort, task.Step)
+	var taskMeta ddl.BackfillGlobalMeta
+	require.NoError(t, json.Unmarshal(task.Meta, &taskMeta))
+	var mergeSubtaskMeta ddl.BackfillSubTaskMeta
+	require.NoError(t, json.Unmarshal(subtaskMetas[0], &mergeSubtaskMeta))
+	require.Equal(t, taskMeta.EleIDs[0], mergeSubtaskMeta.EleID)
+	require.Equal(t, []string{"gs://sort-bucket/data/1"}, mergeSubtaskMeta.DataFiles)
 
 	// update meta, same as import into.
 	subtasks = make([]*proto.Subtask, 0, len(subtaskMetas))
@@ -264,6 +274,104 @@ func TestBackfillingDispatcherGlobalSortMode(t *testing.T) {
 	require.Equal(t, proto.StepDone, task.Step)
 }
 
+func TestBackfillingDispatcherOnTick(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	tk := testkit.NewTestKit(t, store)
+	pool := pools.NewResourcePool(func() (pools.Resource, error) {
+		return tk.Session(), nil
+	}, 1, 1, time.Second)
+	defer pool.Close()
+	ctx := context.WithValue(context.Background(), "etcd", true)
+	mgr := storage.NewTaskManager(util.WithInternalSourceType(ctx, "taskManager"), pool)
+	storage.SetTaskManager(mgr)
+
+	tk.MustExec("use test")
+	tk.MustExec("create table t1(id bigint auto_random primary key)")
+	task := createAddIndexGlobalTask(t, dom, "test", "t1", proto.Backfill, false)
+	var taskMeta ddl.BackfillGlobalMeta
+	require.NoError(t, json.Unmarshal(task.Meta, &taskMeta))
+	taskMeta.WorkerCnt = 4
+	task.Meta, _ = json.Marshal(&taskMeta)
+	taskID, err := mgr.AddNewGlobalTask(task.Key, proto.Backfill, 1, task.Meta)
+	require.NoError(t, err)
+	task.ID = taskID
+	task.State = proto.TaskStateRunning
+	ext, err := ddl.NewBackfillingDispatcherExt(dom.DDL())
+	require.NoError(t, err)
+
+	getWorkerCnt := func(meta []byte) int {
+		var m ddl.BackfillGlobalMeta
+		require.NoError(t, json.Unmarshal(meta, &m))
+		return m.WorkerCnt
+	}
+	defer variable.SetDDLReorgWorkerCounter(variable.GetDDLReorgWorkerCounter())
+	variable.SetDDLReorgWorkerCounter(4)
+	ext.OnTick(ctx, task)
+	require.Equal(t, 4, getWorkerCnt(task.Meta))
+
+	// The new worker count is recorded in the task meta.
+	variable.SetDDLReorgWorkerCounter(8)
+	ext.OnTick(ctx, task)
+	require.Equal(t, 8, getWorkerCnt(task.Meta))
+	gotTask, err := mgr.GetGlobalTaskByID(taskID)
+	require.NoError(t, err)
+	require.Equal(t, 8, getWorkerCnt(gotTask.Meta))
+}
+
+type testBackfillingDispatcherHook struct {
+	ddl.BaseBackfillingDispatcherHook
+	failStep    proto.Step
+	splitRanges int
+	metaCnt     int
+}
+
+func (h *testBackfillingDispatcherHook) BeforeStepTransition(_ *proto.Task, nextStep proto.Step) error {
+	if nextStep == h.failStep {
+		h.failStep = proto.StepInit
+		return errors.New("mock step transition error")
+	}
+	return nil
+}
+
+func (h *testBackfillingDispatcherHook) AfterSubtaskMetaGenerated(_ *proto.Task, _ proto.Step, metas [][]byte) error {
+	h.metaCnt = len(metas)
+	return nil
+}
+
+func (h *testBackfillingDispatcherHook) OnSplitRange(_ int64, step proto.Step, startKey, endKey kv.Key) error {
+	if step == ddl.StepReadIndex && startKey.Cmp(endKey) < 0 {
+		h.splitRanges++
+	}
+	return nil
+}
+
+func TestBackfillingDispatcherHook(t *testing.T) {
+	store, dom := testkit.CreateMockStoreAndDomain(t)
+	dsp, err := ddl.NewBackfillingDispatcherExt(dom.DDL())
+	require.NoError(t, err)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t(id bigint auto_random primary key)")
+	tk.MustExec("insert into t values (), (), (), ()")
+	hook := &testBackfillingDispatcherHook{failStep: ddl.StepReadIndex}
+	ddl.SetBackfillingDispatcherHook(hook)
+	defer ddl.SetBackfillingDispatcherHook(nil)
+
+	gTask := createAddIndexGlobalTask(t, dom, "test", "t", proto.Backfill, false)
+	gTask.Step = dsp.GetNextStep(gTask)
+	// the injected failure is retried like the other errors of generating the subtasks.
+	_, err = dsp.OnNextSubtasksBatch(context.Background(), nil, gTask, gTask.Step)
+	require.ErrorContains(t, err, "mock step transition error")
+	require.True(t, dsp.IsRetryableErr(err))
+	require.Zero(t, hook.splitRanges)
+
+	metas, err := dsp.OnNextSubtasksBatch(context.Background(), nil, gTask, gTask.Step)
+	require.NoError(t, err)
+	require.Len(t, metas, 1)
+	require.Equal(t, 1, hook.splitRanges)
+	require.Equal(t, 1, hook.metaCnt)
+}
+
 func TestGetNextStep(t *testing.T) {
 	task := &proto.Task{
 		Step: proto.StepInit,
@@ -304,10 +412,11 @@ func createAddIndexGlobalTask(t *testing.T,
 			SchemaID: db.ID,
 			TableID:  tblInfo.ID,
 			ReorgMeta: &model.DDLReorgMeta{
-				SQLMode:     defaultSQLMode,
-				Location:    &model.TimeZoneLocation{Name: time.UTC.String(), Offset: 0},
-				ReorgTp:     model.ReorgTypeLitMerge,
-				IsDistReorg: true,
+				SQLMode:           defaultSQLMode,
+				Location:          &model.TimeZoneLocation{Name: time.UTC.String(), Offset: 0},
+				ReorgTp:           model.ReorgTypeLitMerge,
+				IsDistReorg:       true,
+				ResourceGroupName: "default",
 			},
 		},
 		EleIDs:     []int64{10},
@@ -332,3 +441,51 @@ func createAddIndexGlobalTask(t *testing.T,
 
 	return gTask
 }
+
+func TestGetRegionBatch(t *testing.T) {
+	// The batch is computed from the region count and the node count.
+	require.Equal(t, 1, ddl.GetRegionBatch4Test(0, 3))
+	require.Equal(t, 1, ddl.GetRegionBatch4Test(10, 3))
+	require.Equal(t, 2, ddl.GetRegionBatch4Test(13, 3))
+	require.Equal(t, 25, ddl.GetRegionBatch4Test(100, 0))
+	require.Equal(t, 834, ddl.GetRegionBatch4Test(10000, 3))
+	require.Equal(t, 1000, ddl.GetRegionBatch4Test(1000000, 3))
+
+	// The batch is specified by the sysvar.
+	variable.DDLBackfillRegionBatch.Store(20)
+	defer variable.DDLBackfillRegionBatch.Store(variable.DefTiDBDDLBackfillRegionBatch)
+	require.Equal(t, 20, ddl.GetRegionBatch4Test(10, 3))
+	require.Equal(t, 20, ddl.GetRegionBatch4Test(1000000, 3))
+}
+
+func TestGetRangeSplitterSizeKeys(t *testing.T) {
+	const (
+		gib        = int64(1 << 30)
+		regionSize = int64(96 << 20)
+		regionKeys = int64(960000)
+	)
+	// The size is divided evenly by the nodes and a range is at most as large as a region.
+	groupSize, groupKeys, rangeSize, rangeKeys := ddl.GetRangeSplitterSizeKeys4Test(30*gib, 3, regionSize, regionKeys)
+	require.Equal(t, 10*gib, groupSize)
+	require.Equal(t, int64(math.MaxInt64), groupKeys)
+	require.Equal(t, regionSize, rangeSize)
+	require.Equal(t, regionKeys, rangeKeys)
+
+	// The limits are specified by the sysvars.
+	variable.DDLGlobalSortRangeGroupSize.Store(gib)
+	defer variable.DDLGlobalSortRangeGroupSize.Store(variable.DefTiDBDDLGlobalSortRangeGroupSize)
+	variable.DDLGlobalSortMaxKeysPerRange.Store(10000)
+	defer variable.DDLGlobalSortMaxKeysPerRange.Store(variable.DefTiDBDDLGlobalSortMaxKeysPerRange)
+	groupSize, groupKeys, rangeSize, rangeKeys = ddl.GetRangeSplitterSizeKeys4Test(30*gib, 3, regionSize, regionKeys)
+	require.Equal(t, gib, groupSize)
+	require.Equal(t, int64(math.MaxInt64), groupKeys)
+	require.Equal(t, regionSize, rangeSize)
+	require.Equal(t, int64(10000), rangeKeys)
+
+	// The number of ranges of a subtask is limited by the size and keys of the range group.
+	variable.DDLGlobalSortMaxRangesPerSubtask.Store(4)
+	defer variable.DDLGlobalSortMaxRangesPerSubtask.Store(variable.DefTiDBDDLGlobalSortMaxRangesPerSubtask)
+	groupSize, groupKeys, _, _ = ddl.GetRangeSplitterSizeKeys4Test(30*gib, 3, regionSize, regionKeys)
+	require.Equal(t, 4*regionSize, groupSize)
+	require.Equal(t, int64(40000), groupKeys)
+}
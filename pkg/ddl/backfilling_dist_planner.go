@@ -0,0 +1,192 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"encoding/json"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/disttask/framework/dispatcher"
+	"github.com/pingcap/tidb/pkg/disttask/framework/planner"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"go.uber.org/zap"
+)
+
+var (
+	_ planner.LogicalPlan  = &BackfillingLogicalPlan{}
+	_ planner.PipelineSpec = &ReadIndexSpec{}
+	_ planner.PipelineSpec = &MergeSortSpec{}
+	_ planner.PipelineSpec = &WriteIngestSpec{}
+)
+
+// BackfillingLogicalPlan represents a logical plan for backfilling the indexes.
+type BackfillingLogicalPlan struct {
+	Meta BackfillGlobalMeta
+
+	dsp        *BackfillingDispatcherExt
+	taskHandle dispatcher.TaskHandle
+	task       *proto.Task
+	tblInfo    *model.TableInfo
+	logger     *zap.Logger
+}
+
+// ToTaskMeta converts the logical plan to task meta.
+func (p *BackfillingLogicalPlan) ToTaskMeta() ([]byte, error) {
+	return json.Marshal(&p.Meta)
+}
+
+// FromTaskMeta converts the task meta to logical plan.
+func (p *BackfillingLogicalPlan) FromTaskMeta(bs []byte) error {
+	return errors.Trace(json.Unmarshal(bs, &p.Meta))
+}
+
+// ToPhysicalPlan converts the logical plan to physical plan.
+// Like import into, only the plan of the next step is generated.
+func (p *BackfillingLogicalPlan) ToPhysicalPlan(planCtx planner.PlanCtx) (*planner.PhysicalPlan, error) {
+	physicalPlan := &planner.PhysicalPlan{}
+	var resourceGroupName string
+	if p.Meta.Job.ReorgMeta != nil {
+		resourceGroupName = p.Meta.Job.ReorgMeta.ResourceGroupName
+	}
+	addSpecs := func(specs []planner.PipelineSpec) {
+		for i, spec := range specs {
+			if m := getSubtaskMetaOfSpec(spec); m != nil {
+				m.ResourceGroupName = resourceGroupName
+			}
+			physicalPlan.AddProcessor(planner.ProcessorSpec{
+				ID:       i,
+				Pipeline: spec,
+				Step:     planCtx.NextTaskStep,
+			})
+		}
+	}
+	switch planCtx.NextTaskStep {
+	case StepReadIndex:
+		specs, err := p.generateReadIndexSpecs(planCtx)
+		if err != nil {
+			return nil, err
+		}
+		addSpecs(specs)
+	case StepMergeSort:
+		serverNodes, err := p.dsp.GetEligibleInstances(planCtx.Ctx, p.task)
+		if err != nil {
+			return nil, err
+		}
+		specs, err := generateMergePlan(p.taskHandle, p.task, p.tblInfo, p.Meta.EleIDs,
+			len(serverNodes), p.Meta.CompressData, p.logger)
+		if err != nil {
+			return nil, err
+		}
+		if len(specs) > 0 {
+			p.Meta.UseMergeSort = true
+		}
+		addSpecs(specs)
+	case StepWriteAndIngest:
+		specs, err := p.generateWriteIngestSpecs(planCtx)
+		if err != nil {
+			return nil, err
+		}
+		addSpecs(specs)
+	}
+	return physicalPlan, nil
+}
+
+func (p *BackfillingLogicalPlan) generateReadIndexSpecs(planCtx planner.PlanCtx) ([]planner.PipelineSpec, error) {
+	if p.tblInfo.Partition != nil {
+		return generatePartitionPlan(p.tblInfo)
+	}
+	serverNodes, err := p.dsp.GetEligibleInstances(planCtx.Ctx, p.task)
+	if err != nil {
+		return nil, err
+	}
+	return generateNonPartitionPlan(p.dsp.d, p.tblInfo, &p.Meta.Job, len(serverNodes))
+}
+
+func (p *BackfillingLogicalPlan) generateWriteIngestSpecs(planCtx planner.PlanCtx) ([]planner.PipelineSpec, error) {
+	if planCtx.GlobalSort {
+		prevStep := StepReadIndex
+		if p.Meta.UseMergeSort {
+			prevStep = StepMergeSort
+		}
+
+		failpoint.Inject("mockWriteIngest", func() {
+			failpoint.Return([]planner.PipelineSpec{&WriteIngestSpec{BackfillSubTaskMeta: &BackfillSubTaskMeta{}}}, nil)
+		})
+		return generateGlobalSortIngestPlan(
+			planCtx.Ctx,
+			p.taskHandle,
+			p.task,
+			p.Meta.Job.ID,
+			p.Meta.CloudStorageURI,
+			prevStep,
+			p.tblInfo,
+			p.Meta.EleIDs,
+			p.logger)
+	}
+	// In local mode, the index records of a node are kept in its local engines until the write&ingest step.
+	// For partition table, the subtask of a node ingests the partitions it read, so the partitions are
+	// ingested concurrently across the nodes.
+	specs, err := generateIngestTaskPlan(planCtx.Ctx, p.dsp, p.taskHandle, p.task)
+	if err != nil {
+		return nil, err
+	}
+	p.Meta.PreviousSchedulerIDs = p.dsp.previousSchedulerIDs
+	return specs, nil
+}
+
+// getSubtaskMetaOfSpec returns the subtask meta of the backfilling pipeline spec.
+func getSubtaskMetaOfSpec(spec planner.PipelineSpec) *BackfillSubTaskMeta {
+	switch s := spec.(type) {
+	case *ReadIndexSpec:
+		return s.BackfillSubTaskMeta
+	case *MergeSortSpec:
+		return s.BackfillSubTaskMeta
+	case *WriteIngestSpec:
+		return s.BackfillSubTaskMeta
+	}
+	return nil
+}
+
+// ReadIndexSpec is the specification of a read-index pipeline.
+type ReadIndexSpec struct {
+	*BackfillSubTaskMeta
+}
+
+// ToSubtaskMeta converts the read-index spec to subtask meta.
+func (s *ReadIndexSpec) ToSubtaskMeta(planner.PlanCtx) ([]byte, error) {
+	return json.Marshal(s.BackfillSubTaskMeta)
+}
+
+// MergeSortSpec is the specification of a merge-sort pipeline.
+type MergeSortSpec struct {
+	*BackfillSubTaskMeta
+}
+
+// ToSubtaskMeta converts the merge-sort spec to subtask meta.
+func (s *MergeSortSpec) ToSubtaskMeta(planner.PlanCtx) ([]byte, error) {
+	return json.Marshal(s.BackfillSubTaskMeta)
+}
+
+// WriteIngestSpec is the specification of a write-ingest pipeline.
+type WriteIngestSpec struct {
+	*BackfillSubTaskMeta
+}
+
+// ToSubtaskMeta converts the write-ingest spec to subtask meta.
+func (s *WriteIngestSpec) ToSubtaskMeta(planner.PlanCtx) ([]byte, error) {
+	return json.Marshal(s.BackfillSubTaskMeta)
+}
@@ -34,15 +34,42 @@ import (
 type BackfillGlobalMeta struct {
 	Job model.Job `json:"job"`
 	// EleIDs stands for the index/column IDs to backfill with distributed framework.
+	// All the indexes are built from a single scan over the table.
 	EleIDs []int64 `json:"ele_ids"`
 	// EleTypeKey is the type of the element to backfill with distributed framework.
 	// For now, only index type is supported.
 	EleTypeKey []byte `json:"ele_type_key"`
 
 	CloudStorageURI string `json:"cloud_storage_uri"`
+	// FallbackCloudStorageURI is the cloud storage URI of the task falling back to local sort after
+	// it's unavailable, the files written to it before are cleaned up when the task finishes.
+	FallbackCloudStorageURI string `json:"fallback_cloud_storage_uri,omitempty"`
 	// UseMergeSort indicate whether the backfilling task use merge sort step for global sort.
 	// Merge Sort step aims to support more data.
 	UseMergeSort bool `json:"use_merge_sort"`
+	// WorkerCnt is the value of tidb_ddl_reorg_worker_cnt the subtasks run with. It's updated by
+	// the dispatcher when the variable changes, and the running subtasks follow it.
+	WorkerCnt int `json:"worker_cnt,omitempty"`
+	// CompressData indicates whether the sorted KV files written by the read index
+	// and merge sort steps are compressed.
+	CompressData bool `json:"compress_data,omitempty"`
+	// Throughput and ETA are the progress of the read index step estimated by the dispatcher
+	// from the row count in the subtask summaries, see model.DDLReorgMeta.
+	Throughput float64 `json:"throughput,omitempty"`
+	ETA        int64   `json:"eta,omitempty"`
+	// MinVersion is the TiDB version of the node submitting the task. The nodes of older versions
+	// may lack the executors of some steps, so they are excluded from running the subtasks during
+	// rolling upgrades. It's empty if the version is unknown.
+	MinVersion string `json:"min_version,omitempty"`
+	// GlobalSortStats is collected by the dispatcher from the subtasks of the global sort, see model.DDLReorgMeta.
+	GlobalSortStats *model.GlobalSortStats `json:"global_sort_stats,omitempty"`
+	// PreviousSchedulerIDs are the nodes running the read index step with local sort, only they have the
+	// subtasks of the write&ingest step since the index records are in their local engines. It's persisted
+	// with the subtasks of the write&ingest step, so a new dispatcher owner continues the task with it.
+	PreviousSchedulerIDs []string `json:"previous_scheduler_ids,omitempty"`
+	// TotalRows is the estimated row count of the table to estimate the ETA, it's kept across the
+	// dispatcher owners to avoid counting it again.
+	TotalRows int64 `json:"total_rows,omitempty"`
 }
 
 // BackfillSubTaskMeta is the sub-task meta for backfilling index.
@@ -53,6 +80,20 @@ type BackfillSubTaskMeta struct {
 
 	RangeSplitKeys        [][]byte `json:"range_split_keys"`
 	external.SortedKVMeta `json:",inline"`
+	// MetaGroups is the sorted KV meta of each index in BackfillGlobalMeta.EleIDs written by
+	// the read index step with global sort.
+	MetaGroups []*external.SortedKVMeta `json:"meta_groups,omitempty"`
+	// EleID is the ID of the index whose sorted KV is handled by the merge sort or the ingest subtask.
+	EleID int64 `json:"ele_id,omitempty"`
+
+	// CheckpointKey is the key before which the index records have been ingested or
+	// written to the external storage, a re-dispatched subtask resumes from it.
+	CheckpointKey      []byte `json:"checkpoint_key,omitempty"`
+	CheckpointRowCount int64  `json:"checkpoint_row_count,omitempty"`
+
+	// ResourceGroupName is the resource group of the DDL submitter, the requests sent to TiKV
+	// by the subtask consume the RU of it.
+	ResourceGroupName string `json:"resource_group_name,omitempty"`
 }
 
 // NewBackfillSubtaskExecutor creates a new backfill subtask executor.
@@ -86,14 +127,14 @@ func NewBackfillSubtaskExecutor(_ context.Context, taskMeta []byte, d *ddl,
 		d.setDDLLabelForTopSQL(jobMeta.ID, jobMeta.Query)
 		d.setDDLSourceForDiagnosis(jobMeta.ID, jobMeta.Type)
 		return newReadIndexExecutor(
-			d, &bgm.Job, indexInfos, tbl.(table.PhysicalTable), jc, bc, summary, bgm.CloudStorageURI), nil
+			d, &bgm.Job, indexInfos, tbl.(table.PhysicalTable), jc, bc, summary, bgm.CloudStorageURI, bgm.CompressData), nil
 	case proto.StepTwo:
-		return newMergeSortExecutor(jobMeta.ID, indexInfos[0], tbl.(table.PhysicalTable), bc, bgm.CloudStorageURI)
+		return newMergeSortExecutor(jobMeta.ID, tbl.(table.PhysicalTable), bc, bgm.CloudStorageURI, bgm.CompressData)
 	case proto.StepThree:
 		if len(bgm.CloudStorageURI) > 0 {
-			return newCloudImportExecutor(&bgm.Job, jobMeta.ID, indexInfos[0], tbl.(table.PhysicalTable), bc, bgm.CloudStorageURI)
+			return newCloudImportExecutor(&bgm.Job, jobMeta.ID, indexInfos, tbl.(table.PhysicalTable), bc, bgm.CloudStorageURI, d.sessPool)
 		}
-		return newImportFromLocalStepExecutor(jobMeta.ID, indexInfos, tbl.(table.PhysicalTable), bc), nil
+		return newImportFromLocalStepExecutor(&bgm.Job, indexInfos, tbl.(table.PhysicalTable), bc, d.sessPool), nil
 	default:
 		return nil, errors.Errorf("unknown step %d for job %d", stage, jobMeta.ID)
 	}
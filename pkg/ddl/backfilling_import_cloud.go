@@ -21,7 +21,9 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend"
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
+	tidbconfig "github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/table"
@@ -32,27 +34,30 @@ import (
 type cloudImportExecutor struct {
 	job           *model.Job
 	jobID         int64
-	index         *model.IndexInfo
+	indexes       []*model.IndexInfo
 	ptbl          table.PhysicalTable
 	bc            ingest.BackendCtx
 	cloudStoreURI string
+	sessPool      *sess.Pool
 }
 
 func newCloudImportExecutor(
 	job *model.Job,
 	jobID int64,
-	index *model.IndexInfo,
+	indexes []*model.IndexInfo,
 	ptbl table.PhysicalTable,
 	bc ingest.BackendCtx,
 	cloudStoreURI string,
+	sessPool *sess.Pool,
 ) (*cloudImportExecutor, error) {
 	return &cloudImportExecutor{
 		job:           job,
 		jobID:         jobID,
-		index:         index,
+		indexes:       indexes,
 		ptbl:          ptbl,
 		bc:            bc,
 		cloudStoreURI: cloudStoreURI,
+		sessPool:      sessPool,
 	}, nil
 }
 
@@ -77,7 +82,16 @@ func (m *cloudImportExecutor) RunSubtask(ctx context.Context, subtask *proto.Sub
 	if local == nil {
 		return errors.Errorf("local backend not found")
 	}
-	_, engineUUID := backend.MakeUUID(m.ptbl.Meta().Name.L, m.index.ID)
+	// Each index is imported by its own engine.
+	indexID := m.indexes[0].ID
+	if sm.EleID != 0 {
+		indexID = sm.EleID
+	}
+	_, engineUUID := backend.MakeUUID(m.ptbl.Meta().Name.L, indexID)
+	masterKey, err := tidbconfig.GetGlobalConfig().Security.LoadMasterKey()
+	if err != nil {
+		return err
+	}
 	err = local.CloseEngine(ctx, &backend.EngineConfig{
 		External: &backend.ExternalEngineConfig{
 			StorageURI:    m.cloudStoreURI,
@@ -88,13 +102,17 @@ func (m *cloudImportExecutor) RunSubtask(ctx context.Context, subtask *proto.Sub
 			SplitKeys:     sm.RangeSplitKeys,
 			TotalFileSize: int64(sm.TotalKVSize),
 			TotalKVCount:  0,
+			EncryptionKey: masterKey,
 		},
 	}, engineUUID)
 	if err != nil {
 		return err
 	}
 	err = local.ImportEngine(ctx, engineUUID, int64(config.SplitRegionSize), int64(config.SplitRegionKeys))
-	return err
+	if err != nil {
+		return handleIngestDupKeyErr(ctx, m.sessPool, m.job, err, m.indexes, m.ptbl.Meta())
+	}
+	return nil
 }
 
 func (*cloudImportExecutor) Cleanup(ctx context.Context) error {
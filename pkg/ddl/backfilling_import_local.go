@@ -19,6 +19,7 @@ import (
 
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/table"
@@ -26,23 +27,26 @@ import (
 )
 
 type localImportExecutor struct {
-	jobID   int64
-	indexes []*model.IndexInfo
-	ptbl    table.PhysicalTable
-	bc      ingest.BackendCtx
+	job      *model.Job
+	indexes  []*model.IndexInfo
+	ptbl     table.PhysicalTable
+	bc       ingest.BackendCtx
+	sessPool *sess.Pool
 }
 
 func newImportFromLocalStepExecutor(
-	jobID int64,
+	job *model.Job,
 	indexes []*model.IndexInfo,
 	ptbl table.PhysicalTable,
 	bc ingest.BackendCtx,
+	sessPool *sess.Pool,
 ) *localImportExecutor {
 	return &localImportExecutor{
-		jobID:   jobID,
-		indexes: indexes,
-		ptbl:    ptbl,
-		bc:      bc,
+		job:      job,
+		indexes:  indexes,
+		ptbl:     ptbl,
+		bc:       bc,
+		sessPool: sessPool,
 	}
 }
 
@@ -52,8 +56,7 @@ func (i *localImportExecutor) Init(ctx context.Context) error {
 		_, _, err := i.bc.Flush(index.ID, ingest.FlushModeForceGlobal)
 		if err != nil {
 			if common.ErrFoundDuplicateKeys.Equal(err) {
-				err = convertToKeyExistsErr(err, index, i.ptbl.Meta())
-				return err
+				return handleIngestDupKeyErr(ctx, i.sessPool, i.job, err, []*model.IndexInfo{index}, i.ptbl.Meta())
 			}
 		}
 	}
@@ -24,40 +24,46 @@ import (
 	"github.com/google/uuid"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
-	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
-	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/table"
-	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/size"
 	"go.uber.org/zap"
 )
 
+const (
+	// mergeSortReadBufferSize is the read buffer size of each data file to merge.
+	mergeSortReadBufferSize = 64 * 1024
+	// mergeSortWriterMemLimit is the memory size limit of the writer of the merged files.
+	mergeSortWriterMemLimit = 256 * size.MB
+	// mergeSortPropSizeDist is the size distance of the range properties of the merged files.
+	mergeSortPropSizeDist = 1 * size.MB
+)
+
 type mergeSortExecutor struct {
 	jobID               int64
-	index               *model.IndexInfo
 	ptbl                table.PhysicalTable
 	bc                  ingest.BackendCtx
 	cloudStoreURI       string
+	compressData        bool
 	mu                  sync.Mutex
 	subtaskSortedKVMeta *external.SortedKVMeta
 }
 
 func newMergeSortExecutor(
 	jobID int64,
-	index *model.IndexInfo,
 	ptbl table.PhysicalTable,
 	bc ingest.BackendCtx,
 	cloudStoreURI string,
+	compressData bool,
 ) (*mergeSortExecutor, error) {
 	return &mergeSortExecutor{
 		jobID:         jobID,
-		index:         index,
 		ptbl:          ptbl,
 		bc:            bc,
 		cloudStoreURI: cloudStoreURI,
+		compressData:  compressData,
 	}, nil
 }
 
@@ -85,15 +91,7 @@ func (m *mergeSortExecutor) RunSubtask(ctx context.Context, subtask *proto.Subta
 		m.mu.Unlock()
 	}
 
-	storeBackend, err := storage.ParseBackend(m.cloudStoreURI, nil)
-	if err != nil {
-		return err
-	}
-	opt := &storage.ExternalStorageOptions{}
-	if intest.InTest {
-		opt.NoCredentials = true
-	}
-	store, err := storage.New(ctx, storeBackend, opt)
+	store, err := newGlobalSortStore(ctx, m.cloudStoreURI)
 	if err != nil {
 		return err
 	}
@@ -106,15 +104,16 @@ func (m *mergeSortExecutor) RunSubtask(ctx context.Context, subtask *proto.Subta
 		ctx,
 		sm.DataFiles,
 		store,
-		64*1024,
+		mergeSortReadBufferSize,
 		prefix,
 		writerID,
-		256*size.MB,
+		mergeSortWriterMemLimit,
 		external.DefaultBlockSize,
 		8*1024,
-		1*size.MB,
+		mergeSortPropSizeDist,
 		8*1024,
-		onClose)
+		onClose,
+		m.compressData)
 }
 
 func (*mergeSortExecutor) Cleanup(ctx context.Context) error {
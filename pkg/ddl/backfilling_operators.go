@@ -30,6 +30,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
 	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/br/pkg/storage"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/copr"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
 	"github.com/pingcap/tidb/pkg/ddl/internal/session"
@@ -124,6 +125,8 @@ func NewAddIndexIngestPipeline(
 	startKey, endKey kv.Key,
 	totalRowCount *atomic.Int64,
 	metricCounter prometheus.Counter,
+	checkpoint *readIndexCheckpoint,
+	throttler *backfillThrottler,
 ) (*operator.AsyncPipeline, error) {
 	indexes := make([]table.Index, 0, len(idxInfos))
 	for _, idxInfo := range idxInfos {
@@ -146,6 +149,24 @@ func NewAddIndexIngestPipeline(
 	scanOp := NewTableScanOperator(ctx, sessPool, copCtx, srcChkPool, readerCnt)
 	ingestOp := NewIndexIngestOperator(ctx, copCtx, sessPool, tbl, indexes, engines, srcChkPool, writerCnt)
 	sinkOp := newIndexWriteResultSink(ctx, backendCtx, tbl, indexes, totalRowCount, metricCounter)
+	if checkpoint != nil {
+		// The local engines are lost if the node crashes, so import them before recording the checkpoint.
+		checkpoint.flush = func() error {
+			return flushIngestEngines(backendCtx, tbl, indexes, ingest.FlushModeForceGlobal)
+		}
+		srcOp.checkpoint, scanOp.checkpoint, sinkOp.checkpoint = checkpoint, checkpoint, checkpoint
+	}
+	if throttler != nil {
+		throttler.addOperator(scanOp, func(workerCnt int) int {
+			readerCnt, _ := ingestWorkerCnt(workerCnt)
+			return readerCnt
+		})
+		throttler.addOperator(ingestOp, func(workerCnt int) int {
+			_, writerCnt := ingestWorkerCnt(workerCnt)
+			return writerCnt
+		})
+		srcOp.throttler = throttler
+	}
 
 	operator.Compose[TableScanTask](srcOp, scanOp)
 	operator.Compose[IndexRecordChunk](scanOp, ingestOp)
@@ -156,11 +177,79 @@ func NewAddIndexIngestPipeline(
 	), nil
 }
 
+// newGlobalSortStore creates the external storage to write and read the sorted
+// KV files of global sort. The files are encrypted by the master key if
+// [security]master-key-path is set.
+func newGlobalSortStore(ctx context.Context, uri string) (storage.ExternalStorage, error) {
+	masterKey, err := config.GetGlobalConfig().Security.LoadMasterKey()
+	if err != nil {
+		return nil, err
+	}
+	backend, err := storage.ParseBackend(uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	store, err := storage.NewWithDefaultOpt(ctx, backend)
+	if err != nil {
+		return nil, err
+	}
+	return storage.WithEncryption(store, masterKey), nil
+}
+
+// errGlobalSortStorage means the sorted KV files of global sort can't be written to the cloud storage,
+// the dispatcher falls back to local sort on it, see BackfillingDispatcherExt.ReplanOnErr.
+var errGlobalSortStorage = errors.Normalize("write to the cloud storage of global sort failed: %s",
+	errors.RFCCodeText("DDL:GlobalSort:ErrStorage"))
+
+// wrapGlobalSortStorageErr converts the error of the cloud storage to errGlobalSortStorage. Only the
+// message of the original error is kept, since the subtask error is persisted by the message too.
+func wrapGlobalSortStorageErr(err error) error {
+	if err == nil || errors.Cause(err) == context.Canceled {
+		return err
+	}
+	return errGlobalSortStorage.FastGenByArgs(err.Error())
+}
+
+// globalSortWriteStore wraps the errors of writing the sorted KV files into errGlobalSortStorage.
+type globalSortWriteStore struct {
+	storage.ExternalStorage
+}
+
+// WriteFile implements storage.ExternalStorage.
+func (s globalSortWriteStore) WriteFile(ctx context.Context, name string, data []byte) error {
+	return wrapGlobalSortStorageErr(s.ExternalStorage.WriteFile(ctx, name, data))
+}
+
+// Create implements storage.ExternalStorage.
+func (s globalSortWriteStore) Create(ctx context.Context, path string, option *storage.WriterOption) (storage.ExternalFileWriter, error) {
+	w, err := s.ExternalStorage.Create(ctx, path, option)
+	if err != nil {
+		return nil, wrapGlobalSortStorageErr(err)
+	}
+	return globalSortFileWriter{ExternalFileWriter: w}, nil
+}
+
+type globalSortFileWriter struct {
+	storage.ExternalFileWriter
+}
+
+// Write implements storage.ExternalFileWriter.
+func (w globalSortFileWriter) Write(ctx context.Context, p []byte) (int, error) {
+	n, err := w.ExternalFileWriter.Write(ctx, p)
+	return n, wrapGlobalSortStorageErr(err)
+}
+
+// Close implements storage.ExternalFileWriter.
+func (w globalSortFileWriter) Close(ctx context.Context) error {
+	return wrapGlobalSortStorageErr(w.ExternalFileWriter.Close(ctx))
+}
+
 // NewWriteIndexToExternalStoragePipeline creates a pipeline for writing index to external storage.
 func NewWriteIndexToExternalStoragePipeline(
 	ctx *OperatorCtx,
 	store kv.Storage,
 	extStoreURI string,
+	compressData bool,
 	sessPool opSessPool,
 	sessCtx sessionctx.Context,
 	jobID, subtaskID int64,
@@ -169,8 +258,10 @@ func NewWriteIndexToExternalStoragePipeline(
 	startKey, endKey kv.Key,
 	totalRowCount *atomic.Int64,
 	metricCounter prometheus.Counter,
-	onClose external.OnCloseFunc,
+	onClose IndexOnCloseFunc,
 	bcctx ingest.BackendCtx,
+	checkpoint *readIndexCheckpoint,
+	throttler *backfillThrottler,
 ) (*operator.AsyncPipeline, error) {
 	indexes := make([]table.Index, 0, len(idxInfos))
 	for _, idxInfo := range idxInfos {
@@ -190,14 +281,14 @@ func NewWriteIndexToExternalStoragePipeline(
 	readerCnt := int(variable.GetDDLReorgWorkerCounter())
 	writerCnt := 1
 
-	backend, err := storage.ParseBackend(extStoreURI, nil)
-	if err != nil {
-		return nil, err
-	}
-	extStore, err := storage.NewWithDefaultOpt(ctx, backend)
+	extStore, err := newGlobalSortStore(ctx, extStoreURI)
 	if err != nil {
-		return nil, err
+		return nil, wrapGlobalSortStorageErr(err)
 	}
+	failpoint.Inject("mockGlobalSortStorageErr", func() {
+		failpoint.Return(nil, wrapGlobalSortStorageErr(errors.New("mock cloud storage unavailable")))
+	})
+	extStore = globalSortWriteStore{ExternalStorage: extStore}
 
 	var shareMu *sync.Mutex
 	if bcctx != nil {
@@ -213,8 +304,22 @@ func NewWriteIndexToExternalStoragePipeline(
 	srcOp := NewTableScanTaskSource(ctx, store, tbl, startKey, endKey)
 	scanOp := NewTableScanOperator(ctx, sessPool, copCtx, srcChkPool, readerCnt)
 	writeOp := NewWriteExternalStoreOperator(
-		ctx, copCtx, sessPool, jobID, subtaskID, tbl, indexes, extStore, srcChkPool, writerCnt, onClose, shareMu, memSize)
+		ctx, copCtx, sessPool, jobID, subtaskID, tbl, indexes, extStore, compressData, srcChkPool, writerCnt, onClose, shareMu, memSize)
 	sinkOp := newIndexWriteResultSink(ctx, nil, tbl, indexes, totalRowCount, metricCounter)
+	if checkpoint != nil {
+		// The buffered index records are flushed to the external storage when the writers are closed.
+		checkpoint.flush = func() error {
+			return writeOp.rotateWriters(ctx)
+		}
+		srcOp.checkpoint, scanOp.checkpoint, sinkOp.checkpoint = checkpoint, checkpoint, checkpoint
+	}
+	if throttler != nil {
+		// The records are written to the external storage, only reading puts pressure on TiKV.
+		throttler.addOperator(scanOp, func(workerCnt int) int {
+			return workerCnt
+		})
+		srcOp.throttler = throttler
+	}
 
 	operator.Compose[TableScanTask](srcOp, scanOp)
 	operator.Compose[IndexRecordChunk](scanOp, writeOp)
@@ -257,6 +362,9 @@ type TableScanTaskSource struct {
 	store    kv.Storage
 	startKey kv.Key
 	endKey   kv.Key
+
+	checkpoint *readIndexCheckpoint
+	throttler  *backfillThrottler
 }
 
 // NewTableScanTaskSource creates a new TableScanTaskSource.
@@ -291,6 +399,10 @@ func (src *TableScanTaskSource) Open() error {
 func (src *TableScanTaskSource) generateTasks() error {
 	taskIDAlloc := newTaskIDAllocator()
 	defer src.sink.Finish()
+	if src.throttler != nil {
+		src.throttler.start(src.ctx)
+		defer src.throttler.close()
+	}
 	startKey := src.startKey
 	endKey := src.endKey
 	for {
@@ -310,6 +422,15 @@ func (src *TableScanTaskSource) generateTasks() error {
 
 		batchTasks := src.getBatchTableScanTask(kvRanges, taskIDAlloc)
 		for _, task := range batchTasks {
+			if src.checkpoint != nil {
+				if err := src.checkpoint.maybeCheckpoint(src.ctx); err != nil {
+					return err
+				}
+				src.checkpoint.register(task)
+			}
+			if src.throttler != nil {
+				src.throttler.apply()
+			}
 			select {
 			case <-src.ctx.Done():
 				return src.ctx.Err()
@@ -365,6 +486,8 @@ func (*TableScanTaskSource) String() string {
 // TableScanOperator scans table records in given key ranges from kv store.
 type TableScanOperator struct {
 	*operator.AsyncOperator[TableScanTask, IndexRecordChunk]
+
+	checkpoint *readIndexCheckpoint
 }
 
 // NewTableScanOperator creates a new TableScanOperator.
@@ -375,6 +498,7 @@ func NewTableScanOperator(
 	srcChkPool chan *chunk.Chunk,
 	concurrency int,
 ) *TableScanOperator {
+	op := &TableScanOperator{}
 	pool := workerpool.NewWorkerPool(
 		"TableScanOperator",
 		util.DDL,
@@ -386,11 +510,11 @@ func NewTableScanOperator(
 				sessPool:   sessPool,
 				se:         nil,
 				srcChkPool: srcChkPool,
+				op:         op,
 			}
 		})
-	return &TableScanOperator{
-		AsyncOperator: operator.NewAsyncOperator[TableScanTask, IndexRecordChunk](ctx, pool),
-	}
+	op.AsyncOperator = operator.NewAsyncOperator[TableScanTask, IndexRecordChunk](ctx, pool)
+	return op
 }
 
 type tableScanWorker struct {
@@ -399,6 +523,7 @@ type tableScanWorker struct {
 	sessPool   opSessPool
 	se         *session.Session
 	srcChkPool chan *chunk.Chunk
+	op         *TableScanOperator
 }
 
 func (w *tableScanWorker) HandleTask(task TableScanTask, sender func(IndexRecordChunk)) {
@@ -449,6 +574,9 @@ func (w *tableScanWorker) scanRecords(task TableScanTask, sender func(IndexRecor
 				return err
 			}
 			idxResult = IndexRecordChunk{ID: task.ID, Chunk: srcChk, Done: done}
+			if w.op.checkpoint != nil {
+				w.op.checkpoint.onChunkScanned(task.ID, done)
+			}
 			sender(idxResult)
 		}
 		return rs.Close()
@@ -472,9 +600,16 @@ func (w *tableScanWorker) recycleChunk(chk *chunk.Chunk) {
 	w.srcChkPool <- chk
 }
 
+// IndexOnCloseFunc is the callback function when the external writer of an index is closed.
+type IndexOnCloseFunc func(indexID int64, summary *external.WriterSummary)
+
 // WriteExternalStoreOperator writes index records to external storage.
 type WriteExternalStoreOperator struct {
 	*operator.AsyncOperator[IndexRecordChunk, IndexWriteResult]
+
+	newWriters func() []ingest.Writer
+	mu         sync.Mutex
+	workers    []*indexIngestWorker
 }
 
 // NewWriteExternalStoreOperator creates a new WriteExternalStoreOperator.
@@ -487,44 +622,71 @@ func NewWriteExternalStoreOperator(
 	tbl table.PhysicalTable,
 	indexes []table.Index,
 	store storage.ExternalStorage,
+	compressData bool,
 	srcChunkPool chan *chunk.Chunk,
 	concurrency int,
-	onClose external.OnCloseFunc,
+	onClose IndexOnCloseFunc,
 	shareMu *sync.Mutex,
 	memoryQuota uint64,
 ) *WriteExternalStoreOperator {
+	op := &WriteExternalStoreOperator{}
+	op.newWriters = func() []ingest.Writer {
+		writers := make([]ingest.Writer, 0, len(indexes))
+		for _, index := range indexes {
+			indexID := index.Meta().ID
+			builder := external.NewWriterBuilder().
+				SetOnCloseFunc(func(summary *external.WriterSummary) {
+					onClose(indexID, summary)
+				}).
+				SetKeyDuplicationEncoding(index.Meta().Unique).
+				SetMutex(shareMu).
+				SetMemorySizeLimit(memoryQuota).
+				SetDataCompression(compressData)
+			writerID := uuid.New().String()
+			prefix := path.Join(strconv.Itoa(int(jobID)), strconv.Itoa(int(subtaskID)))
+			writer := builder.Build(store, prefix, writerID)
+			writers = append(writers, writer)
+		}
+		return writers
+	}
 	pool := workerpool.NewWorkerPool(
 		"WriteExternalStoreOperator",
 		util.DDL,
 		concurrency,
 		func() workerpool.Worker[IndexRecordChunk, IndexWriteResult] {
-			writers := make([]ingest.Writer, 0, len(indexes))
-			for _, index := range indexes {
-				builder := external.NewWriterBuilder().
-					SetOnCloseFunc(onClose).
-					SetKeyDuplicationEncoding(index.Meta().Unique).
-					SetMutex(shareMu).
-					SetMemorySizeLimit(memoryQuota)
-				writerID := uuid.New().String()
-				prefix := path.Join(strconv.Itoa(int(jobID)), strconv.Itoa(int(subtaskID)))
-				writer := builder.Build(store, prefix, writerID)
-				writers = append(writers, writer)
-			}
-
-			return &indexIngestWorker{
+			w := &indexIngestWorker{
 				ctx:          ctx,
 				tbl:          tbl,
 				indexes:      indexes,
 				copCtx:       copCtx,
 				se:           nil,
 				sessPool:     sessPool,
-				writers:      writers,
+				writers:      op.newWriters(),
 				srcChunkPool: srcChunkPool,
 			}
+			op.mu.Lock()
+			op.workers = append(op.workers, w)
+			op.mu.Unlock()
+			return w
 		})
-	return &WriteExternalStoreOperator{
-		AsyncOperator: operator.NewAsyncOperator[IndexRecordChunk, IndexWriteResult](ctx, pool),
+	op.AsyncOperator = operator.NewAsyncOperator[IndexRecordChunk, IndexWriteResult](ctx, pool)
+	return op
+}
+
+// rotateWriters closes the writers of all the workers to flush the buffered index records to the external
+// storage, and replaces them with new ones. It must be called when no chunk is being written.
+func (o *WriteExternalStoreOperator) rotateWriters(ctx context.Context) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	for _, w := range o.workers {
+		for _, writer := range w.writers {
+			if err := writer.Close(ctx); err != nil {
+				return err
+			}
+		}
+		w.writers = o.newWriters()
 	}
+	return nil
 }
 
 // IndexWriteResult contains the result of writing index records to ingest engine.
@@ -673,6 +835,7 @@ type indexWriteResultSink struct {
 
 	rowCount      *atomic.Int64
 	metricCounter prometheus.Counter
+	checkpoint    *readIndexCheckpoint
 
 	errGroup errgroup.Group
 	source   operator.DataChannel[IndexWriteResult]
@@ -723,6 +886,9 @@ func (s *indexWriteResultSink) collectResult() error {
 			if s.metricCounter != nil {
 				s.metricCounter.Add(float64(result.Added))
 			}
+			if s.checkpoint != nil {
+				s.checkpoint.onChunkWritten(result.ID)
+			}
 		}
 	}
 }
@@ -734,16 +900,17 @@ func (s *indexWriteResultSink) flush() error {
 	failpoint.Inject("mockFlushError", func(_ failpoint.Value) {
 		failpoint.Return(errors.New("mock flush error"))
 	})
-	flushMode := ingest.FlushModeForceLocalAndCheckDiskQuota
-	if s.tbl.GetPartitionedTable() != nil {
-		flushMode = ingest.FlushModeForceGlobal
-	}
-	for _, index := range s.indexes {
+	// The index records of all the partitions read by the node are imported by the write&ingest step together.
+	return flushIngestEngines(s.backendCtx, s.tbl, s.indexes, ingest.FlushModeForceLocalAndCheckDiskQuota)
+}
+
+func flushIngestEngines(bc ingest.BackendCtx, tbl table.PhysicalTable, indexes []table.Index, mode ingest.FlushMode) error {
+	for _, index := range indexes {
 		idxInfo := index.Meta()
-		_, _, err := s.backendCtx.Flush(idxInfo.ID, flushMode)
+		_, _, err := bc.Flush(idxInfo.ID, mode)
 		if err != nil {
 			if common.ErrFoundDuplicateKeys.Equal(err) {
-				err = convertToKeyExistsErr(err, idxInfo, s.tbl.Meta())
+				err = convertToKeyExistsErr(err, idxInfo, tbl.Meta())
 				return err
 			}
 			logutil.BgLogger().Error("flush error",
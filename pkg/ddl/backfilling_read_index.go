@@ -26,12 +26,15 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler"
 	"github.com/pingcap/tidb/pkg/disttask/framework/scheduler/execute"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/disttask/operator"
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/table"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"go.uber.org/zap"
@@ -45,6 +48,7 @@ type readIndexExecutor struct {
 	jc      *JobContext
 
 	cloudStorageURI string
+	compressData    bool
 
 	bc      ingest.BackendCtx
 	summary *execute.Summary
@@ -53,13 +57,17 @@ type readIndexExecutor struct {
 }
 
 type readIndexSummary struct {
-	minKey    []byte
-	maxKey    []byte
-	totalSize uint64
-	dataFiles []string
-	statFiles []string
-	stats     []external.MultipleFilesStat
-	mu        sync.Mutex
+	// metaGroups is the sorted KV meta of each index, all of them are written in one table scan.
+	metaGroups []*external.SortedKVMeta
+	mu         sync.Mutex
+}
+
+func newReadIndexSummary(indexCnt int) *readIndexSummary {
+	s := &readIndexSummary{metaGroups: make([]*external.SortedKVMeta, indexCnt)}
+	for i := range s.metaGroups {
+		s.metaGroups[i] = &external.SortedKVMeta{}
+	}
+	return s
 }
 
 func newReadIndexExecutor(
@@ -71,6 +79,7 @@ func newReadIndexExecutor(
 	bc ingest.BackendCtx,
 	summary *execute.Summary,
 	cloudStorageURI string,
+	compressData bool,
 ) *readIndexExecutor {
 	return &readIndexExecutor{
 		d:               d,
@@ -81,6 +90,7 @@ func newReadIndexExecutor(
 		bc:              bc,
 		summary:         summary,
 		cloudStorageURI: cloudStorageURI,
+		compressData:    compressData,
 	}
 }
 
@@ -95,8 +105,6 @@ func (r *readIndexExecutor) RunSubtask(ctx context.Context, subtask *proto.Subta
 		zap.String("category", "ddl"),
 		zap.Bool("use cloud", len(r.cloudStorageURI) > 0))
 
-	r.subtaskSummary.Store(subtask.ID, &readIndexSummary{})
-
 	sm := &BackfillSubTaskMeta{}
 	err := json.Unmarshal(subtask.Meta, sm)
 	if err != nil {
@@ -105,27 +113,80 @@ func (r *readIndexExecutor) RunSubtask(ctx context.Context, subtask *proto.Subta
 			zap.Error(err))
 		return err
 	}
+	if len(r.cloudStorageURI) > 0 {
+		// The task may have fallen back to local sort after the executor is created,
+		// the subtasks planned since then are run with local sort.
+		taskMeta, err := getBackfillTaskMeta(subtask.TaskID)
+		if err != nil {
+			return err
+		}
+		if len(taskMeta.CloudStorageURI) == 0 {
+			logutil.BgLogger().Info("read index executor falls back to local sort",
+				zap.String("category", "ddl"), zap.Int64("subtaskID", subtask.ID))
+			r.cloudStorageURI = ""
+		}
+	}
 
 	startKey, endKey, tbl, err := r.getTableStartEndKey(sm)
 	if err != nil {
 		return err
 	}
 
-	sessCtx, err := newSessCtx(
-		r.d.store, r.job.ReorgMeta.SQLMode, r.job.ReorgMeta.Location, r.job.ReorgMeta.ResourceGroupName)
+	summary := newReadIndexSummary(len(r.indexes))
+	totalRowCount := &atomic.Int64{}
+	if len(sm.CheckpointKey) > 0 && kv.Key(sm.CheckpointKey).Cmp(startKey) > 0 {
+		logutil.BgLogger().Info("read index executor resume subtask from checkpoint",
+			zap.String("category", "ddl"),
+			zap.Int64("subtaskID", subtask.ID),
+			zap.String("startKey", hex.EncodeToString(startKey)),
+			zap.String("checkpoint", hex.EncodeToString(sm.CheckpointKey)),
+			zap.Int64("rowCount", sm.CheckpointRowCount))
+		startKey = sm.CheckpointKey
+		totalRowCount.Store(sm.CheckpointRowCount)
+		// The files written before the checkpoint are kept.
+		if len(sm.MetaGroups) == len(r.indexes) {
+			summary.metaGroups = sm.MetaGroups
+		}
+	}
+	r.subtaskSummary.Store(subtask.ID, summary)
+
+	// The table scan requests consume the RU of the resource group of the DDL submitter.
+	resourceGroupName := sm.ResourceGroupName
+	if len(resourceGroupName) == 0 {
+		resourceGroupName = r.job.ReorgMeta.ResourceGroupName
+	}
+	sessCtx, err := newSessCtx(r.d.store, r.job.ReorgMeta.SQLMode, r.job.ReorgMeta.Location, resourceGroupName)
 	if err != nil {
 		return err
 	}
+	setReorgReadOption(sessCtx, r.job)
 
-	opCtx := NewOperatorCtx(ctx)
+	checkpoint := r.newCheckpoint(ctx, subtask.ID, sm, summary, totalRowCount)
+	// When the subtask is paused, the pipeline is not canceled but stops at the next checkpoint,
+	// so the subtask can resume from it.
+	opCtx := NewOperatorCtx(context.WithoutCancel(ctx))
 	defer opCtx.Cancel()
-	totalRowCount := &atomic.Int64{}
+	stop := context.AfterFunc(ctx, func() {
+		if checkpoint != nil && context.Cause(ctx) == scheduler.ErrPauseSubtask {
+			logutil.BgLogger().Info("read index executor park subtask",
+				zap.String("category", "ddl"), zap.Int64("subtaskID", subtask.ID))
+			checkpoint.park()
+			return
+		}
+		opCtx.Cancel()
+	})
+	defer stop()
 
+	// The concurrency is reduced when TiKV is under pressure, and follows the worker count of the task.
+	throttler := newBackfillThrottler(r.d.store, func() (int, error) {
+		return getBackfillTaskWorkerCnt(subtask.TaskID)
+	})
 	var pipe *operator.AsyncPipeline
 	if len(r.cloudStorageURI) > 0 {
-		pipe, err = r.buildExternalStorePipeline(opCtx, subtask.ID, sessCtx, tbl, startKey, endKey, totalRowCount)
+		pipe, err = r.buildExternalStorePipeline(
+			opCtx, subtask.ID, sessCtx, tbl, startKey, endKey, totalRowCount, checkpoint, throttler)
 	} else {
-		pipe, err = r.buildLocalStorePipeline(opCtx, sessCtx, tbl, startKey, endKey, totalRowCount)
+		pipe, err = r.buildLocalStorePipeline(opCtx, sessCtx, tbl, startKey, endKey, totalRowCount, checkpoint, throttler)
 	}
 	if err != nil {
 		return err
@@ -139,6 +200,9 @@ func (r *readIndexExecutor) RunSubtask(ctx context.Context, subtask *proto.Subta
 	if opCtx.OperatorErr() != nil {
 		return opCtx.OperatorErr()
 	}
+	if errors.Cause(err) == errReadIndexParked {
+		return context.Cause(ctx)
+	}
 	if err != nil {
 		return err
 	}
@@ -175,17 +239,15 @@ func (r *readIndexExecutor) OnFinished(ctx context.Context, subtask *proto.Subta
 	}
 	sum, _ := r.subtaskSummary.LoadAndDelete(subtask.ID)
 	s := sum.(*readIndexSummary)
-	subtaskMeta.MinKey = s.minKey
-	subtaskMeta.MaxKey = s.maxKey
-	subtaskMeta.TotalKVSize = s.totalSize
-	subtaskMeta.DataFiles = s.dataFiles
-	subtaskMeta.StatFiles = s.statFiles
-	subtaskMeta.MultipleFilesStats = s.stats
-	logutil.Logger(ctx).Info("get key boundary on subtask finished",
-		zap.String("min", hex.EncodeToString(s.minKey)),
-		zap.String("max", hex.EncodeToString(s.maxKey)),
-		zap.Int("fileCount", len(s.dataFiles)),
-		zap.Uint64("totalSize", s.totalSize))
+	subtaskMeta.MetaGroups = s.metaGroups
+	for i, g := range s.metaGroups {
+		logutil.Logger(ctx).Info("get key boundary on subtask finished",
+			zap.Int64("indexID", r.indexes[i].ID),
+			zap.String("min", hex.EncodeToString(g.MinKey)),
+			zap.String("max", hex.EncodeToString(g.MaxKey)),
+			zap.Int("fileCount", len(g.DataFiles)),
+			zap.Uint64("totalSize", g.TotalKVSize))
+	}
 	meta, err := json.Marshal(subtaskMeta)
 	if err != nil {
 		return err
@@ -200,6 +262,82 @@ func (r *readIndexExecutor) Rollback(ctx context.Context) error {
 	return nil
 }
 
+// newCheckpoint creates the checkpoint of the subtask, which records the checkpoint key together with
+// the files written to the external storage so far into the subtask meta.
+func (r *readIndexExecutor) newCheckpoint(
+	ctx context.Context,
+	subtaskID int64,
+	sm *BackfillSubTaskMeta,
+	summary *readIndexSummary,
+	totalRowCount *atomic.Int64,
+) *readIndexCheckpoint {
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		logutil.Logger(ctx).Warn("cannot get task manager, checkpoint is disabled",
+			zap.String("category", "ddl"), zap.Error(err))
+		return nil
+	}
+	return newReadIndexCheckpoint(func(key kv.Key) error {
+		rowCount := totalRowCount.Load()
+		sm.CheckpointKey = key
+		sm.CheckpointRowCount = rowCount
+		summary.mu.Lock()
+		sm.MetaGroups = summary.metaGroups
+		meta, err := json.Marshal(sm)
+		summary.mu.Unlock()
+		if err != nil {
+			return err
+		}
+		r.summary.UpdateRowCount(subtaskID, rowCount)
+		// The subtask can still go on without the checkpoint.
+		if err := taskMgr.UpdateSubtaskMeta(subtaskID, meta); err != nil {
+			logutil.Logger(ctx).Warn("update subtask checkpoint failed",
+				zap.String("category", "ddl"), zap.Int64("subtaskID", subtaskID), zap.Error(err))
+			return nil
+		}
+		logutil.Logger(ctx).Info("update subtask checkpoint",
+			zap.String("category", "ddl"),
+			zap.Int64("subtaskID", subtaskID),
+			zap.String("checkpoint", hex.EncodeToString(key)),
+			zap.Int64("rowCount", rowCount))
+		return nil
+	})
+}
+
+// getBackfillTaskWorkerCnt gets the worker count of the backfill task, which is updated by the
+// dispatcher when tidb_ddl_reorg_worker_cnt changes.
+func getBackfillTaskWorkerCnt(taskID int64) (int, error) {
+	taskMeta, err := getBackfillTaskMeta(taskID)
+	if err != nil {
+		return 0, err
+	}
+	if taskMeta.WorkerCnt == 0 {
+		// The task is submitted by the old version, keep the worker count of the variable.
+		return int(variable.GetDDLReorgWorkerCounter()), nil
+	}
+	return taskMeta.WorkerCnt, nil
+}
+
+// getBackfillTaskMeta gets the latest meta of the backfill task.
+func getBackfillTaskMeta(taskID int64) (*BackfillGlobalMeta, error) {
+	taskMgr, err := storage.GetTaskManager()
+	if err != nil {
+		return nil, err
+	}
+	task, err := taskMgr.GetGlobalTaskByID(taskID)
+	if err != nil {
+		return nil, err
+	}
+	if task == nil {
+		return nil, errors.Errorf("cannot find task %d", taskID)
+	}
+	var taskMeta BackfillGlobalMeta
+	if err := json.Unmarshal(task.Meta, &taskMeta); err != nil {
+		return nil, errors.Trace(err)
+	}
+	return &taskMeta, nil
+}
+
 func (r *readIndexExecutor) getTableStartEndKey(sm *BackfillSubTaskMeta) (
 	start, end kv.Key, tbl table.PhysicalTable, err error) {
 	currentVer, err1 := getValidCurrentVersion(r.d.store)
@@ -229,6 +367,8 @@ func (r *readIndexExecutor) buildLocalStorePipeline(
 	tbl table.PhysicalTable,
 	start, end kv.Key,
 	totalRowCount *atomic.Int64,
+	checkpoint *readIndexCheckpoint,
+	throttler *backfillThrottler,
 ) (*operator.AsyncPipeline, error) {
 	d := r.d
 	engines := make([]ingest.Engine, 0, len(r.indexes))
@@ -244,7 +384,7 @@ func (r *readIndexExecutor) buildLocalStorePipeline(
 	counter := metrics.BackfillTotalCounter.WithLabelValues(
 		metrics.GenerateReorgLabel("add_idx_rate", r.job.SchemaName, tbl.Meta().Name.O))
 	return NewAddIndexIngestPipeline(
-		opCtx, d.store, d.sessPool, r.bc, engines, sessCtx, tbl, r.indexes, start, end, totalRowCount, counter)
+		opCtx, d.store, d.sessPool, r.bc, engines, sessCtx, tbl, r.indexes, start, end, totalRowCount, counter, checkpoint, throttler)
 }
 
 func (r *readIndexExecutor) buildExternalStorePipeline(
@@ -254,24 +394,22 @@ func (r *readIndexExecutor) buildExternalStorePipeline(
 	tbl table.PhysicalTable,
 	start, end kv.Key,
 	totalRowCount *atomic.Int64,
+	checkpoint *readIndexCheckpoint,
+	throttler *backfillThrottler,
 ) (*operator.AsyncPipeline, error) {
 	d := r.d
-	onClose := func(summary *external.WriterSummary) {
+	onClose := func(indexID int64, summary *external.WriterSummary) {
+		// The writers rotated by the checkpoint may write nothing.
+		if summary.TotalSize == 0 {
+			return
+		}
 		sum, _ := r.subtaskSummary.Load(subtaskID)
 		s := sum.(*readIndexSummary)
 		s.mu.Lock()
-		if len(s.minKey) == 0 || summary.Min.Cmp(s.minKey) < 0 {
-			s.minKey = summary.Min.Clone()
-		}
-		if len(s.maxKey) == 0 || summary.Max.Cmp(s.maxKey) > 0 {
-			s.maxKey = summary.Max.Clone()
-		}
-		s.totalSize += summary.TotalSize
-		s.stats = append(s.stats, summary.MultipleFilesStats...)
-		for _, f := range summary.MultipleFilesStats {
-			for _, filename := range f.Filenames {
-				s.dataFiles = append(s.dataFiles, filename[0])
-				s.statFiles = append(s.statFiles, filename[1])
+		for i, index := range r.indexes {
+			if index.ID == indexID {
+				s.metaGroups[i].MergeSummary(summary)
+				break
 			}
 		}
 		s.mu.Unlock()
@@ -279,6 +417,6 @@ func (r *readIndexExecutor) buildExternalStorePipeline(
 	counter := metrics.BackfillTotalCounter.WithLabelValues(
 		metrics.GenerateReorgLabel("add_idx_rate", r.job.SchemaName, tbl.Meta().Name.O))
 	return NewWriteIndexToExternalStoragePipeline(
-		opCtx, d.store, r.cloudStorageURI, r.d.sessPool, sessCtx, r.job.ID, subtaskID,
-		tbl, r.indexes, start, end, totalRowCount, counter, onClose, r.bc)
+		opCtx, d.store, r.cloudStorageURI, r.compressData, r.d.sessPool, sessCtx, r.job.ID, subtaskID,
+		tbl, r.indexes, start, end, totalRowCount, counter, onClose, r.bc, checkpoint, throttler)
 }
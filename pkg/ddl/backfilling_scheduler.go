@@ -450,6 +450,7 @@ func (b *ingestBackfillScheduler) createCopReqSenderPool() (*copReqSenderPool, e
 		logutil.Logger(b.ctx).Warn("cannot init cop request sender", zap.Error(err))
 		return nil, err
 	}
+	setReorgReadOption(sessCtx, ri.Job)
 	reqSrc := getDDLRequestSource(model.ActionAddIndex)
 	copCtx, err := copr.NewCopContext(b.tbl.Meta(), allIndexInfos, sessCtx, reqSrc)
 	if err != nil {
@@ -464,7 +465,11 @@ func (*ingestBackfillScheduler) expectedWorkerSize() (readerSize int, writerSize
 }
 
 func expectedIngestWorkerCnt() (readerCnt, writerCnt int) {
-	workerCnt := int(variable.GetDDLReorgWorkerCounter())
+	return ingestWorkerCnt(int(variable.GetDDLReorgWorkerCounter()))
+}
+
+// ingestWorkerCnt returns the reader and writer count of the ingest pipeline for the worker count.
+func ingestWorkerCnt(workerCnt int) (readerCnt, writerCnt int) {
 	readerCnt = mathutil.Min(workerCnt/2, maxBackfillWorkerSize)
 	readerCnt = mathutil.Max(readerCnt, 1)
 	writerCnt = mathutil.Min(workerCnt/2+2, maxBackfillWorkerSize)
@@ -17,14 +17,33 @@ package ddl
 import (
 	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/backend/external"
+	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
+	"github.com/pingcap/tidb/pkg/disttask/framework/dispatcher"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/meta"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/util/dbterror"
+	"github.com/pingcap/tidb/pkg/util/mock"
+	"github.com/pingcap/tidb/pkg/util/size"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
 	"github.com/stretchr/testify/require"
+	"github.com/tikv/client-go/v2/oracle"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 func TestDoneTaskKeeper(t *testing.T) {
@@ -49,6 +68,430 @@ func TestDoneTaskKeeper(t *testing.T) {
 	require.True(t, bytes.Equal(n.nextKey, kv.Key("h")))
 }
 
+func TestReadIndexCheckpoint(t *testing.T) {
+	origin := readIndexCheckpointInterval
+	readIndexCheckpointInterval = 0
+	defer func() {
+		readIndexCheckpointInterval = origin
+	}()
+
+	var flushed int
+	var persisted []kv.Key
+	cp := newReadIndexCheckpoint(func(key kv.Key) error {
+		persisted = append(persisted, key)
+		return nil
+	})
+	cp.flush = func() error {
+		flushed++
+		return nil
+	}
+	ctx := context.Background()
+	// No task is finished, nothing is persisted.
+	require.NoError(t, cp.maybeCheckpoint(ctx))
+	require.Equal(t, 0, flushed)
+	require.Len(t, persisted, 0)
+
+	for i, end := range []string{"b", "c", "d"} {
+		cp.register(TableScanTask{ID: i + 1, End: kv.Key(end)})
+	}
+	cp.onChunkScanned(1, false)
+	cp.onChunkScanned(2, true)
+	cp.onChunkScanned(3, true)
+	cp.onChunkWritten(3)
+	cp.onChunkWritten(2)
+	cp.onChunkScanned(1, true)
+	cp.onChunkWritten(1)
+
+	done := make(chan error)
+	go func() {
+		done <- cp.maybeCheckpoint(ctx)
+	}()
+	select {
+	case <-done:
+		require.FailNow(t, "checkpoint should wait for the running tasks")
+	case <-time.After(100 * time.Millisecond):
+	}
+	require.Equal(t, 0, flushed)
+	// The last chunk of task 1 finishes all the tasks.
+	cp.onChunkWritten(1)
+	require.NoError(t, <-done)
+	require.Equal(t, 1, flushed)
+	require.Equal(t, []kv.Key{kv.Key("d")}, persisted)
+
+	// The checkpoint is skipped before the interval is reached.
+	readIndexCheckpointInterval = time.Hour
+	cp.register(TableScanTask{ID: 4, End: kv.Key("e")})
+	require.NoError(t, cp.maybeCheckpoint(ctx))
+	require.Equal(t, 1, flushed)
+	require.Len(t, persisted, 1)
+
+	// The parked subtask makes a checkpoint without waiting for the interval and stops.
+	cp.park()
+	go func() {
+		done <- cp.maybeCheckpoint(ctx)
+	}()
+	cp.onChunkScanned(4, true)
+	cp.onChunkWritten(4)
+	require.ErrorIs(t, <-done, errReadIndexParked)
+	require.Equal(t, 2, flushed)
+	require.Equal(t, []kv.Key{kv.Key("d"), kv.Key("e")}, persisted)
+}
+
+type mockTunableOperator struct {
+	size int32
+}
+
+func (op *mockTunableOperator) TunePool(workerNum int32) {
+	op.size = workerNum
+}
+
+func (op *mockTunableOperator) GetWorkerPoolSize() int32 {
+	return op.size
+}
+
+func TestBackfillThrottler(t *testing.T) {
+	busy := tikvLoad{cpuUsage: 0.9}
+	idle := tikvLoad{cpuUsage: 0.1}
+	throttler := newBackfillThrottlerWithSampler(nil)
+	reader, writer := &mockTunableOperator{size: 8}, &mockTunableOperator{size: 3}
+	throttler.addOperator(reader, func(workerCnt int) int { return workerCnt })
+	throttler.addOperator(writer, func(workerCnt int) int { return workerCnt/4 + 1 })
+	throttler.setWorkerCnt(8)
+
+	throttler.adjust(busy)
+	throttler.apply()
+	require.Equal(t, int32(4), reader.size)
+	require.Equal(t, int32(2), writer.size)
+	for i := 0; i < 5; i++ {
+		throttler.adjust(tikvLoad{pendingCompactionBytes: tikvPendingCompactionBytesThreshold})
+	}
+	throttler.apply()
+	require.Equal(t, int32(1), reader.size)
+	require.Equal(t, int32(1), writer.size)
+
+	// The concurrency is kept if the load is between the recover ratio and the thresholds.
+	throttler.adjust(tikvLoad{applyWaitDuration: tikvApplyWaitDurationThreshold * 8 / 10})
+	throttler.apply()
+	require.Equal(t, int32(1), reader.size)
+
+	throttler.adjust(idle)
+	throttler.apply()
+	require.Equal(t, int32(2), reader.size)
+	for i := 0; i < 5; i++ {
+		throttler.adjust(idle)
+	}
+	throttler.apply()
+	require.Equal(t, int32(8), reader.size)
+	require.Equal(t, int32(3), writer.size)
+
+	// The concurrency follows the worker count of the task.
+	throttler.setWorkerCnt(16)
+	throttler.apply()
+	require.Equal(t, int32(16), reader.size)
+	require.Equal(t, int32(5), writer.size)
+	throttler.adjust(busy)
+	throttler.setWorkerCnt(4)
+	throttler.apply()
+	require.Equal(t, int32(2), reader.size)
+	require.Equal(t, int32(1), writer.size)
+}
+
+func TestTiKVLoadSampler(t *testing.T) {
+	metrics := map[string]string{
+		"store1": `
+process_cpu_seconds_total %[1]d
+tikv_server_cpu_cores_quota 4
+# TYPE tikv_raftstore_apply_wait_time_duration_secs histogram
+tikv_raftstore_apply_wait_time_duration_secs_bucket{le="+Inf"} %[3]d
+tikv_raftstore_apply_wait_time_duration_secs_sum %[2]f
+tikv_raftstore_apply_wait_time_duration_secs_count %[3]d
+tikv_engine_pending_compaction_bytes{cf="default",db="kv"} 1024
+tikv_engine_pending_compaction_bytes{cf="write",db="kv"} 2048
+`,
+		"store2": `
+process_cpu_seconds_total %[1]d
+tikv_server_cpu_cores_quota 8
+# TYPE tikv_raftstore_apply_wait_time_duration_secs histogram
+tikv_raftstore_apply_wait_time_duration_secs_bucket{le="+Inf"} %[3]d
+tikv_raftstore_apply_wait_time_duration_secs_sum %[2]f
+tikv_raftstore_apply_wait_time_duration_secs_count %[3]d
+tikv_engine_pending_compaction_bytes{cf="default",db="kv"} 0
+`,
+	}
+	var (
+		cpuSeconds   = map[string]int{}
+		applyWaitSum = map[string]float64{}
+		applyWaitCnt = map[string]int{}
+	)
+	sampler := &tikvLoadSampler{
+		getStatusAddrs: func() ([]string, error) {
+			return []string{"store1", "store2"}, nil
+		},
+		fetchMetrics: func(_ context.Context, addr string) (map[string]*dto.MetricFamily, error) {
+			text := fmt.Sprintf(metrics[addr], cpuSeconds[addr], applyWaitSum[addr], applyWaitCnt[addr])
+			var parser expfmt.TextParser
+			return parser.TextToMetricFamilies(strings.NewReader(text))
+		},
+		prev: make(map[string]tikvStoreCounters),
+	}
+	ctx := context.Background()
+
+	// The counters are only recorded in the first sample.
+	load, err := sampler.sample(ctx)
+	require.NoError(t, err)
+	require.Equal(t, tikvLoad{pendingCompactionBytes: 2048}, load)
+
+	// Make the previous sample 1 second ago.
+	for addr, c := range sampler.prev {
+		c.sampleTime = c.sampleTime.Add(-time.Second)
+		sampler.prev[addr] = c
+	}
+	cpuSeconds["store1"], cpuSeconds["store2"] = 2, 4
+	applyWaitSum["store1"], applyWaitCnt["store1"] = 0.1, 10
+	applyWaitSum["store2"], applyWaitCnt["store2"] = 0.5, 10
+	load, err = sampler.sample(ctx)
+	require.NoError(t, err)
+	require.InDelta(t, 0.5, load.cpuUsage, 0.05)
+	require.InDelta(t, float64(50*time.Millisecond), float64(load.applyWaitDuration), float64(5*time.Millisecond))
+	require.Equal(t, float64(2048), load.pendingCompactionBytes)
+	require.Less(t, load.pressure(), 1.0)
+}
+
+type mockSubtaskMetasHandle struct {
+	dispatcher.TaskHandle
+	metas [][]byte
+}
+
+func (h *mockSubtaskMetasHandle) GetPreviousSubtaskMetas(int64, proto.Step) ([][]byte, error) {
+	return h.metas, nil
+}
+
+func TestGetSummaryFromLastStep(t *testing.T) {
+	tblInfo := &model.TableInfo{
+		ID:      100,
+		Indices: []*model.IndexInfo{{ID: 1}, {ID: 2, Global: true}},
+	}
+	eleIDs := []int64{1, 2}
+	newMeta := func(minKey, maxKey, file string) *external.SortedKVMeta {
+		return &external.SortedKVMeta{
+			MinKey:      []byte(minKey),
+			MaxKey:      []byte(maxKey),
+			TotalKVSize: 1,
+			DataFiles:   []string{file},
+			StatFiles:   []string{file + ".stat"},
+		}
+	}
+	h := &mockSubtaskMetasHandle{}
+	for _, m := range []*BackfillSubTaskMeta{
+		{PhysicalTableID: 101, MetaGroups: []*external.SortedKVMeta{newMeta("a1", "a2", "f1"), newMeta("g1", "g2", "f2")}},
+		{PhysicalTableID: 102, MetaGroups: []*external.SortedKVMeta{newMeta("b1", "b2", "f3"), newMeta("g0", "g1", "f4")}},
+		{PhysicalTableID: 102, MetaGroups: []*external.SortedKVMeta{{}, {}}},
+	} {
+		bs, err := json.Marshal(m)
+		require.NoError(t, err)
+		h.metas = append(h.metas, bs)
+	}
+
+	// The keys of the local index are grouped by the partition, while the keys of the global index are not.
+	groups, err := getSummaryFromLastStep(h, 1, StepReadIndex, tblInfo, eleIDs)
+	require.NoError(t, err)
+	require.Len(t, groups, 3)
+	require.Equal(t, [2]int64{1, 101}, [2]int64{groups[0].eleID, groups[0].physicalTableID})
+	require.Equal(t, []string{"f1"}, groups[0].DataFiles)
+	require.Equal(t, [2]int64{2, 100}, [2]int64{groups[1].eleID, groups[1].physicalTableID})
+	require.Equal(t, []string{"f2", "f4"}, groups[1].DataFiles)
+	require.Equal(t, kv.Key("g0"), kv.Key(groups[1].MinKey))
+	require.Equal(t, kv.Key("g2"), kv.Key(groups[1].MaxKey))
+	require.Equal(t, uint64(2), groups[1].TotalKVSize)
+	require.Equal(t, []uint64{1, 1}, groups[1].fileSizes)
+	require.Equal(t, [2]int64{1, 102}, [2]int64{groups[2].eleID, groups[2].physicalTableID})
+	require.Equal(t, []string{"f3"}, groups[2].DataFiles)
+
+	// The subtasks of the merge sort step handle one group each.
+	h.metas = h.metas[:0]
+	for _, m := range []*BackfillSubTaskMeta{
+		{PhysicalTableID: 101, EleID: 1, SortedKVMeta: *newMeta("a1", "a2", "m1")},
+		{PhysicalTableID: 100, EleID: 2, SortedKVMeta: *newMeta("g0", "g2", "m2")},
+	} {
+		bs, err := json.Marshal(m)
+		require.NoError(t, err)
+		h.metas = append(h.metas, bs)
+	}
+	groups, err = getSummaryFromLastStep(h, 1, StepMergeSort, tblInfo, eleIDs)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+	require.Equal(t, []string{"m1"}, groups[0].DataFiles)
+	require.Equal(t, [2]int64{2, 100}, [2]int64{groups[1].eleID, groups[1].physicalTableID})
+
+	h.metas = h.metas[:1]
+	_, err = getSummaryFromLastStep(h, 1, StepMergeSort, tblInfo, []int64{3})
+	require.ErrorContains(t, err, "unknown element ID 1")
+}
+
+func TestSplitMergeSortFiles(t *testing.T) {
+	require.Empty(t, splitMergeSortFiles(nil, 10, 2))
+	// balanced by the file sizes.
+	require.Equal(t, [][2]int{{0, 1}, {1, 4}, {4, 6}},
+		splitMergeSortFiles([]uint64{60, 20, 20, 20, 30, 30}, 10, 3))
+	// limited by the file count.
+	require.Equal(t, [][2]int{{0, 2}, {2, 4}, {4, 5}},
+		splitMergeSortFiles([]uint64{1, 1, 1, 1, 1}, 2, 1))
+	// balanced by the file count when the sizes are unknown.
+	require.Equal(t, [][2]int{{0, 2}, {2, 4}},
+		splitMergeSortFiles([]uint64{0, 0, 0, 0}, 10, 2))
+	// no more groups than the files.
+	require.Equal(t, [][2]int{{0, 1}, {1, 2}},
+		splitMergeSortFiles([]uint64{5, 5}, 10, 4))
+}
+
+func TestGetMergeSortMaxFileCount(t *testing.T) {
+	require.Equal(t, external.MergeSortFileCountStep, getMergeSortMaxFileCount(64*size.GB, false))
+	// 1GB is left after reserving the memory of the writer and the hot reader.
+	memTotal := uint64(2*size.GB) + 2*(mergeSortWriterMemLimit+
+		uint64(external.ConcurrentReaderBufferSizePerConc*external.ConcurrentReaderConcurrency))
+	require.Equal(t, external.MergeSortFileCountStep, getMergeSortMaxFileCount(memTotal, false))
+	// the decoders of the compressed files take more memory.
+	require.Equal(t, 496, getMergeSortMaxFileCount(memTotal, true))
+	require.Equal(t, 2, getMergeSortMaxFileCount(512*size.MB, false))
+}
+
+func TestCleanUpGlobalSortFiles(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := storage.NewLocalStorage(dir)
+	require.NoError(t, err)
+	for _, name := range []string{"1/2/data/0", "1/2/data_stat/0", "1/3/data/0", "10/2/data/0"} {
+		require.NoError(t, store.WriteFile(ctx, name, []byte("data")))
+	}
+
+	leaked, err := cleanUpGlobalSortFiles(ctx, "local://"+dir, 1)
+	require.NoError(t, err)
+	require.Zero(t, leaked)
+	var left []string
+	require.NoError(t, store.WalkDir(ctx, &storage.WalkOption{}, func(path string, _ int64) error {
+		left = append(left, path)
+		return nil
+	}))
+	// the files of the other jobs are kept.
+	require.Equal(t, []string{"10/2/data/0"}, left)
+
+	// nothing to clean up.
+	leaked, err = cleanUpGlobalSortFiles(ctx, "local://"+dir, 1)
+	require.NoError(t, err)
+	require.Zero(t, leaked)
+}
+
+func TestClassifyBackfillPlanErr(t *testing.T) {
+	dsp := &BackfillingDispatcherExt{}
+	logger := zap.NewNop()
+	require.NoError(t, dsp.waitPlanRetry(context.Background(), StepReadIndex))
+
+	// permanent errors fail the task immediately.
+	for _, err := range []error{
+		kv.ErrKeyExists.FastGenByArgs("1", "idx"),
+		meta.ErrTableNotExists.GenWithStack("table doesn't exist"),
+		errors.Trace(json.Unmarshal([]byte("{"), &BackfillGlobalMeta{})),
+	} {
+		err = dsp.classifyPlanErr(StepReadIndex, err, logger)
+		require.False(t, dsp.IsRetryableErr(err))
+	}
+	require.Zero(t, dsp.planRetryCnt)
+	// the paused job doesn't consume the retry budget.
+	err := dsp.classifyPlanErr(StepReadIndex, dbterror.ErrPausedDDLJob.GenWithStackByArgs(1), logger)
+	require.True(t, dsp.IsRetryableErr(err))
+	require.Zero(t, dsp.planRetryCnt)
+
+	// other errors are retried with exponential backoff.
+	mockErr := errors.New("mock error")
+	err = dsp.classifyPlanErr(StepReadIndex, mockErr, logger)
+	require.True(t, dsp.IsRetryableErr(err))
+	require.Equal(t, 1, dsp.planRetryCnt)
+	require.WithinDuration(t, time.Now().Add(backfillPlanRetryInterval), dsp.planRetryAfter, time.Second)
+	err = dsp.classifyPlanErr(StepReadIndex, mockErr, logger)
+	require.True(t, dsp.IsRetryableErr(err))
+	require.WithinDuration(t, time.Now().Add(2*backfillPlanRetryInterval), dsp.planRetryAfter, time.Second)
+	// the wait is interrupted by the context.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, dsp.waitPlanRetry(ctx, StepReadIndex), context.Canceled)
+	// success resets the retry count.
+	require.NoError(t, dsp.classifyPlanErr(StepReadIndex, nil, logger))
+	require.Zero(t, dsp.planRetryCnt)
+
+	// the task fails when the retry budget runs out.
+	for i := 0; i < backfillPlanMaxRetryCnt; i++ {
+		err = dsp.classifyPlanErr(StepMergeSort, mockErr, logger)
+		require.True(t, dsp.IsRetryableErr(err))
+	}
+	err = dsp.classifyPlanErr(StepMergeSort, mockErr, logger)
+	require.False(t, dsp.IsRetryableErr(err))
+	require.ErrorContains(t, err, "failed to generate the subtasks of step merge-sort after 10 retries: mock error")
+	// the retry budget is per step.
+	require.NoError(t, dsp.waitPlanRetry(context.Background(), StepWriteAndIngest))
+	require.Zero(t, dsp.planRetryCnt)
+}
+
+func TestBackfillProgressEstimator(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	e := &backfillProgressEstimator{totalRows: 10000}
+	require.True(t, e.needSample(now))
+	throughput, eta := e.update(now, 0)
+	require.Zero(t, throughput)
+	require.Zero(t, eta)
+	require.False(t, e.needSample(now.Add(time.Second)))
+
+	// 100 rows per second.
+	now = now.Add(10 * time.Second)
+	require.True(t, e.needSample(now))
+	throughput, eta = e.update(now, 1000)
+	require.Equal(t, float64(100), throughput)
+	require.Equal(t, now.Add(90*time.Second).Unix(), eta)
+
+	// the throughput is calculated within the window.
+	for i := 0; i < 10; i++ {
+		now = now.Add(10 * time.Second)
+		throughput, eta = e.update(now, 1000+int64(i+1)*200)
+	}
+	require.Equal(t, float64(20), throughput)
+	require.Equal(t, now.Add(350*time.Second).Unix(), eta)
+	require.LessOrEqual(t, now.Sub(e.samples[1].time), backfillThroughputWindow)
+
+	// the ETA can't be estimated when no rows are backfilled within the window,
+	// or the table statistics are out of date.
+	e = &backfillProgressEstimator{totalRows: 100}
+	e.update(now, 1000)
+	throughput, eta = e.update(now.Add(10*time.Second), 1000)
+	require.Zero(t, throughput)
+	require.Zero(t, eta)
+	throughput, eta = e.update(now.Add(20*time.Second), 2000)
+	require.Equal(t, float64(50), throughput)
+	require.Zero(t, eta)
+}
+
+func TestLitBackfillDispatcherTakeOver(t *testing.T) {
+	// the state of the previous dispatcher owner is restored from the task meta.
+	taskMeta := &BackfillGlobalMeta{PreviousSchedulerIDs: []string{"10.0.0.1:4000", "10.0.0.2:4000"}, TotalRows: 1000}
+	metaBytes, err := json.Marshal(taskMeta)
+	require.NoError(t, err)
+	dsp := newLitBackfillDispatcher(context.Background(), nil, nil, ":4000", &proto.Task{ID: 1, Meta: metaBytes})
+	require.NoError(t, dsp.Init())
+	ext := dsp.(*LitBackfillDispatcher).Extension.(*BackfillingDispatcherExt)
+	require.False(t, ext.GlobalSort)
+	require.Equal(t, taskMeta.PreviousSchedulerIDs, ext.previousSchedulerIDs)
+	require.Equal(t, int64(1000), ext.progress.totalRows)
+
+	// the task meta of the old version.
+	taskMeta = &BackfillGlobalMeta{CloudStorageURI: "s3://sorted"}
+	metaBytes, err = json.Marshal(taskMeta)
+	require.NoError(t, err)
+	dsp = newLitBackfillDispatcher(context.Background(), nil, nil, ":4000", &proto.Task{ID: 1, Meta: metaBytes})
+	require.NoError(t, dsp.Init())
+	ext = dsp.(*LitBackfillDispatcher).Extension.(*BackfillingDispatcherExt)
+	require.True(t, ext.GlobalSort)
+	require.Empty(t, ext.previousSchedulerIDs)
+	require.Zero(t, ext.progress.totalRows)
+}
+
 func TestPickBackfillType(t *testing.T) {
 	originMgr := ingest.LitBackCtxMgr
 	originInit := ingest.LitInitialized
@@ -88,3 +531,134 @@ func TestPickBackfillType(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, tp, model.ReorgTypeLitMerge)
 }
+
+func TestGetDistTaskPriority(t *testing.T) {
+	require.Equal(t, proto.NormalPriority, getDistTaskPriority(kv.PriorityNormal))
+	require.Equal(t, proto.LowPriority, getDistTaskPriority(kv.PriorityLow))
+	require.Equal(t, proto.HighPriority, getDistTaskPriority(kv.PriorityHigh))
+	require.Less(t, getDistTaskPriority(kv.PriorityHigh), getDistTaskPriority(kv.PriorityNormal))
+}
+
+func TestFilterByMinVersion(t *testing.T) {
+	require.Equal(t, "7.5.0", parseTiDBVersion("8.0.11-TiDB-v7.5.0-alpha-12-gabcdef").String())
+	require.Nil(t, parseTiDBVersion("8.0.11-TiDB-None"))
+
+	newServerInfo := func(id, ver string) *infosync.ServerInfo {
+		return &infosync.ServerInfo{ID: id, ServerVersionInfo: infosync.ServerVersionInfo{Version: ver}}
+	}
+	serverInfos := []*infosync.ServerInfo{
+		newServerInfo("1", "8.0.11-TiDB-v7.4.0"),
+		newServerInfo("2", "8.0.11-TiDB-v7.5.0"),
+		newServerInfo("3", "8.0.11-TiDB-v7.6.0-alpha"),
+		newServerInfo("4", "8.0.11-TiDB-None"),
+	}
+	ctx := context.Background()
+	require.Equal(t, serverInfos, filterByMinVersion(ctx, serverInfos, ""))
+	require.Equal(t, serverInfos, filterByMinVersion(ctx, serverInfos, "7.4.0"))
+	require.Equal(t, serverInfos[1:], filterByMinVersion(ctx, serverInfos, "7.5.0"))
+	require.Equal(t, serverInfos[2:], filterByMinVersion(ctx, serverInfos, "7.6.0"))
+	require.Equal(t, serverInfos[3:], filterByMinVersion(ctx, serverInfos, "8.0.0"))
+}
+
+func TestPlanSubtaskRebalance(t *testing.T) {
+	newSubtask := func(id int64, state proto.TaskState, node string) *proto.Subtask {
+		return &proto.Subtask{ID: id, State: state, SchedulerID: node}
+	}
+	getTargets := func(moves []subtaskMove) map[int64]string {
+		targets := make(map[int64]string, len(moves))
+		for _, m := range moves {
+			targets[m.subtask.ID] = m.target
+		}
+		return targets
+	}
+	subtasks := []*proto.Subtask{
+		newSubtask(1, proto.TaskStateRunning, "a"),
+		newSubtask(2, proto.TaskStatePending, "a"),
+		newSubtask(3, proto.TaskStatePending, "a"),
+		newSubtask(4, proto.TaskStatePending, "a"),
+		newSubtask(5, proto.TaskStateSucceed, "b"),
+		newSubtask(6, proto.TaskStateRunning, "b"),
+	}
+	require.Empty(t, planSubtaskRebalance(subtasks, nil))
+	// balanced already.
+	require.Empty(t, planSubtaskRebalance([]*proto.Subtask{subtasks[1], subtasks[5]}, []string{"a", "b"}))
+	// node c joins.
+	require.Equal(t, map[int64]string{4: "c", 3: "b"}, getTargets(planSubtaskRebalance(subtasks, []string{"a", "b", "c"})))
+	// node a is drained, the running subtask 1 is not moved.
+	require.Equal(t, map[int64]string{2: "c", 3: "b", 4: "c"}, getTargets(planSubtaskRebalance(subtasks, []string{"b", "c"})))
+	// the speculative copy is not moved.
+	subtasks[3].OriginID = 6
+	require.Equal(t, map[int64]string{3: "c", 2: "b"}, getTargets(planSubtaskRebalance(subtasks, []string{"a", "b", "c"})))
+}
+
+func TestReorgReadOption(t *testing.T) {
+	now := time.Now()
+	startTS := oracle.GoTimeToTS(now)
+	snapshotVer := oracle.GoTimeToTS(now.Add(-time.Minute))
+	job := &model.Job{SnapshotVer: snapshotVer, ReorgMeta: &model.DDLReorgMeta{ReplicaRead: "follower"}}
+	sessCtx := mock.NewContext()
+	setReorgReadOption(sessCtx, job)
+	require.Equal(t, kv.ReplicaReadFollower, sessCtx.GetSessionVars().GetReplicaRead())
+	require.Nil(t, sessCtx.Value(staleReadOptionKey))
+
+	job.ReorgMeta.MaxStaleness = 10
+	setReorgReadOption(sessCtx, job)
+	opt := sessCtx.Value(staleReadOptionKey).(*staleReadOption)
+	readTS, isStale := opt.readTS(startTS)
+	require.True(t, isStale)
+	require.Equal(t, oracle.GoTimeToTS(now.Add(-10*time.Second)), readTS)
+	// never read the snapshot older than the snapshot version.
+	opt.maxStaleness = time.Hour
+	readTS, isStale = opt.readTS(startTS)
+	require.True(t, isStale)
+	require.Equal(t, snapshotVer, readTS)
+	readTS, isStale = opt.readTS(snapshotVer)
+	require.False(t, isStale)
+	require.Equal(t, snapshotVer, readTS)
+
+	require.Nil(t, sessCtx.Value(scanLimiterKey))
+	job.ReorgMeta.MaxScanSpeed = 100
+	setReorgReadOption(sessCtx, job)
+	limiter := sessCtx.Value(scanLimiterKey).(*rate.Limiter)
+	require.Equal(t, rate.Limit(100), limiter.Limit())
+	// the rows more than the burst are waited in batches.
+	require.NoError(t, waitScanLimiter(context.Background(), limiter, 150))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, waitScanLimiter(ctx, limiter, 10))
+}
+
+func TestUpdateGlobalSortStats(t *testing.T) {
+	marshal := func(metas ...*BackfillSubTaskMeta) [][]byte {
+		res := make([][]byte, 0, len(metas))
+		for _, m := range metas {
+			bs, err := json.Marshal(m)
+			require.NoError(t, err)
+			res = append(res, bs)
+		}
+		return res
+	}
+	newMeta := func(size uint64, files ...string) *external.SortedKVMeta {
+		return &external.SortedKVMeta{TotalKVSize: size, DataFiles: files, StatFiles: files}
+	}
+	h := &mockSubtaskMetasHandle{metas: marshal(
+		&BackfillSubTaskMeta{MetaGroups: []*external.SortedKVMeta{newMeta(10, "f1", "f2"), newMeta(5, "f3")}},
+		&BackfillSubTaskMeta{MetaGroups: []*external.SortedKVMeta{newMeta(20, "f4"), newMeta(0)}},
+	)}
+	stats := &model.GlobalSortStats{}
+	task := &proto.Task{ID: 1, Step: StepReadIndex}
+	require.NoError(t, updateGlobalSortStats(h, task, StepMergeSort, nil, stats))
+	require.Equal(t, model.GlobalSortStats{TotalKVSize: 35, DataFiles: 4, StatFiles: 4}, *stats)
+
+	task.Step = StepMergeSort
+	ingestMetas := marshal(
+		&BackfillSubTaskMeta{RangeSplitKeys: [][]byte{[]byte("a"), []byte("b")}},
+		&BackfillSubTaskMeta{RangeSplitKeys: [][]byte{[]byte("c")}},
+	)
+	require.NoError(t, updateGlobalSortStats(h, task, StepWriteAndIngest, ingestMetas, stats))
+	require.Equal(t, model.GlobalSortStats{TotalKVSize: 35, DataFiles: 4, StatFiles: 4, MergeSortPasses: 1, RangeSplits: 3}, *stats)
+	// generating the subtasks again doesn't count twice.
+	require.NoError(t, updateGlobalSortStats(h, task, StepWriteAndIngest, ingestMetas, stats))
+	require.Equal(t, 1, stats.MergeSortPasses)
+	require.Equal(t, 3, stats.RangeSplits)
+}
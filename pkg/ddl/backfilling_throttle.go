@@ -0,0 +1,378 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/ddl/placement"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/pingcap/tidb/pkg/store/helper"
+	"github.com/pingcap/tidb/pkg/util"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/mathutil"
+	"github.com/pingcap/tidb/pkg/util/size"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+)
+
+// backfillThrottleInterval is the interval to sample the load of TiKV stores.
+var backfillThrottleInterval = 10 * time.Second
+
+const (
+	// The load thresholds of a TiKV store, above which the backfill concurrency is reduced.
+	tikvCPUUsageThreshold               = 0.8
+	tikvApplyWaitDurationThreshold      = 100 * time.Millisecond
+	tikvPendingCompactionBytesThreshold = float64(64 * size.GB)
+	// The backfill concurrency is recovered once the load drops below this ratio of the thresholds.
+	backfillThrottleRecoverRatio = 0.6
+	// backfillThrottleMinRatio is the minimum ratio of the throttled concurrency to the original one.
+	backfillThrottleMinRatio = 0.125
+)
+
+// The names of the TiKV metrics used to evaluate the load.
+const (
+	tikvMetricCPUSeconds             = "process_cpu_seconds_total"
+	tikvMetricCPUCoresQuota          = "tikv_server_cpu_cores_quota"
+	tikvMetricApplyWaitDuration      = "tikv_raftstore_apply_wait_time_duration_secs"
+	tikvMetricPendingCompactionBytes = "tikv_engine_pending_compaction_bytes"
+)
+
+// tikvLoad is the load of the busiest TiKV store on each dimension.
+type tikvLoad struct {
+	// cpuUsage is the ratio of the used CPU to the CPU quota.
+	cpuUsage float64
+	// applyWaitDuration is the average duration that a raft log waits to be applied.
+	applyWaitDuration      time.Duration
+	pendingCompactionBytes float64
+}
+
+// pressure returns the ratio of the load to the thresholds on the busiest dimension.
+func (l tikvLoad) pressure() float64 {
+	return mathutil.Max(
+		l.cpuUsage/tikvCPUUsageThreshold,
+		float64(l.applyWaitDuration)/float64(tikvApplyWaitDurationThreshold),
+		l.pendingCompactionBytes/tikvPendingCompactionBytesThreshold,
+	)
+}
+
+type tikvStoreCounters struct {
+	sampleTime     time.Time
+	cpuSeconds     float64
+	applyWaitSum   float64
+	applyWaitCount float64
+}
+
+// tikvLoadSampler samples the load of TiKV stores from their metrics. The CPU usage and
+// the apply wait duration are computed from the counters of two successive samples.
+type tikvLoadSampler struct {
+	getStatusAddrs func() ([]string, error)
+	fetchMetrics   func(ctx context.Context, statusAddr string) (map[string]*dto.MetricFamily, error)
+
+	prev map[string]tikvStoreCounters // status address -> counters
+}
+
+func newTiKVLoadSampler(store helper.Storage) *tikvLoadSampler {
+	h := helper.NewHelper(store)
+	return &tikvLoadSampler{
+		getStatusAddrs: func() ([]string, error) {
+			storesStat, err := h.GetStoresStat()
+			if err != nil {
+				return nil, err
+			}
+			addrs := make([]string, 0, len(storesStat.Stores))
+			for _, s := range storesStat.Stores {
+				if s.Store.StateName != "Up" || isTiFlashStore(s.Store.Labels) {
+					continue
+				}
+				addrs = append(addrs, s.Store.StatusAddress)
+			}
+			return addrs, nil
+		},
+		fetchMetrics: fetchTiKVMetrics,
+		prev:         make(map[string]tikvStoreCounters),
+	}
+}
+
+func isTiFlashStore(labels []helper.StoreLabel) bool {
+	for _, label := range labels {
+		if label.Key == placement.EngineLabelKey && label.Value == placement.EngineLabelTiFlash {
+			return true
+		}
+	}
+	return false
+}
+
+func fetchTiKVMetrics(ctx context.Context, statusAddr string) (map[string]*dto.MetricFamily, error) {
+	url := fmt.Sprintf("%s://%s/metrics", util.InternalHTTPSchema(), statusAddr)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	resp, err := util.InternalHTTPClient().Do(req)
+	if err != nil {
+		return nil, errors.Trace(err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("fetch metrics from %s failed, status code: %d", statusAddr, resp.StatusCode)
+	}
+	var parser expfmt.TextParser
+	mfs, err := parser.TextToMetricFamilies(resp.Body)
+	return mfs, errors.Trace(err)
+}
+
+func (s *tikvLoadSampler) sample(ctx context.Context) (tikvLoad, error) {
+	var load tikvLoad
+	addrs, err := s.getStatusAddrs()
+	if err != nil {
+		return load, err
+	}
+	now := time.Now()
+	cur := make(map[string]tikvStoreCounters, len(addrs))
+	for _, addr := range addrs {
+		mfs, err := s.fetchMetrics(ctx, addr)
+		if err != nil {
+			// Ignore the unavailable store, it doesn't slow down the backfill.
+			logutil.BgLogger().Warn("fetch TiKV metrics failed", zap.String("category", "ddl"),
+				zap.String("address", addr), zap.Error(err))
+			continue
+		}
+		applyWaitSum, applyWaitCount := sumHistogram(mfs[tikvMetricApplyWaitDuration])
+		c := tikvStoreCounters{
+			sampleTime:     now,
+			cpuSeconds:     sumMetric(mfs[tikvMetricCPUSeconds]),
+			applyWaitSum:   applyWaitSum,
+			applyWaitCount: applyWaitCount,
+		}
+		cur[addr] = c
+		load.pendingCompactionBytes = mathutil.Max(load.pendingCompactionBytes,
+			maxMetric(mfs[tikvMetricPendingCompactionBytes]))
+		prev, ok := s.prev[addr]
+		if !ok {
+			continue
+		}
+		elapsed := c.sampleTime.Sub(prev.sampleTime).Seconds()
+		if quota := sumMetric(mfs[tikvMetricCPUCoresQuota]); quota > 0 && elapsed > 0 {
+			load.cpuUsage = mathutil.Max(load.cpuUsage, (c.cpuSeconds-prev.cpuSeconds)/elapsed/quota)
+		}
+		if cnt := c.applyWaitCount - prev.applyWaitCount; cnt > 0 {
+			avg := time.Duration((c.applyWaitSum - prev.applyWaitSum) / cnt * float64(time.Second))
+			load.applyWaitDuration = mathutil.Max(load.applyWaitDuration, avg)
+		}
+	}
+	s.prev = cur
+	return load, nil
+}
+
+// sumMetric returns the sum of the counter and gauge values of the metric family.
+func sumMetric(mf *dto.MetricFamily) float64 {
+	var sum float64
+	for _, m := range mf.GetMetric() {
+		sum += m.GetCounter().GetValue() + m.GetGauge().GetValue() + m.GetUntyped().GetValue()
+	}
+	return sum
+}
+
+// maxMetric returns the maximum of the counter and gauge values of the metric family.
+func maxMetric(mf *dto.MetricFamily) float64 {
+	var maxVal float64
+	for _, m := range mf.GetMetric() {
+		v := m.GetCounter().GetValue() + m.GetGauge().GetValue() + m.GetUntyped().GetValue()
+		maxVal = mathutil.Max(maxVal, v)
+	}
+	return maxVal
+}
+
+// sumHistogram returns the sample sum and the sample count of the histogram metric family.
+func sumHistogram(mf *dto.MetricFamily) (sum, count float64) {
+	for _, m := range mf.GetMetric() {
+		sum += m.GetHistogram().GetSampleSum()
+		count += float64(m.GetHistogram().GetSampleCount())
+	}
+	return sum, count
+}
+
+type tunableOperator interface {
+	TunePool(workerNum int32)
+	GetWorkerPoolSize() int32
+}
+
+// backfillThrottler reduces the concurrency of the backfill operators when TiKV is under
+// pressure, and recovers it gradually once the load drops. It also follows the change of
+// the worker count of the task. The load and the worker count are refreshed in the background,
+// while the operators are tuned by the table scan source between tasks, when all the workers
+// are alive.
+type backfillThrottler struct {
+	sample func(ctx context.Context) (tikvLoad, error)
+	// getWorkerCnt gets the latest worker count of the task.
+	getWorkerCnt func() (int, error)
+
+	ops []tunableOperator
+	// sizes compute the concurrency of the operators from the worker count.
+	sizes []func(workerCnt int) int
+	// ratio is the expected ratio of the concurrency to the unthrottled one, stored in math.Float64bits.
+	ratio     atomic.Uint64
+	workerCnt atomic.Int64
+	applied   struct {
+		ratio     float64
+		workerCnt int64
+	}
+
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+}
+
+// newBackfillThrottler creates a backfillThrottler. The TiKV load is not sampled if the store is not TiKV.
+func newBackfillThrottler(store kv.Storage, getWorkerCnt func() (int, error)) *backfillThrottler {
+	var sample func(ctx context.Context) (tikvLoad, error)
+	if s, ok := store.(helper.Storage); ok {
+		if ebd, ok := store.(kv.EtcdBackend); ok {
+			if addrs, err := ebd.EtcdAddrs(); err == nil && len(addrs) > 0 {
+				sample = newTiKVLoadSampler(s).sample
+			}
+		}
+	}
+	t := newBackfillThrottlerWithSampler(sample)
+	t.getWorkerCnt = getWorkerCnt
+	// The operators are created with the worker count in the variable.
+	t.setWorkerCnt(int(variable.GetDDLReorgWorkerCounter()))
+	t.applied.workerCnt = t.workerCnt.Load()
+	return t
+}
+
+func newBackfillThrottlerWithSampler(sample func(ctx context.Context) (tikvLoad, error)) *backfillThrottler {
+	t := &backfillThrottler{
+		sample: sample,
+	}
+	t.applied.ratio = 1
+	t.ratio.Store(math.Float64bits(1))
+	return t
+}
+
+// addOperator registers an operator whose concurrency is throttled, size computes its
+// concurrency from the worker count.
+func (t *backfillThrottler) addOperator(op tunableOperator, size func(workerCnt int) int) {
+	t.ops = append(t.ops, op)
+	t.sizes = append(t.sizes, size)
+}
+
+func (t *backfillThrottler) getRatio() float64 {
+	return math.Float64frombits(t.ratio.Load())
+}
+
+func (t *backfillThrottler) setWorkerCnt(workerCnt int) {
+	old := t.workerCnt.Swap(int64(workerCnt))
+	if old != 0 && old != int64(workerCnt) {
+		logutil.BgLogger().Info("adjust backfill concurrency by worker count", zap.String("category", "ddl"),
+			zap.Int64("oldWorkerCnt", old), zap.Int("newWorkerCnt", workerCnt))
+	}
+}
+
+// start starts refreshing the load and the worker count in the background.
+func (t *backfillThrottler) start(ctx context.Context) {
+	ctx, t.cancel = context.WithCancel(ctx)
+	interval := backfillThrottleInterval
+	failpoint.Inject("mockBackfillThrottleInterval", func(val failpoint.Value) {
+		//nolint:forcetypeassert
+		interval = time.Duration(val.(int)) * time.Millisecond
+	})
+	t.wg.Add(1)
+	go func() {
+		defer t.wg.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if t.getWorkerCnt != nil {
+				if workerCnt, err := t.getWorkerCnt(); err != nil {
+					logutil.BgLogger().Warn("get backfill worker count failed", zap.String("category", "ddl"), zap.Error(err))
+				} else {
+					t.setWorkerCnt(workerCnt)
+				}
+			}
+			if t.sample == nil {
+				continue
+			}
+			load, err := t.sample(ctx)
+			if err != nil {
+				logutil.BgLogger().Warn("sample TiKV load failed", zap.String("category", "ddl"), zap.Error(err))
+				continue
+			}
+			t.adjust(load)
+		}
+	}()
+}
+
+// close stops refreshing the load and the worker count.
+func (t *backfillThrottler) close() {
+	t.cancel()
+	t.wg.Wait()
+}
+
+// adjust halves the ratio if the load exceeds the thresholds, and increases it step by step
+// once the load is low enough.
+func (t *backfillThrottler) adjust(load tikvLoad) {
+	pressure := load.pressure()
+	oldRatio := t.getRatio()
+	ratio := oldRatio
+	if pressure >= 1 {
+		ratio = mathutil.Max(ratio/2, backfillThrottleMinRatio)
+	} else if pressure < backfillThrottleRecoverRatio {
+		ratio = mathutil.Min(ratio*2, 1)
+	}
+	if ratio == oldRatio {
+		return
+	}
+	logutil.BgLogger().Info("adjust backfill concurrency by TiKV load", zap.String("category", "ddl"),
+		zap.Float64("cpuUsage", load.cpuUsage),
+		zap.Duration("applyWaitDuration", load.applyWaitDuration),
+		zap.Float64("pendingCompactionBytes", load.pendingCompactionBytes),
+		zap.Float64("oldRatio", oldRatio), zap.Float64("newRatio", ratio))
+	t.ratio.Store(math.Float64bits(ratio))
+}
+
+// apply tunes the operators to the expected concurrency. It must be called by the goroutine
+// generating the tasks.
+func (t *backfillThrottler) apply() {
+	ratio, workerCnt := t.getRatio(), t.workerCnt.Load()
+	if workerCnt <= 0 || (ratio == t.applied.ratio && workerCnt == t.applied.workerCnt) {
+		return
+	}
+	for i, op := range t.ops {
+		size := int32(math.Ceil(float64(t.sizes[i](int(workerCnt))) * ratio))
+		size = mathutil.Max(size, 1)
+		if op.GetWorkerPoolSize() != size {
+			op.TunePool(size)
+		}
+	}
+	t.applied.ratio, t.applied.workerCnt = ratio, workerCnt
+}
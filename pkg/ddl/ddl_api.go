@@ -8995,5 +8995,8 @@ func NewDDLReorgMeta(ctx sessionctx.Context) *model.DDLReorgMeta {
 		Location:          &model.TimeZoneLocation{Name: tzName, Offset: tzOffset},
 		ResourceGroupName: ctx.GetSessionVars().ResourceGroupName,
 		Version:           model.CurrentReorgMetaVersion,
+		ReplicaRead:       variable.DDLReorgReplicaRead.Load(),
+		MaxStaleness:      variable.DDLReorgMaxStaleness.Load(),
+		MaxScanSpeed:      ctx.GetSessionVars().DDLReorgMaxScanSpeed,
 	}
 }
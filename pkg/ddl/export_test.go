@@ -21,6 +21,7 @@ import (
 	"github.com/pingcap/tidb/pkg/ddl/copr"
 	"github.com/pingcap/tidb/pkg/ddl/internal/session"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/table"
@@ -66,3 +67,21 @@ func ConvertRowToHandleAndIndexDatum(
 	handle, err := buildHandle(handleData, c.TableInfo, c.PrimaryKeyInfo, stmtctx.NewStmtCtxWithTimeZone(time.Local))
 	return handle, idxData, err
 }
+
+// GetRegionBatch4Test is only used for test.
+func GetRegionBatch4Test(regionCnt, nodeCnt int) int {
+	return getRegionBatch(regionCnt, nodeCnt)
+}
+
+// GetRangeSplitterSizeKeys4Test is only used for test.
+func GetRangeSplitterSizeKeys4Test(
+	totalSize, instanceCnt, regionSplitSize, regionSplitKeys int64,
+) (rangeGroupSize, rangeGroupKeys, maxSizePerRange, maxKeysPerRange int64) {
+	return getRangeSplitterSizeKeys(totalSize, instanceCnt, regionSplitSize, regionSplitKeys)
+}
+
+// HandleIngestDupKeyErr is only used for test.
+func HandleIngestDupKeyErr(ctx context.Context, sessPool *session.Pool, job *model.Job, err error,
+	indexes []*model.IndexInfo, tblInfo *model.TableInfo) error {
+	return handleIngestDupKeyErr(ctx, sessPool, job, err, indexes, tblInfo)
+}
@@ -31,7 +31,6 @@ import (
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
-	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl/copr"
 	"github.com/pingcap/tidb/pkg/ddl/ingest"
@@ -984,9 +983,7 @@ func runIngestReorgJob(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job,
 	for _, indexInfo := range allIndexInfos {
 		err = bc.FinishImport(indexInfo.ID, indexInfo.Unique, tbl)
 		if err != nil {
-			if common.ErrFoundDuplicateKeys.Equal(err) {
-				err = convertToKeyExistsErr(err, indexInfo, tbl.Meta())
-			}
+			err = handleIngestDupKeyErr(ctx, w.sessPool, job, err, []*model.IndexInfo{indexInfo}, tbl.Meta())
 			if kv.ErrKeyExists.Equal(err) {
 				logutil.BgLogger().Warn("import index duplicate key, convert job to rollback", zap.String("category", "ddl"), zap.String("job", job.String()), zap.Error(err))
 				ver, err = convertAddIdxJob2RollbackJob(d, t, job, tbl.Meta(), allIndexInfos, err)
@@ -1084,10 +1081,7 @@ func runReorgJobAndHandleErr(w *worker, d *ddlCtx, t *meta.Meta, job *model.Job,
 			// if timeout, we should return, check for the owner and re-wait job done.
 			return false, ver, nil
 		}
-		if common.ErrFoundDuplicateKeys.Equal(err) {
-			// TODO(tangenta): get duplicate column and match index.
-			err = convertToKeyExistsErr(err, allIndexInfos[0], tbl.Meta())
-		}
+		err = handleIngestDupKeyErr(w.ctx, w.sessPool, job, err, allIndexInfos, tbl.Meta())
 		if !errorIsRetryable(err, job) ||
 			// TODO: Remove this check make it can be retry. Related test is TestModifyColumnReorgInfo.
 			job.ReorgMeta.IsDistReorg {
@@ -1590,22 +1584,29 @@ func (w *addIndexTxnWorker) checkHandleExists(idxInfo *model.IndexInfo, key kv.K
 }
 
 func genKeyExistsErr(key, value []byte, idxInfo *model.IndexInfo, tblInfo *model.TableInfo) error {
-	idxColLen := len(idxInfo.Columns)
 	indexName := fmt.Sprintf("%s.%s", tblInfo.Name.String(), idxInfo.Name.String())
-	colInfos := tables.BuildRowcodecColInfoForIndexColumns(idxInfo, tblInfo)
-	values, err := tablecodec.DecodeIndexKV(key, value, idxColLen, tablecodec.HandleNotNeeded, colInfos)
+	valueStr, err := decodeIndexValuesStr(key, value, idxInfo, tblInfo)
 	if err != nil {
 		logutil.BgLogger().Warn("decode index key value failed", zap.String("index", indexName),
 			zap.String("key", hex.EncodeToString(key)), zap.String("value", hex.EncodeToString(value)), zap.Error(err))
 		return kv.ErrKeyExists.FastGenByArgs(key, indexName)
 	}
+	return kv.ErrKeyExists.FastGenByArgs(valueStr, indexName)
+}
+
+// decodeIndexValuesStr decodes the column values of the index KV, and joins them with "-".
+func decodeIndexValuesStr(key, value []byte, idxInfo *model.IndexInfo, tblInfo *model.TableInfo) (string, error) {
+	idxColLen := len(idxInfo.Columns)
+	colInfos := tables.BuildRowcodecColInfoForIndexColumns(idxInfo, tblInfo)
+	values, err := tablecodec.DecodeIndexKV(key, value, idxColLen, tablecodec.HandleNotNeeded, colInfos)
+	if err != nil {
+		return "", err
+	}
 	valueStr := make([]string, 0, idxColLen)
 	for i, val := range values[:idxColLen] {
 		d, err := tablecodec.DecodeColumnValue(val, colInfos[i].Ft, time.Local)
 		if err != nil {
-			logutil.BgLogger().Warn("decode column value failed", zap.String("index", indexName),
-				zap.String("key", hex.EncodeToString(key)), zap.String("value", hex.EncodeToString(value)), zap.Error(err))
-			return kv.ErrKeyExists.FastGenByArgs(key, indexName)
+			return "", err
 		}
 		str, err := d.ToString()
 		if err != nil {
@@ -1616,7 +1617,7 @@ func genKeyExistsErr(key, value []byte, idxInfo *model.IndexInfo, tblInfo *model
 		}
 		valueStr = append(valueStr, str)
 	}
-	return kv.ErrKeyExists.FastGenByArgs(strings.Join(valueStr, "-"), indexName)
+	return strings.Join(valueStr, "-"), nil
 }
 
 // batchCheckUniqueKey checks the unique keys in the batch.
@@ -2124,6 +2125,9 @@ func (w *worker) executeDistGlobalTask(reorgInfo *reorgInfo) error {
 			EleIDs:          elemIDs,
 			EleTypeKey:      reorgInfo.currElement.TypeKey,
 			CloudStorageURI: w.jobContext(job.ID, job.ReorgMeta).cloudStorageURI,
+			WorkerCnt:       int(variable.GetDDLReorgWorkerCounter()),
+			CompressData:    variable.DDLGlobalSortCompression.Load(),
+			MinVersion:      getBackfillMinVersion(),
 		}
 
 		metaData, err := json.Marshal(taskMeta)
@@ -2133,7 +2137,8 @@ func (w *worker) executeDistGlobalTask(reorgInfo *reorgInfo) error {
 
 		g.Go(func() error {
 			defer close(done)
-			err := handle.SubmitAndRunGlobalTask(ctx, taskKey, taskType, distPhysicalTableConcurrency, metaData)
+			err := handle.SubmitAndRunGlobalTask(ctx, taskKey, taskType, distPhysicalTableConcurrency,
+				getDistTaskPriority(job.Priority), metaData)
 			failpoint.Inject("pauseAfterDistTaskFinished", func() {
 				MockDMLExecutionOnTaskFinished()
 			})
@@ -2187,6 +2192,18 @@ func (w *worker) executeDistGlobalTask(reorgInfo *reorgInfo) error {
 	return err
 }
 
+// getDistTaskPriority maps the priority of the DDL job, which is set by tidb_ddl_reorg_priority,
+// to the priority of the distributed task.
+func getDistTaskPriority(jobPriority int) int {
+	switch jobPriority {
+	case kv.PriorityHigh:
+		return proto.HighPriority
+	case kv.PriorityLow:
+		return proto.LowPriority
+	}
+	return proto.NormalPriority
+}
+
 func (w *worker) updateJobRowCount(taskKey string, jobID int64) {
 	taskMgr, err := storage.GetTaskManager()
 	if err != nil {
@@ -2203,7 +2220,13 @@ func (w *worker) updateJobRowCount(taskKey string, jobID int64) {
 		logutil.BgLogger().Warn("cannot get subtask row count", zap.String("category", "ddl"), zap.String("task_key", taskKey), zap.Error(err))
 		return
 	}
-	w.getReorgCtx(jobID).setRowCount(rowCount)
+	rc := w.getReorgCtx(jobID)
+	rc.setRowCount(rowCount)
+	var taskMeta BackfillGlobalMeta
+	if err := json.Unmarshal(gTask.Meta, &taskMeta); err == nil {
+		rc.setProgress(taskMeta.Throughput, taskMeta.ETA)
+		rc.setGlobalSortStats(taskMeta.GlobalSortStats)
+	}
 }
 
 func getNextPartitionInfo(reorg *reorgInfo, t table.PartitionedTable, currPhysicalTableID int64) (int64, kv.Key, kv.Key, error) {
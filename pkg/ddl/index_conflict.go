@@ -0,0 +1,116 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ddl
+
+import (
+	"context"
+	"encoding/hex"
+	goerrors "errors"
+	"strings"
+
+	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
+	sess "github.com/pingcap/tidb/pkg/ddl/internal/session"
+	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
+	"github.com/pingcap/tidb/pkg/tablecodec"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/sqlexec"
+	"go.uber.org/zap"
+)
+
+// handleIngestDupKeyErr converts the duplicate key error found when ingesting the unique indexes to
+// kv.ErrKeyExists. If the conflicting values of the key are known, the conflicting rows are recorded
+// into mysql.tidb_ddl_index_conflicts, so users can find and fix them. The index of the duplicate key
+// is matched from the indexes by the key, indexes[0] is used if no one matches.
+func handleIngestDupKeyErr(
+	ctx context.Context,
+	sessPool *sess.Pool,
+	job *model.Job,
+	err error,
+	indexes []*model.IndexInfo,
+	tblInfo *model.TableInfo,
+) error {
+	if !common.ErrFoundDuplicateKeys.Equal(err) {
+		return err
+	}
+	var dupErr *common.DuplicateKeyError
+	if !goerrors.As(err, &dupErr) {
+		return convertToKeyExistsErr(err, indexes[0], tblInfo)
+	}
+	idxInfo := indexes[0]
+	physicalTableID, indexID, _, decodeErr := tablecodec.DecodeKeyHead(dupErr.Key)
+	if decodeErr == nil {
+		for _, idx := range indexes {
+			if idx.ID == indexID {
+				idxInfo = idx
+				break
+			}
+		}
+	}
+	if sessPool != nil {
+		if rErr := recordIndexConflicts(ctx, sessPool, job, physicalTableID, idxInfo, tblInfo, dupErr); rErr != nil {
+			logutil.Logger(ctx).Warn("record index conflicts failed", zap.String("category", "ddl"),
+				zap.Int64("jobID", job.ID), zap.String("index", idxInfo.Name.O), zap.Error(rErr))
+		}
+	}
+	return convertToKeyExistsErr(err, idxInfo, tblInfo)
+}
+
+// recordIndexConflicts inserts the rows conflicting on the key of the unique index into
+// mysql.tidb_ddl_index_conflicts.
+func recordIndexConflicts(
+	ctx context.Context,
+	sessPool *sess.Pool,
+	job *model.Job,
+	physicalTableID int64,
+	idxInfo *model.IndexInfo,
+	tblInfo *model.TableInfo,
+	dupErr *common.DuplicateKeyError,
+) error {
+	var sql strings.Builder
+	sql.WriteString("insert into mysql.tidb_ddl_index_conflicts (job_id, table_schema, table_name, physical_table_id, " +
+		"index_name, index_values, handle, raw_key, raw_value) values ")
+	for i, val := range dupErr.Values {
+		idxValues, err := decodeIndexValuesStr(dupErr.Key, val, idxInfo, tblInfo)
+		if err != nil {
+			idxValues = hex.EncodeToString(dupErr.Key)
+		}
+		handleStr := hex.EncodeToString(val)
+		if h, err := tablecodec.DecodeIndexHandle(dupErr.Key, val, len(idxInfo.Columns)); err == nil {
+			handleStr = h.String()
+		}
+		if i > 0 {
+			sql.WriteString(", ")
+		}
+		sqlexec.MustFormatSQL(&sql, "(%?, %?, %?, %?, %?, %?, %?, %?, %?)", job.ID, job.SchemaName, tblInfo.Name.O,
+			physicalTableID, idxInfo.Name.O, idxValues, handleStr, dupErr.Key, val)
+	}
+
+	se, err := sessPool.Get()
+	if err != nil {
+		return errors.Trace(err)
+	}
+	defer sessPool.Put(se)
+	ctx = kv.WithInternalSourceType(ctx, kv.InternalTxnDDL)
+	_, err = sess.NewSession(se).Execute(ctx, sql.String(), "record_index_conflicts")
+	if err != nil {
+		return errors.Trace(err)
+	}
+	logutil.Logger(ctx).Warn("found conflicting rows of the unique index, see mysql.tidb_ddl_index_conflicts",
+		zap.String("category", "ddl"), zap.Int64("jobID", job.ID), zap.String("index", idxInfo.Name.O),
+		zap.Int("count", len(dupErr.Values)))
+	return nil
+}
@@ -46,8 +46,10 @@ import (
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/pingcap/tidb/pkg/util/timeutil"
 	"github.com/pingcap/tipb/go-tipb"
+	"github.com/tikv/client-go/v2/oracle"
 	kvutil "github.com/tikv/client-go/v2/util"
 	"go.uber.org/zap"
+	"golang.org/x/time/rate"
 )
 
 // copReadBatchSize is the batch size of coprocessor read.
@@ -262,16 +264,82 @@ func (c *copReqSenderPool) recycleChunk(chk *chunk.Chunk) {
 	c.srcChkPool <- chk
 }
 
+// staleReadOption is the bounded staleness of the table scans of the backfilling.
+type staleReadOption struct {
+	maxStaleness time.Duration
+	// minReadTS is the snapshot version of the reorganization. The table scans never read the
+	// snapshot older than it, the writes after it are merged from the temporary index later.
+	minReadTS uint64
+}
+
+type staleReadOptionKeyType int
+
+func (staleReadOptionKeyType) String() string {
+	return "backfill_stale_read_option"
+}
+
+const staleReadOptionKey staleReadOptionKeyType = 0
+
+type scanLimiterKeyType int
+
+func (scanLimiterKeyType) String() string {
+	return "backfill_scan_limiter"
+}
+
+// scanLimiterKey is the key of the limiter of the rows scanned per second, which is shared by
+// the table scan workers of the session context.
+const scanLimiterKey scanLimiterKeyType = 0
+
+// readTS returns the timestamp to read for the transaction starting at startTS, and whether it's stale.
+func (o *staleReadOption) readTS(startTS uint64) (uint64, bool) {
+	readTS := oracle.GoTimeToTS(oracle.GetTimeFromTS(startTS).Add(-o.maxStaleness))
+	readTS = max(readTS, o.minReadTS)
+	if readTS >= startTS {
+		return startTS, false
+	}
+	return readTS, true
+}
+
+// setReorgReadOption sets the replica read type, the staleness and the speed limit of the table scans
+// to the session context of the backfilling according to the reorg meta of the job.
+func setReorgReadOption(sessCtx sessionctx.Context, job *model.Job) {
+	switch job.ReorgMeta.ReplicaRead {
+	case "follower":
+		sessCtx.GetSessionVars().SetReplicaRead(kv.ReplicaReadFollower)
+	case "leader-and-follower":
+		sessCtx.GetSessionVars().SetReplicaRead(kv.ReplicaReadMixed)
+	case "closest-replicas":
+		sessCtx.GetSessionVars().SetReplicaRead(kv.ReplicaReadClosest)
+	}
+	if job.ReorgMeta.MaxScanSpeed > 0 {
+		limit := int(job.ReorgMeta.MaxScanSpeed)
+		sessCtx.SetValue(scanLimiterKey, rate.NewLimiter(rate.Limit(limit), limit))
+	}
+	// The snapshot version is unknown if it's 0, the table scans can't read a stale snapshot safely.
+	if job.ReorgMeta.MaxStaleness > 0 && job.SnapshotVer > 0 {
+		sessCtx.SetValue(staleReadOptionKey, &staleReadOption{
+			maxStaleness: time.Duration(job.ReorgMeta.MaxStaleness) * time.Second,
+			minReadTS:    job.SnapshotVer,
+		})
+	}
+}
+
 func buildTableScan(ctx context.Context, c *copr.CopContextBase, startTS uint64, start, end kv.Key) (distsql.SelectResult, error) {
 	dagPB, err := buildDAGPB(c.SessionContext, c.TableInfo, c.ColumnInfos)
 	if err != nil {
 		return nil, err
 	}
 
+	readTS, isStale := startTS, false
+	if opt, ok := c.SessionContext.Value(staleReadOptionKey).(*staleReadOption); ok {
+		readTS, isStale = opt.readTS(startTS)
+	}
+
 	var builder distsql.RequestBuilder
 	kvReq, err := builder.
 		SetDAGRequest(dagPB).
-		SetStartTS(startTS).
+		SetStartTS(readTS).
+		SetIsStaleness(isStale).
 		SetKeyRanges([]kv.KeyRange{{StartKey: start, EndKey: end}}).
 		SetKeepOrder(true).
 		SetFromSessionVars(c.SessionContext.GetSessionVars()).
@@ -300,12 +368,29 @@ func fetchTableScanResult(
 	if chk.NumRows() == 0 {
 		return true, nil
 	}
+	if limiter, ok := copCtx.SessionContext.Value(scanLimiterKey).(*rate.Limiter); ok {
+		if err := waitScanLimiter(ctx, limiter, chk.NumRows()); err != nil {
+			return false, errors.Trace(err)
+		}
+	}
 	err = table.FillVirtualColumnValue(
 		copCtx.VirtualColumnsFieldTypes, copCtx.VirtualColumnsOutputOffsets,
 		copCtx.ExprColumnInfos, copCtx.ColumnInfos, copCtx.SessionContext, chk)
 	return false, err
 }
 
+// waitScanLimiter blocks until n rows can be scanned, n may exceed the burst of the limiter.
+func waitScanLimiter(ctx context.Context, limiter *rate.Limiter, n int) error {
+	for n > 0 {
+		cnt := min(n, limiter.Burst())
+		if err := limiter.WaitN(ctx, cnt); err != nil {
+			return err
+		}
+		n -= cnt
+	}
+	return nil
+}
+
 func completeErr(err error, idxInfo *model.IndexInfo) error {
 	if expression.ErrInvalidJSONForFuncIndex.Equal(err) {
 		err = expression.ErrInvalidJSONForFuncIndex.GenWithStackByArgs(idxInfo.Name.O)
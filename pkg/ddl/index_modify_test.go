@@ -19,15 +19,19 @@ import (
 	"fmt"
 	"math"
 	"math/rand"
+	"slices"
 	"strconv"
 	"strings"
 	"sync/atomic"
 	"testing"
 	"time"
 
+	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
+	"github.com/pingcap/tidb/br/pkg/lightning/common"
 	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/ddl"
+	"github.com/pingcap/tidb/pkg/ddl/internal/session"
 	testddlutil "github.com/pingcap/tidb/pkg/ddl/testutil"
 	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/kv"
@@ -1041,3 +1045,59 @@ func TestAddIndexUniqueFailOnDuplicate(t *testing.T) {
 	require.Less(t, int(ddl.ResultCounterForTest.Load()), 6)
 	ddl.ResultCounterForTest = nil
 }
+
+func TestHandleIngestDupKeyErr(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test")
+	tk.MustExec("create table t (a int primary key, b varchar(10), c int, unique key idx_b(b), key idx_c(c));")
+	tbl := external.GetTableByName(t, tk, "test", "t")
+	idxB, idxC := tbl.Meta().FindIndexByName("idx_b"), tbl.Meta().FindIndexByName("idx_c")
+	getIndexKV := func() (key, val []byte) {
+		txn, err := store.Begin()
+		require.NoError(t, err)
+		defer func() {
+			require.NoError(t, txn.Rollback())
+		}()
+		prefix := tablecodec.EncodeTableIndexPrefix(tbl.Meta().ID, idxB.ID)
+		it, err := txn.Iter(prefix, prefix.PrefixNext())
+		require.NoError(t, err)
+		defer it.Close()
+		require.True(t, it.Valid())
+		return it.Key().Clone(), slices.Clone(it.Value())
+	}
+	// Mock the same key of the unique index is written by the rows (1, 'x') and (2, 'x').
+	tk.MustExec("insert into t values (1, 'x', 1);")
+	key, val1 := getIndexKV()
+	tk.MustExec("delete from t;")
+	tk.MustExec("insert into t values (2, 'x', 2);")
+	key2, val2 := getIndexKV()
+	require.Equal(t, key, key2)
+
+	rs := pools.NewResourcePool(func() (pools.Resource, error) {
+		newTk := testkit.NewTestKit(t, store)
+		return newTk.Session(), nil
+	}, 1, 1, 0)
+	defer rs.Close()
+	sessPool := session.NewSessionPool(rs, store)
+	job := &model.Job{ID: 100, SchemaName: "test"}
+	ctx := context.Background()
+	indexes := []*model.IndexInfo{idxC, idxB}
+
+	// The other errors are returned as they are.
+	otherErr := errors.New("mock error")
+	require.Equal(t, otherErr, ddl.HandleIngestDupKeyErr(ctx, sessPool, job, otherErr, indexes, tbl.Meta()))
+
+	// The index of the key is idx_b, the conflicting rows are recorded.
+	dupErr := errors.Trace(common.NewDuplicateKeyError(key, val1, val2))
+	err := ddl.HandleIngestDupKeyErr(ctx, sessPool, job, dupErr, indexes, tbl.Meta())
+	require.True(t, kv.ErrKeyExists.Equal(err))
+	require.ErrorContains(t, err, "Duplicate entry 'x' for key 't.idx_b'")
+	tk.MustQuery("select job_id, table_schema, table_name, physical_table_id = ?, index_name, index_values, handle "+
+		"from mysql.tidb_ddl_index_conflicts order by handle", tbl.Meta().ID).Check(testkit.Rows(
+		"100 test t 1 idx_b x 1",
+		"100 test t 1 idx_b x 2",
+	))
+	tk.MustQuery("select count(*) from mysql.tidb_ddl_index_conflicts where raw_key = ? and raw_value = ?",
+		[]byte(key), val2).Check(testkit.Rows("1"))
+}
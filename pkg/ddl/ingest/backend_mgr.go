@@ -25,8 +25,10 @@ import (
 	"github.com/pingcap/tidb/br/pkg/lightning/config"
 	"github.com/pingcap/tidb/pkg/ddl/util"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/util/generic"
 	"github.com/pingcap/tidb/pkg/util/logutil"
+	"github.com/pingcap/tidb/pkg/util/size"
 	kvutil "github.com/tikv/client-go/v2/util"
 	clientv3 "go.etcd.io/etcd/client/v3"
 	"go.uber.org/zap"
@@ -135,6 +137,10 @@ func createLocalBackend(ctx context.Context, cfg *Config, resourceGroupName stri
 		raftKV2SwitchModeDuration = config.DefaultSwitchTiKVModeInterval
 	}
 	backendConfig := local.NewBackendConfig(cfg.Lightning, int(LitRLimit), cfg.KeyspaceName, resourceGroupName, kvutil.ExplicitTypeDDL, raftKV2SwitchModeDuration)
+	// Only one job uses ingest at the same time, so the limit of the backend is the limit of the node.
+	backendConfig.GetNodeWriteBWLimit = func() int {
+		return int(variable.DDLReorgMaxWriteSpeed.Load() * int64(size.MB))
+	}
 	return local.NewBackend(ctx, tls, backendConfig, regionSizeGetter)
 }
 
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/ddl"
@@ -309,3 +310,75 @@ func TestAddIndexIngestMultiSchemaChange(t *testing.T) {
 	tk.MustExec("alter table t add index idx_a(a), add index idx_ab(a, b), add index idx_d(d);")
 	tk.MustExec("admin check table t;")
 }
+
+func TestAddIndexIngestExpressionIndexDist(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test;")
+	defer ingesttestutil.InjectMockBackendMgr(t, store)()
+	tk.MustExec("set @@global.tidb_enable_dist_task = 1;")
+	defer tk.MustExec("set @@global.tidb_enable_dist_task = 0;")
+
+	// The expressions are evaluated per row by the read index step of the distributed task.
+	tk.MustExec(`create table t (a int, b varchar(20), c json, d int as (a*3) virtual,
+		pk varchar(10) primary key clustered) partition by key(pk) partitions 3;`)
+	tk.MustExec(`insert into t(a, b, c, pk) values (1, 'Abc', '[1,2]', 'x'), (2, 'dEf', '[3]', 'y'), (3, null, null, 'z');`)
+	tk.MustExec("alter table t add index idx_d((d+1));")
+	tk.MustExec("alter table t add index idx_b((lower(b)));")
+	tk.MustExec("alter table t add index idx_c((cast(c as signed array)));")
+	tk.MustExec("alter table t add index idx_ab(a, (upper(b))), add unique index idx_a(pk, (a*2));")
+	tk.MustExec("admin check table t;")
+	rows := tk.MustQuery("admin show ddl jobs 5;").Rows()
+	for _, r := range rows {
+		jobTp := r[3].(string)
+		if strings.HasPrefix(jobTp, "add index") {
+			require.Contains(t, jobTp, "ingest")
+		}
+	}
+
+	tk.MustExec("alter table t drop index idx_c;")
+	tk.MustExec("insert into t(a, c, pk) values (4, '[\"abc\"]', 'w');")
+	tk.MustGetErrCode("alter table t add index idx_c((cast(c as signed array)));", errno.ErrInvalidJSONValueForFuncIndex)
+}
+
+func TestAddIndexIngestReplicaReadAndStaleness(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test;")
+	defer ingesttestutil.InjectMockBackendMgr(t, store)()
+
+	tk.MustExec("create table t (a int primary key, b int);")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3);")
+	tk.MustExec("set global tidb_ddl_reorg_replica_read = 'leader-and-follower';")
+	tk.MustExec("set global tidb_ddl_reorg_max_staleness = 5;")
+	defer func() {
+		tk.MustExec("set global tidb_ddl_reorg_replica_read = default;")
+		tk.MustExec("set global tidb_ddl_reorg_max_staleness = default;")
+	}()
+	// The rows written before the reorganization are read even if the snapshot is stale.
+	tk.MustExec("alter table t add index idx(b);")
+	tk.MustExec("admin check table t;")
+	tk.MustQuery("select b from t use index(idx) order by b;").Check(testkit.Rows("1", "2", "3"))
+	rows := tk.MustQuery("admin show ddl jobs 1;").Rows()
+	//nolint: forcetypeassert
+	require.Contains(t, rows[0][3].(string), "ingest")
+}
+
+func TestAddIndexIngestMaxScanSpeed(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("use test;")
+	defer ingesttestutil.InjectMockBackendMgr(t, store)()
+
+	tk.MustExec("create table t (a int primary key, b int);")
+	tk.MustExec("insert into t values (1, 1), (2, 2), (3, 3), (4, 4);")
+	tk.MustExec("set @@tidb_ddl_reorg_max_scan_speed = 2;")
+	start := time.Now()
+	tk.MustExec("alter table t add index idx(b);")
+	// The burst is 2 rows, the other 2 rows wait for 1 second.
+	require.GreaterOrEqual(t, time.Since(start), 900*time.Millisecond)
+	tk.MustExec("admin check table t;")
+	rows := tk.MustQuery("admin show ddl jobs 1;").Rows()
+	//nolint: forcetypeassert
+	require.Contains(t, rows[0][3].(string), "ingest")
+}
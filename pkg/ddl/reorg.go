@@ -15,6 +15,7 @@
 package ddl
 
 import (
+	"context"
 	"encoding/hex"
 	"fmt"
 	"strconv"
@@ -69,6 +70,11 @@ type reorgCtx struct {
 		// warnings are used to store the warnings when doing the reorg job under certain SQL modes.
 		warnings      map[errors.ErrorID]*terror.Error
 		warningsCount map[errors.ErrorID]int64
+		// throughput and eta are the progress of the distributed reorganization estimated by the dispatcher.
+		throughput float64
+		eta        int64
+		// globalSortStats is the statistics of the global sort collected by the dispatcher.
+		globalSortStats *model.GlobalSortStats
 	}
 
 	references atomicutil.Int32
@@ -125,6 +131,30 @@ func (rc *reorgCtx) resetWarnings() {
 	rc.mu.warningsCount = make(map[errors.ErrorID]int64)
 }
 
+func (rc *reorgCtx) setProgress(throughput float64, eta int64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.mu.throughput, rc.mu.eta = throughput, eta
+}
+
+func (rc *reorgCtx) getProgress() (throughput float64, eta int64) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.mu.throughput, rc.mu.eta
+}
+
+func (rc *reorgCtx) setGlobalSortStats(stats *model.GlobalSortStats) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.mu.globalSortStats = stats
+}
+
+func (rc *reorgCtx) getGlobalSortStats() *model.GlobalSortStats {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	return rc.mu.globalSortStats
+}
+
 func (rc *reorgCtx) increaseRowCount(count int64) {
 	atomic.AddInt64(&rc.rowCount, count)
 }
@@ -228,6 +258,13 @@ func (w *worker) runReorgJob(reorgInfo *reorgInfo, tblInfo *model.TableInfo,
 		}
 		rowCount := rc.getRowCount()
 		job.SetRowCount(rowCount)
+		if job.ReorgMeta != nil {
+			// The estimated progress is meaningless after the reorganization is done.
+			job.ReorgMeta.Throughput, job.ReorgMeta.ETA = 0, 0
+			if stats := rc.getGlobalSortStats(); stats != nil {
+				job.ReorgMeta.GlobalSortStats = stats
+			}
+		}
 		if err != nil {
 			logutil.BgLogger().Warn("run reorg job done", zap.String("category", "ddl"), zap.Int64("handled rows", rowCount), zap.Error(err))
 		} else {
@@ -254,6 +291,12 @@ func (w *worker) runReorgJob(reorgInfo *reorgInfo, tblInfo *model.TableInfo,
 	case <-time.After(waitTimeout):
 		rowCount := rc.getRowCount()
 		job.SetRowCount(rowCount)
+		if job.ReorgMeta != nil {
+			job.ReorgMeta.Throughput, job.ReorgMeta.ETA = rc.getProgress()
+			if stats := rc.getGlobalSortStats(); stats != nil {
+				job.ReorgMeta.GlobalSortStats = stats
+			}
+		}
 		updateBackfillProgress(w, reorgInfo, tblInfo, rowCount)
 
 		// Update a job's warnings.
@@ -328,7 +371,7 @@ func updateBackfillProgress(w *worker, reorgInfo *reorgInfo, tblInfo *model.Tabl
 	}
 	progress := float64(0)
 	if addedRowCount != 0 {
-		totalCount := getTableTotalCount(w, tblInfo)
+		totalCount := getTableTotalCount(w.ctx, w.sessPool, tblInfo)
 		if totalCount > 0 {
 			progress = float64(addedRowCount) / float64(totalCount)
 		} else {
@@ -359,15 +402,14 @@ func updateBackfillProgress(w *worker, reorgInfo *reorgInfo, tblInfo *model.Tabl
 	}
 }
 
-func getTableTotalCount(w *worker, tblInfo *model.TableInfo) int64 {
-	var ctx sessionctx.Context
-	ctx, err := w.sessPool.Get()
+func getTableTotalCount(ctx context.Context, sessPool *sess.Pool, tblInfo *model.TableInfo) int64 {
+	se, err := sessPool.Get()
 	if err != nil {
 		return statistics.PseudoRowCount
 	}
-	defer w.sessPool.Put(ctx)
+	defer sessPool.Put(se)
 
-	executor, ok := ctx.(sqlexec.RestrictedSQLExecutor)
+	executor, ok := se.(sqlexec.RestrictedSQLExecutor)
 	// `mock.Context` is used in tests, which doesn't implement RestrictedSQLExecutor
 	if !ok {
 		return statistics.PseudoRowCount
@@ -381,10 +423,10 @@ func getTableTotalCount(w *worker, tblInfo *model.TableInfo) int64 {
 			partIDs = append(partIDs, strconv.FormatInt(def.ID, 10))
 		}
 		sql := "select sum(table_rows) from information_schema.partitions where tidb_partition_id in (%?);"
-		rows, _, err = executor.ExecRestrictedSQL(w.ctx, nil, sql, strings.Join(partIDs, ","))
+		rows, _, err = executor.ExecRestrictedSQL(ctx, nil, sql, strings.Join(partIDs, ","))
 	} else {
 		sql := "select table_rows from information_schema.tables where tidb_table_id=%?;"
-		rows, _, err = executor.ExecRestrictedSQL(w.ctx, nil, sql, tblInfo.ID)
+		rows, _, err = executor.ExecRestrictedSQL(ctx, nil, sql, tblInfo.ID)
 	}
 	if err != nil {
 		return statistics.PseudoRowCount
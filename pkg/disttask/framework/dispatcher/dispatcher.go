@@ -26,6 +26,8 @@ import (
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/metrics"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	distroleutil "github.com/pingcap/tidb/pkg/util/distrole"
 	disttaskutil "github.com/pingcap/tidb/pkg/util/disttask"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -95,6 +97,8 @@ type BaseDispatcher struct {
 	liveNodeFetchTick int
 	// taskNodes stores the id of current scheduler nodes.
 	taskNodes []string
+	// fencedNodes are the nodes excluded from the task since their subtasks got stuck.
+	fencedNodes map[string]struct{}
 	// rand is for generating random selection of nodes.
 	rand *rand.Rand
 }
@@ -323,6 +327,15 @@ func (d *BaseDispatcher) onPending() error {
 // If subtasks finished, run into the next stage.
 func (d *BaseDispatcher) onRunning() error {
 	logutil.Logger(d.logCtx).Debug("on running state", zap.Stringer("state", d.Task.State), zap.Int64("stage", int64(d.Task.Step)))
+	// The failed speculative subtasks are discarded before collecting the errors.
+	if err := d.speculateStragglers(); err != nil {
+		logutil.Logger(d.logCtx).Warn("speculate straggler subtasks failed", zap.Error(err))
+		return err
+	}
+	if err := d.rescheduleStuckSubtasks(); err != nil {
+		logutil.Logger(d.logCtx).Warn("reschedule stuck subtasks failed", zap.Error(err))
+		return err
+	}
 	subTaskErrs, err := d.taskMgr.CollectSubTaskError(d.Task.ID)
 	if err != nil {
 		logutil.Logger(d.logCtx).Warn("collect subtask error failed", zap.Error(err))
@@ -330,6 +343,9 @@ func (d *BaseDispatcher) onRunning() error {
 	}
 	if len(subTaskErrs) > 0 {
 		logutil.Logger(d.logCtx).Warn("subtasks encounter errors")
+		if replanned, err := d.replanOnErr(subTaskErrs); err != nil || replanned {
+			return err
+		}
 		return d.onErrHandlingStage(subTaskErrs)
 	}
 	// check current stage finished.
@@ -340,6 +356,11 @@ func (d *BaseDispatcher) onRunning() error {
 	}
 
 	if cnt == 0 {
+		// A subtask and its speculative copy might both succeed after the last check,
+		// make sure only one of them is taken.
+		if err := d.speculateStragglers(); err != nil {
+			return err
+		}
 		return d.onNextStage()
 	}
 	// Check if any node are down.
@@ -369,6 +390,14 @@ func (d *BaseDispatcher) replaceDeadNodesIfAny() error {
 	d.liveNodeFetchTick++
 	if d.liveNodeFetchTick == d.liveNodeFetchInterval {
 		d.liveNodeFetchTick = 0
+		// The subtasks might be moved to other nodes by the extension, such as rebalancing.
+		taskNodes, err := d.taskMgr.GetSchedulerIDsByTaskIDAndStep(d.Task.ID, d.Task.Step)
+		if err != nil {
+			return err
+		}
+		if len(taskNodes) > 0 {
+			d.taskNodes = taskNodes
+		}
 		serverInfos, err := GenerateSchedulerNodes(d.ctx)
 		if err != nil {
 			return err
@@ -392,14 +421,19 @@ func (d *BaseDispatcher) replaceDeadNodesIfAny() error {
 		}
 		d.liveNodes = newInfos
 	}
-	if len(d.liveNodes) > 0 {
+	// The subtasks of the fenced nodes are moved to the other nodes too.
+	liveNodes := d.filterFencedNodes(d.liveNodes)
+	if len(liveNodes) > 0 {
 		replaceNodes := make(map[string]string)
 		cleanNodes := make([]string, 0)
 		for _, nodeID := range d.taskNodes {
-			if ok := disttaskutil.MatchServerInfo(d.liveNodes, nodeID); !ok {
-				n := d.liveNodes[d.rand.Int()%len(d.liveNodes)] //nolint:gosec
+			if ok := disttaskutil.MatchServerInfo(liveNodes, nodeID); !ok {
+				n := liveNodes[d.rand.Int()%len(liveNodes)] //nolint:gosec
 				replaceNodes[nodeID] = disttaskutil.GenerateExecID(n.IP, n.Port)
-				cleanNodes = append(cleanNodes, nodeID)
+				// The fenced node is still alive.
+				if !d.isFenced(nodeID) {
+					cleanNodes = append(cleanNodes, nodeID)
+				}
 			}
 		}
 		if len(replaceNodes) > 0 {
@@ -587,6 +621,7 @@ func (d *BaseDispatcher) dispatchSubTask(subtaskStep proto.Step, metas [][]byte)
 	if err != nil {
 		return err
 	}
+	serverNodes = d.filterFencedNodes(serverNodes)
 
 	logutil.Logger(d.logCtx).Info("eligible instances", zap.Int("num", len(serverNodes)))
 
@@ -639,7 +674,24 @@ func GenerateSchedulerNodes(ctx context.Context) (serverNodes []*infosync.Server
 	for _, serverInfo := range serverInfos {
 		serverNodes = append(serverNodes, serverInfo)
 	}
-	return serverNodes, nil
+	return filterByLabels(serverNodes)
+}
+
+// filterByLabels keeps the nodes carrying all the labels in tidb_dist_task_node_labels.
+func filterByLabels(serverNodes []*infosync.ServerInfo) ([]*infosync.ServerInfo, error) {
+	labelsStr := variable.DistTaskNodeLabels.Load()
+	labels, err := distroleutil.ParseNodeLabels(labelsStr)
+	if err != nil {
+		return nil, err
+	}
+	if len(labels) == 0 {
+		return serverNodes, nil
+	}
+	res := disttaskutil.FilterServerInfosByLabels(serverNodes, labels)
+	if len(res) == 0 {
+		return nil, errors.Errorf("not found instance with labels %s", labelsStr)
+	}
+	return res, nil
 }
 
 func (d *BaseDispatcher) filterByRole(infos []*infosync.ServerInfo) ([]*infosync.ServerInfo, error) {
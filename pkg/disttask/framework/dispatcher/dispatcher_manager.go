@@ -153,66 +153,75 @@ func (dm *Manager) dispatchTaskLoop() {
 	logutil.BgLogger().Info("dispatch task loop start")
 	ticker := time.NewTicker(checkTaskRunningInterval)
 	defer ticker.Stop()
+	// The node may just become the owner, take over the running tasks at once instead of waiting for the ticker.
+	dm.dispatchTasks()
 	for {
 		select {
 		case <-dm.ctx.Done():
 			logutil.BgLogger().Info("dispatch task loop exits", zap.Error(dm.ctx.Err()), zap.Int64("interval", int64(checkTaskRunningInterval)/1000000))
 			return
 		case <-ticker.C:
-			cnt := dm.getRunningTaskCnt()
-			if dm.checkConcurrencyOverflow(cnt) {
-				break
-			}
+			dm.dispatchTasks()
+		}
+	}
+}
 
-			// TODO: Consider getting these tasks, in addition to the task being worked on..
-			tasks, err := dm.taskMgr.GetGlobalTasksInStates(
-				proto.TaskStatePending,
-				proto.TaskStateRunning,
-				proto.TaskStateReverting,
-				proto.TaskStateCancelling,
-				proto.TaskStateResuming,
-			)
-			if err != nil {
-				logutil.BgLogger().Warn("get unfinished(pending, running, reverting, cancelling, resuming) tasks failed", zap.Error(err))
-				break
-			}
+// dispatchTasks starts the dispatchers of the unfinished global tasks which are not dispatched yet.
+func (dm *Manager) dispatchTasks() {
+	cnt := dm.getRunningTaskCnt()
+	if dm.checkConcurrencyOverflow(cnt) {
+		return
+	}
 
-			// There are currently no global tasks to work on.
-			if len(tasks) == 0 {
-				break
-			}
-			for _, task := range tasks {
-				// This global task is running, so no need to reprocess it.
-				if dm.isRunningTask(task.ID) {
-					continue
-				}
-				metrics.DistTaskGauge.WithLabelValues(task.Type.String(), metrics.DispatchingStatus).Inc()
-				// we check it before start dispatcher, so no need to check it again.
-				// see startDispatcher.
-				// this should not happen normally, unless user modify system table
-				// directly.
-				if getDispatcherFactory(task.Type) == nil {
-					logutil.BgLogger().Warn("unknown task type", zap.Int64("task-id", task.ID),
-						zap.Stringer("task-type", task.Type))
-					dm.failTask(task, errors.New("unknown task type"))
-					continue
-				}
-				// the task is not in runningTasks set when:
-				// owner changed or task is cancelled when status is pending.
-				if task.State == proto.TaskStateRunning || task.State == proto.TaskStateReverting || task.State == proto.TaskStateCancelling {
-					metrics.UpdateMetricsForDispatchTask(task)
-					dm.startDispatcher(task)
-					cnt++
-					continue
-				}
-				if dm.checkConcurrencyOverflow(cnt) {
-					break
-				}
-				metrics.UpdateMetricsForDispatchTask(task)
-				dm.startDispatcher(task)
-				cnt++
-			}
+	// TODO: Consider getting these tasks, in addition to the task being worked on..
+	// The tasks are ordered by priority, so the pending tasks of higher priority take
+	// the dispatching slots first.
+	tasks, err := dm.taskMgr.GetGlobalTasksInStates(
+		proto.TaskStatePending,
+		proto.TaskStateRunning,
+		proto.TaskStateReverting,
+		proto.TaskStateCancelling,
+		proto.TaskStateResuming,
+	)
+	if err != nil {
+		logutil.BgLogger().Warn("get unfinished(pending, running, reverting, cancelling, resuming) tasks failed", zap.Error(err))
+		return
+	}
+
+	// There are currently no global tasks to work on.
+	if len(tasks) == 0 {
+		return
+	}
+	for _, task := range tasks {
+		// This global task is running, so no need to reprocess it.
+		if dm.isRunningTask(task.ID) {
+			continue
+		}
+		metrics.DistTaskGauge.WithLabelValues(task.Type.String(), metrics.DispatchingStatus).Inc()
+		// we check it before start dispatcher, so no need to check it again.
+		// see startDispatcher.
+		// this should not happen normally, unless user modify system table
+		// directly.
+		if getDispatcherFactory(task.Type) == nil {
+			logutil.BgLogger().Warn("unknown task type", zap.Int64("task-id", task.ID),
+				zap.Stringer("task-type", task.Type))
+			dm.failTask(task, errors.New("unknown task type"))
+			continue
+		}
+		// the task is not in runningTasks set when:
+		// owner changed or task is cancelled when status is pending.
+		if task.State == proto.TaskStateRunning || task.State == proto.TaskStateReverting || task.State == proto.TaskStateCancelling {
+			metrics.UpdateMetricsForDispatchTask(task)
+			dm.startDispatcher(task)
+			cnt++
+			continue
+		}
+		if dm.checkConcurrencyOverflow(cnt) {
+			break
 		}
+		metrics.UpdateMetricsForDispatchTask(task)
+		dm.startDispatcher(task)
+		cnt++
 	}
 }
 
@@ -24,12 +24,14 @@ import (
 	"github.com/ngaut/pools"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
+	"github.com/pingcap/tidb/pkg/config"
 	"github.com/pingcap/tidb/pkg/disttask/framework/dispatcher"
 	"github.com/pingcap/tidb/pkg/disttask/framework/mock"
 	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
 	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
 	"github.com/pingcap/tidb/pkg/util/logutil"
 	"github.com/stretchr/testify/require"
@@ -216,6 +218,27 @@ func TestGetInstance(t *testing.T) {
 	require.ElementsMatch(t, instanceIDs, serverIDs)
 }
 
+func TestGenerateSchedulerNodesWithLabels(t *testing.T) {
+	defer config.RestoreFunc()()
+	config.UpdateGlobal(func(conf *config.Config) {
+		conf.Labels = map[string]string{"zone": "z1", "role": "ddl"}
+	})
+	_ = testkit.CreateMockStore(t)
+	ctx := context.Background()
+	defer variable.DistTaskNodeLabels.Store("")
+
+	serverNodes, err := dispatcher.GenerateSchedulerNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, serverNodes, 1)
+	variable.DistTaskNodeLabels.Store("zone=z1,role=ddl")
+	serverNodes, err = dispatcher.GenerateSchedulerNodes(ctx)
+	require.NoError(t, err)
+	require.Len(t, serverNodes, 1)
+	variable.DistTaskNodeLabels.Store("zone=z2")
+	_, err = dispatcher.GenerateSchedulerNodes(ctx)
+	require.ErrorContains(t, err, "not found instance with labels zone=z2")
+}
+
 func TestTaskFailInManager(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	gtk := testkit.NewTestKit(t, store)
@@ -43,6 +43,10 @@ type TaskManager interface {
 	GetSchedulerIDsByTaskID(taskID int64) ([]string, error)
 	GetSucceedSubtasksByStep(taskID int64, step proto.Step) ([]*proto.Subtask, error)
 	GetSchedulerIDsByTaskIDAndStep(taskID int64, step proto.Step) ([]string, error)
+	GetSubtasksByStep(taskID int64, step proto.Step) ([]*proto.Subtask, error)
+	AddSpeculativeSubtask(subtaskID int64, execID string) (bool, error)
+	DiscardSubtask(subtaskID int64) error
+	RescheduleSubtask(subtaskID int64, execID string) (bool, error)
 
 	WithNewSession(fn func(se sessionctx.Context) error) error
 	WithNewTxn(ctx context.Context, fn func(se sessionctx.Context) error) error
@@ -84,6 +88,25 @@ type Extension interface {
 	GetNextStep(task *proto.Task) proto.Step
 }
 
+// SpeculativeExtension is an optional interface of Extension. If it's implemented, the straggler
+// subtasks of the step, which run much slower than the others, are run again on other idle nodes,
+// and whichever finishes first is taken.
+type SpeculativeExtension interface {
+	// IsSpeculativeStep returns whether the subtasks of the current step of the task can run
+	// speculatively, i.e. a subtask and its copy can run at the same time, and the output of
+	// the one which doesn't finish first can be discarded.
+	IsSpeculativeStep(task *proto.Task) bool
+}
+
+// ReplanExtension is an optional interface of Extension. If it's implemented, the failed step of
+// the task can be planned again in another way, instead of reverting the task.
+type ReplanExtension interface {
+	// ReplanOnErr is called when the subtasks of the current step fail with receiveErrs, before
+	// OnErrStage. If it returns true, all the subtasks of the step are replaced by the ones of
+	// subtaskMetas, and the changes of task.Meta are persisted together.
+	ReplanOnErr(ctx context.Context, h TaskHandle, task *proto.Task, receiveErrs []error) (subtaskMetas [][]byte, replanned bool, err error)
+}
+
 // dispatcherFactoryFn is used to create a dispatcher.
 type dispatcherFactoryFn func(ctx context.Context, taskMgr TaskManager, serverID string, task *proto.Task) Dispatcher
 
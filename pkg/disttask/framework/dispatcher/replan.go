@@ -0,0 +1,59 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// replanOnErr plans the current step again instead of reverting the task, if the extension can
+// recover the task from the subtask errors. The subtasks of the step are replaced by the new ones.
+// It returns whether the step is planned again.
+func (d *BaseDispatcher) replanOnErr(receiveErrs []error) (bool, error) {
+	ext, ok := d.Extension.(ReplanExtension)
+	if !ok {
+		return false, nil
+	}
+	subtasks, err := d.taskMgr.GetSubtasksByStep(d.Task.ID, d.Task.Step)
+	if err != nil {
+		return false, err
+	}
+	metas, replanned, err := ext.ReplanOnErr(d.ctx, d, d.Task, receiveErrs)
+	if err != nil {
+		if d.IsRetryableErr(err) {
+			return false, err
+		}
+		logutil.Logger(d.logCtx).Warn("replan the step failed, revert the task", zap.Error(err))
+		return false, nil
+	}
+	if !replanned {
+		return false, nil
+	}
+	logutil.Logger(d.logCtx).Info("replan the step on subtask errors",
+		zap.Int64("step", int64(d.Task.Step)), zap.Int("old-subtasks", len(subtasks)), zap.Int("new-subtasks", len(metas)))
+	// The new subtasks are added before the old ones are discarded, so the step is never taken as finished
+	// if the dispatcher exits in between, the failed subtasks left revert the task then.
+	if err := d.dispatchSubTask(d.Task.Step, metas); err != nil {
+		return false, err
+	}
+	for _, subtask := range subtasks {
+		// The running subtasks are canceled by their schedulers once discarded.
+		if err := d.taskMgr.DiscardSubtask(subtask.ID); err != nil {
+			return true, err
+		}
+	}
+	return true, nil
+}
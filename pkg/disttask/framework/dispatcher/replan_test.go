@@ -0,0 +1,114 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/mock"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type replanExtension struct {
+	Extension
+	metas     [][]byte
+	replanned bool
+	err       error
+}
+
+func (e *replanExtension) ReplanOnErr(_ context.Context, _ TaskHandle, task *proto.Task, _ []error) ([][]byte, bool, error) {
+	if e.replanned {
+		task.Meta = []byte("replanned")
+	}
+	return e.metas, e.replanned, e.err
+}
+
+func (*replanExtension) GetEligibleInstances(context.Context, *proto.Task) ([]*infosync.ServerInfo, error) {
+	return []*infosync.ServerInfo{{IP: "10.0.0.1", Port: 4000}, {IP: "10.0.0.2", Port: 4000}}, nil
+}
+
+func (*replanExtension) IsRetryableErr(err error) bool {
+	return err.Error() == "retryable"
+}
+
+func TestReplanOnErr(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	task := &proto.Task{ID: 1, Step: proto.StepOne, State: proto.TaskStateRunning}
+	d := NewBaseDispatcher(context.Background(), taskMgr, ":4000", task)
+	receiveErrs := []error{errors.New("subtask error")}
+
+	// the extension doesn't support replanning.
+	replanned, err := d.replanOnErr(receiveErrs)
+	require.NoError(t, err)
+	require.False(t, replanned)
+
+	subtasks := []*proto.Subtask{
+		newTestSubtask(1, proto.TaskStateFailed, "10.0.0.1:4000", time.Time{}, time.Time{}),
+		newTestSubtask(2, proto.TaskStateRunning, "10.0.0.2:4000", time.Time{}, time.Time{}),
+	}
+	ext := &replanExtension{}
+	d.Extension = ext
+	// the extension can't recover the task from the errors.
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	replanned, err = d.replanOnErr(receiveErrs)
+	require.NoError(t, err)
+	require.False(t, replanned)
+	// the retryable error is returned to replan at the next tick, the other errors revert the task.
+	ext.err = errors.New("retryable")
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	_, err = d.replanOnErr(receiveErrs)
+	require.ErrorContains(t, err, "retryable")
+	ext.err = errors.New("other")
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	replanned, err = d.replanOnErr(receiveErrs)
+	require.NoError(t, err)
+	require.False(t, replanned)
+
+	// the subtasks are replaced by the new ones, together with the task meta.
+	ext.err = nil
+	ext.replanned = true
+	ext.metas = [][]byte{[]byte("a"), []byte("b"), []byte("c")}
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	taskMgr.EXPECT().GetNodesByRole("background").Return(nil, nil)
+	taskMgr.EXPECT().GetNodesByRole("").Return(map[string]bool{"10.0.0.1:4000": true, "10.0.0.2:4000": true}, nil)
+	var added []*proto.Subtask
+	taskMgr.EXPECT().UpdateGlobalTaskAndAddSubTasks(task, gomock.Any(), proto.TaskStateRunning).DoAndReturn(
+		func(gTask *proto.Task, subtasks []*proto.Subtask, _ proto.TaskState) (bool, error) {
+			require.Equal(t, []byte("replanned"), gTask.Meta)
+			added = subtasks
+			return true, nil
+		})
+	taskMgr.EXPECT().DiscardSubtask(int64(1)).Return(nil)
+	taskMgr.EXPECT().DiscardSubtask(int64(2)).Return(nil)
+	replanned, err = d.replanOnErr(receiveErrs)
+	require.NoError(t, err)
+	require.True(t, replanned)
+	require.Len(t, added, 3)
+	for i, subtask := range added {
+		require.Equal(t, proto.StepOne, subtask.Step)
+		require.Equal(t, ext.metas[i], subtask.Meta)
+	}
+	require.Equal(t, "10.0.0.1:4000", added[0].SchedulerID)
+	require.Equal(t, "10.0.0.2:4000", added[1].SchedulerID)
+	require.Equal(t, proto.TaskStateRunning, task.State)
+}
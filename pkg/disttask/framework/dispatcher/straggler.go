@@ -0,0 +1,211 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	disttaskutil "github.com/pingcap/tidb/pkg/util/disttask"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+var (
+	// stragglerFinishedRatio is the ratio of the subtasks of the step that must have succeeded
+	// before looking for the stragglers, so there are enough peers to compare with.
+	stragglerFinishedRatio = 0.75
+	// stragglerDurationFactor is how many times longer than the median duration of the succeeded
+	// subtasks a running subtask has run when it's considered as a straggler.
+	stragglerDurationFactor = 2.0
+	// stragglerMinDuration is the minimum running duration of a straggler, it's not worth running
+	// a short subtask again.
+	stragglerMinDuration = time.Minute
+)
+
+// speculateStragglers runs the straggler subtasks of the current step again on other idle nodes,
+// if the extension allows it. Of a subtask and its speculative copy, whichever succeeds first is
+// taken and the other is discarded, a failed one is also discarded while the other is still running.
+func (d *BaseDispatcher) speculateStragglers() error {
+	ext, ok := d.Extension.(SpeculativeExtension)
+	if !ok || !ext.IsSpeculativeStep(d.Task) {
+		return nil
+	}
+	subtasks, err := d.taskMgr.GetSubtasksByStep(d.Task.ID, d.Task.Step)
+	if err != nil {
+		return err
+	}
+	subtasks, err = d.discardSpeculativeLosers(subtasks)
+	if err != nil {
+		return err
+	}
+	stragglers := findStragglers(subtasks, time.Now())
+	if len(stragglers) == 0 {
+		return nil
+	}
+	idleNodes := d.getIdleNodes(subtasks)
+	for _, straggler := range stragglers {
+		candidates := make([]string, 0, len(idleNodes))
+		for _, node := range idleNodes {
+			if node != straggler.SchedulerID {
+				candidates = append(candidates, node)
+			}
+		}
+		if len(candidates) == 0 {
+			break
+		}
+		execID := candidates[d.rand.Intn(len(candidates))] //nolint:gosec
+		added, err := d.taskMgr.AddSpeculativeSubtask(straggler.ID, execID)
+		if err != nil {
+			return err
+		}
+		if !added {
+			continue
+		}
+		logutil.Logger(d.logCtx).Info("run straggler subtask speculatively",
+			zap.Int64("subtask-id", straggler.ID),
+			zap.String("origin-node", straggler.SchedulerID),
+			zap.String("speculative-node", execID),
+			zap.Duration("running-time", time.Since(straggler.StartTime)))
+		idleNodes = slices.DeleteFunc(idleNodes, func(node string) bool { return node == execID })
+	}
+	return nil
+}
+
+// discardSpeculativeLosers discards the losers of the subtasks and their speculative copies,
+// and returns the remaining subtasks.
+func (d *BaseDispatcher) discardSpeculativeLosers(subtasks []*proto.Subtask) ([]*proto.Subtask, error) {
+	byID := make(map[int64]*proto.Subtask, len(subtasks))
+	for _, st := range subtasks {
+		byID[st.ID] = st
+	}
+	discarded := make(map[int64]struct{})
+	for _, st := range subtasks {
+		if st.OriginID == 0 {
+			continue
+		}
+		// The origin subtask has been discarded, the copy goes on as a normal one.
+		origin, ok := byID[st.OriginID]
+		if !ok {
+			continue
+		}
+		loser := pickSpeculativeLoser(origin, st)
+		if loser == nil {
+			continue
+		}
+		if err := d.taskMgr.DiscardSubtask(loser.ID); err != nil {
+			return nil, err
+		}
+		logutil.Logger(d.logCtx).Info("discard speculative subtask",
+			zap.Int64("subtask-id", loser.ID),
+			zap.Stringer("state", loser.State),
+			zap.Int64("origin-id", st.OriginID))
+		discarded[loser.ID] = struct{}{}
+	}
+	if len(discarded) == 0 {
+		return subtasks, nil
+	}
+	return slices.DeleteFunc(subtasks, func(st *proto.Subtask) bool {
+		_, ok := discarded[st.ID]
+		return ok
+	}), nil
+}
+
+// pickSpeculativeLoser returns the one to discard of the subtask and its speculative copy,
+// it returns nil if neither can be discarded yet.
+func pickSpeculativeLoser(origin, copied *proto.Subtask) *proto.Subtask {
+	switch {
+	case origin.State == proto.TaskStateSucceed:
+		return copied
+	case copied.State == proto.TaskStateSucceed:
+		return origin
+	case origin.State == proto.TaskStateFailed && !copied.IsFinished():
+		return origin
+	case copied.State == proto.TaskStateFailed && !origin.IsFinished():
+		return copied
+	}
+	return nil
+}
+
+// findStragglers finds the running subtasks which have run much longer than the succeeded ones,
+// the subtasks which are already run speculatively are skipped.
+func findStragglers(subtasks []*proto.Subtask, now time.Time) []*proto.Subtask {
+	existing := make(map[int64]struct{}, len(subtasks))
+	for _, st := range subtasks {
+		existing[st.ID] = struct{}{}
+	}
+	speculated := make(map[int64]struct{})
+	total := len(subtasks)
+	for _, st := range subtasks {
+		if st.OriginID == 0 {
+			continue
+		}
+		if _, ok := existing[st.OriginID]; ok {
+			speculated[st.OriginID] = struct{}{}
+			speculated[st.ID] = struct{}{}
+			// The subtask and its copy are counted once.
+			total--
+		}
+	}
+	durations := make([]time.Duration, 0, len(subtasks))
+	for _, st := range subtasks {
+		if st.State == proto.TaskStateSucceed && !st.StartTime.IsZero() {
+			durations = append(durations, st.UpdateTime.Sub(st.StartTime))
+		}
+	}
+	if total < 2 || float64(len(durations)) < float64(total)*stragglerFinishedRatio {
+		return nil
+	}
+	slices.Sort(durations)
+	threshold := max(time.Duration(float64(durations[len(durations)/2])*stragglerDurationFactor), stragglerMinDuration)
+
+	var stragglers []*proto.Subtask
+	for _, st := range subtasks {
+		if _, ok := speculated[st.ID]; ok {
+			continue
+		}
+		if st.State == proto.TaskStateRunning && !st.StartTime.IsZero() && now.Sub(st.StartTime) > threshold {
+			stragglers = append(stragglers, st)
+		}
+	}
+	return stragglers
+}
+
+// getIdleNodes returns the alive nodes of the task which have no pending or running subtasks.
+func (d *BaseDispatcher) getIdleNodes(subtasks []*proto.Subtask) []string {
+	nodes := slices.Clone(d.taskNodes)
+	busy := make(map[string]struct{}, len(subtasks))
+	for _, st := range subtasks {
+		if !slices.Contains(nodes, st.SchedulerID) {
+			nodes = append(nodes, st.SchedulerID)
+		}
+		if st.State == proto.TaskStatePending || st.State == proto.TaskStateRunning {
+			busy[st.SchedulerID] = struct{}{}
+		}
+	}
+	idle := make([]string, 0, len(nodes))
+	for _, node := range nodes {
+		if _, ok := busy[node]; ok {
+			continue
+		}
+		if len(d.liveNodes) > 0 && !disttaskutil.MatchServerInfo(d.liveNodes, node) {
+			continue
+		}
+		idle = append(idle, node)
+	}
+	slices.Sort(idle)
+	return idle
+}
@@ -0,0 +1,146 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/mock"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func newTestSubtask(id int64, state proto.TaskState, node string, start, update time.Time) *proto.Subtask {
+	return &proto.Subtask{ID: id, State: state, SchedulerID: node, StartTime: start, UpdateTime: update}
+}
+
+func TestFindStragglers(t *testing.T) {
+	now := time.Now()
+	succeed := func(id int64, d time.Duration) *proto.Subtask {
+		return newTestSubtask(id, proto.TaskStateSucceed, "a", now.Add(-time.Hour), now.Add(-time.Hour+d))
+	}
+	running := func(id int64, d time.Duration) *proto.Subtask {
+		return newTestSubtask(id, proto.TaskStateRunning, "b", now.Add(-d), now)
+	}
+
+	// not enough subtasks succeeded.
+	subtasks := []*proto.Subtask{succeed(1, time.Minute), succeed(2, time.Minute), running(3, time.Hour), running(4, time.Hour)}
+	require.Empty(t, findStragglers(subtasks, now))
+	// the single subtask has no peers.
+	require.Empty(t, findStragglers([]*proto.Subtask{running(1, time.Hour)}, now))
+
+	subtasks = []*proto.Subtask{
+		succeed(1, time.Minute), succeed(2, 2*time.Minute), succeed(3, 3*time.Minute), succeed(4, 2*time.Minute),
+		running(5, 5*time.Minute),
+	}
+	// the median duration is 2 minutes.
+	require.Equal(t, []*proto.Subtask{subtasks[4]}, findStragglers(subtasks, now))
+	subtasks[4].StartTime = now.Add(-3 * time.Minute)
+	require.Empty(t, findStragglers(subtasks, now))
+	// short subtasks are not stragglers.
+	subtasks = []*proto.Subtask{
+		succeed(1, time.Second), succeed(2, time.Second), succeed(3, time.Second), running(4, 50*time.Second),
+	}
+	require.Empty(t, findStragglers(subtasks, now))
+
+	// the subtask which has a copy is not a straggler again, the subtask and its copy are counted once.
+	subtasks = []*proto.Subtask{
+		succeed(1, time.Minute), succeed(2, time.Minute), succeed(3, time.Minute),
+		succeed(4, time.Minute), succeed(5, time.Minute), succeed(6, time.Minute),
+		running(7, time.Hour), running(8, time.Hour), running(9, time.Minute),
+	}
+	subtasks[8].OriginID = 8
+	require.Equal(t, []*proto.Subtask{subtasks[6]}, findStragglers(subtasks, now))
+}
+
+func TestPickSpeculativeLoser(t *testing.T) {
+	origin := &proto.Subtask{ID: 1}
+	copied := &proto.Subtask{ID: 2, OriginID: 1}
+	for _, c := range []struct {
+		origin, copied proto.TaskState
+		loser          *proto.Subtask
+	}{
+		{proto.TaskStateSucceed, proto.TaskStateRunning, copied},
+		{proto.TaskStateSucceed, proto.TaskStateSucceed, copied},
+		{proto.TaskStateRunning, proto.TaskStateSucceed, origin},
+		{proto.TaskStatePaused, proto.TaskStateSucceed, origin},
+		{proto.TaskStateFailed, proto.TaskStatePending, origin},
+		{proto.TaskStateRunning, proto.TaskStateFailed, copied},
+		{proto.TaskStateFailed, proto.TaskStateFailed, nil},
+		{proto.TaskStateCanceled, proto.TaskStateRunning, nil},
+		{proto.TaskStateRunning, proto.TaskStatePending, nil},
+	} {
+		origin.State, copied.State = c.origin, c.copied
+		require.Equal(t, c.loser, pickSpeculativeLoser(origin, copied), "%s %s", c.origin, c.copied)
+	}
+}
+
+type speculativeExtension struct {
+	Extension
+}
+
+func (*speculativeExtension) IsSpeculativeStep(*proto.Task) bool {
+	return true
+}
+
+func TestSpeculateStragglers(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	task := &proto.Task{ID: 1, Step: proto.StepOne}
+	d := NewBaseDispatcher(context.Background(), taskMgr, ":4000", task)
+	d.taskNodes = []string{"a", "b", "c", "d"}
+
+	// the extension doesn't support speculative execution.
+	require.NoError(t, d.speculateStragglers())
+
+	d.Extension = &speculativeExtension{}
+	now := time.Now()
+	subtasks := []*proto.Subtask{
+		newTestSubtask(1, proto.TaskStateSucceed, "c", now.Add(-time.Hour), now.Add(-time.Hour+time.Second)),
+		newTestSubtask(2, proto.TaskStateSucceed, "c", now.Add(-time.Hour), now.Add(-time.Hour+time.Second)),
+		newTestSubtask(3, proto.TaskStateSucceed, "d", now.Add(-time.Hour), now.Add(-time.Hour+time.Second)),
+		newTestSubtask(4, proto.TaskStateRunning, "a", now.Add(-time.Hour), now),
+		// the origin subtask 5 is discarded since its copy succeeds.
+		newTestSubtask(5, proto.TaskStateRunning, "b", now.Add(-time.Hour), now),
+		newTestSubtask(6, proto.TaskStateSucceed, "d", now.Add(-time.Minute), now),
+	}
+	subtasks[5].OriginID = 5
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	taskMgr.EXPECT().DiscardSubtask(int64(5)).Return(nil)
+	var execID string
+	taskMgr.EXPECT().AddSpeculativeSubtask(int64(4), gomock.Any()).DoAndReturn(
+		func(_ int64, id string) (bool, error) {
+			execID = id
+			return true, nil
+		})
+	require.NoError(t, d.speculateStragglers())
+	require.Contains(t, []string{"b", "c", "d"}, execID)
+
+	// the subtask and its copy are both running, nothing to do.
+	subtasks = []*proto.Subtask{
+		newTestSubtask(1, proto.TaskStateSucceed, "c", now.Add(-time.Hour), now.Add(-time.Hour+time.Second)),
+		newTestSubtask(2, proto.TaskStateSucceed, "c", now.Add(-time.Hour), now.Add(-time.Hour+time.Second)),
+		newTestSubtask(3, proto.TaskStateSucceed, "d", now.Add(-time.Hour), now.Add(-time.Hour+time.Second)),
+		newTestSubtask(4, proto.TaskStateRunning, "a", now.Add(-time.Hour), now),
+		newTestSubtask(5, proto.TaskStateRunning, "b", now.Add(-time.Minute), now),
+	}
+	subtasks[4].OriginID = 4
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	require.NoError(t, d.speculateStragglers())
+}
@@ -0,0 +1,148 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"slices"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	disttaskutil "github.com/pingcap/tidb/pkg/util/disttask"
+	"github.com/pingcap/tidb/pkg/util/logutil"
+	"go.uber.org/zap"
+)
+
+// rescheduleStuckSubtasks reschedules the running subtasks of the current step which have made no
+// progress for tidb_dist_task_subtask_max_idle, i.e. neither the checkpoint nor the summary is updated,
+// so that a hung node can't block the task forever. If tidb_dist_task_fence_stuck_node is on, the node
+// of the stuck subtask is also excluded from the task.
+func (d *BaseDispatcher) rescheduleStuckSubtasks() error {
+	maxIdle := variable.DistTaskSubtaskMaxIdle.Load()
+	if maxIdle <= 0 {
+		return nil
+	}
+	subtasks, err := d.taskMgr.GetSubtasksByStep(d.Task.ID, d.Task.Step)
+	if err != nil {
+		return err
+	}
+	stuck := findStuckSubtasks(subtasks, time.Now(), maxIdle)
+	if len(stuck) == 0 {
+		return nil
+	}
+	fence := variable.DistTaskFenceStuckNode.Load()
+	for _, st := range stuck {
+		if fence {
+			d.fenceNode(st.SchedulerID)
+		}
+		execID := d.pickRescheduleNode(subtasks, st.SchedulerID)
+		rescheduled, err := d.taskMgr.RescheduleSubtask(st.ID, execID)
+		if err != nil {
+			return err
+		}
+		if !rescheduled {
+			continue
+		}
+		logutil.Logger(d.logCtx).Warn("reschedule stuck subtask",
+			zap.Int64("subtask-id", st.ID),
+			zap.String("origin-node", st.SchedulerID),
+			zap.String("new-node", execID),
+			zap.Duration("idle-time", time.Since(lastProgressTime(st))),
+			zap.Bool("fenced", fence))
+		// The new subtask is pending on the node, it's not idle any more.
+		subtasks = append(subtasks, &proto.Subtask{SchedulerID: execID, State: proto.TaskStatePending})
+	}
+	return nil
+}
+
+// findStuckSubtasks finds the running subtasks which have made no progress for maxIdle.
+func findStuckSubtasks(subtasks []*proto.Subtask, now time.Time, maxIdle time.Duration) []*proto.Subtask {
+	var stuck []*proto.Subtask
+	for _, st := range subtasks {
+		if st.State != proto.TaskStateRunning {
+			continue
+		}
+		lastProgress := lastProgressTime(st)
+		if !lastProgress.IsZero() && now.Sub(lastProgress) > maxIdle {
+			stuck = append(stuck, st)
+		}
+	}
+	return stuck
+}
+
+// lastProgressTime returns the time when the running subtask made progress last time.
+func lastProgressTime(st *proto.Subtask) time.Time {
+	if st.UpdateTime.After(st.StartTime) {
+		return st.UpdateTime
+	}
+	return st.StartTime
+}
+
+// pickRescheduleNode picks the node to run the stuck subtask again. An idle node is preferred, then
+// the other nodes of the task, the subtask is run again on the same node if there is no other one.
+func (d *BaseDispatcher) pickRescheduleNode(subtasks []*proto.Subtask, stuckNode string) string {
+	pick := func(nodes []string) string {
+		candidates := slices.DeleteFunc(slices.Clone(nodes), func(node string) bool {
+			return node == stuckNode || d.isFenced(node) ||
+				(len(d.liveNodes) > 0 && !disttaskutil.MatchServerInfo(d.liveNodes, node))
+		})
+		if len(candidates) == 0 {
+			return ""
+		}
+		return candidates[d.rand.Intn(len(candidates))] //nolint:gosec
+	}
+	if node := pick(d.getIdleNodes(subtasks)); node != "" {
+		return node
+	}
+	if node := pick(d.taskNodes); node != "" {
+		return node
+	}
+	return stuckNode
+}
+
+// fenceNode excludes the node from the task, its subtasks are moved to the other nodes like
+// the ones of a dead node, and no more subtasks are dispatched to it.
+func (d *BaseDispatcher) fenceNode(node string) {
+	if d.fencedNodes == nil {
+		d.fencedNodes = make(map[string]struct{})
+	}
+	if _, ok := d.fencedNodes[node]; !ok {
+		logutil.Logger(d.logCtx).Warn("fence the node of stuck subtask", zap.String("node", node))
+		d.fencedNodes[node] = struct{}{}
+	}
+}
+
+func (d *BaseDispatcher) isFenced(node string) bool {
+	_, ok := d.fencedNodes[node]
+	return ok
+}
+
+// filterFencedNodes removes the fenced nodes, the nodes are kept if all of them are fenced.
+func (d *BaseDispatcher) filterFencedNodes(infos []*infosync.ServerInfo) []*infosync.ServerInfo {
+	if len(d.fencedNodes) == 0 {
+		return infos
+	}
+	res := make([]*infosync.ServerInfo, 0, len(infos))
+	for _, info := range infos {
+		if !d.isFenced(disttaskutil.GenerateExecID(info.IP, info.Port)) {
+			res = append(res, info)
+		}
+	}
+	if len(res) == 0 {
+		return infos
+	}
+	return res
+}
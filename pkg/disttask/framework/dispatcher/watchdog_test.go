@@ -0,0 +1,97 @@
+// Copyright 2023 PingCAP, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dispatcher
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/pingcap/tidb/pkg/disttask/framework/mock"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/domain/infosync"
+	"github.com/pingcap/tidb/pkg/sessionctx/variable"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestFindStuckSubtasks(t *testing.T) {
+	now := time.Now()
+	subtasks := []*proto.Subtask{
+		// no progress since started.
+		newTestSubtask(1, proto.TaskStateRunning, "a", now.Add(-time.Hour), now.Add(-time.Hour)),
+		// the checkpoint is updated recently.
+		newTestSubtask(2, proto.TaskStateRunning, "a", now.Add(-time.Hour), now.Add(-time.Minute)),
+		newTestSubtask(3, proto.TaskStateRunning, "b", now.Add(-time.Minute), time.Time{}),
+		newTestSubtask(4, proto.TaskStatePending, "b", time.Time{}, time.Time{}),
+		newTestSubtask(5, proto.TaskStateSucceed, "b", now.Add(-2*time.Hour), now.Add(-time.Hour)),
+		newTestSubtask(6, proto.TaskStateRunning, "c", now.Add(-time.Hour), time.Time{}),
+	}
+	require.Equal(t, []*proto.Subtask{subtasks[0], subtasks[5]}, findStuckSubtasks(subtasks, now, 10*time.Minute))
+	require.Empty(t, findStuckSubtasks(subtasks, now, 2*time.Hour))
+}
+
+func TestRescheduleStuckSubtasks(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	taskMgr := mock.NewMockTaskManager(ctrl)
+	task := &proto.Task{ID: 1, Step: proto.StepOne}
+	d := NewBaseDispatcher(context.Background(), taskMgr, ":4000", task)
+	d.taskNodes = []string{"a", "b", "c"}
+	defer func() {
+		variable.DistTaskSubtaskMaxIdle.Store(variable.DefTiDBDistTaskSubtaskMaxIdle)
+		variable.DistTaskFenceStuckNode.Store(variable.DefTiDBDistTaskFenceStuckNode)
+	}()
+
+	// the watchdog is disabled.
+	require.NoError(t, d.rescheduleStuckSubtasks())
+
+	variable.DistTaskSubtaskMaxIdle.Store(10 * time.Minute)
+	now := time.Now()
+	subtasks := []*proto.Subtask{
+		newTestSubtask(1, proto.TaskStateRunning, "a", now.Add(-time.Hour), now.Add(-time.Hour)),
+		newTestSubtask(2, proto.TaskStateRunning, "b", now.Add(-time.Hour), now),
+	}
+	// the stuck subtask is moved to the idle node.
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	taskMgr.EXPECT().RescheduleSubtask(int64(1), "c").Return(true, nil)
+	require.NoError(t, d.rescheduleStuckSubtasks())
+	require.Empty(t, d.fencedNodes)
+
+	// the node is fenced, its subtasks are moved to the other nodes and no more subtasks are dispatched to it.
+	variable.DistTaskFenceStuckNode.Store(true)
+	subtasks = append(subtasks, newTestSubtask(3, proto.TaskStateRunning, "c", now.Add(-time.Minute), now))
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	taskMgr.EXPECT().RescheduleSubtask(int64(1), gomock.Any()).DoAndReturn(
+		func(_ int64, execID string) (bool, error) {
+			require.Contains(t, []string{"b", "c"}, execID)
+			return true, nil
+		})
+	require.NoError(t, d.rescheduleStuckSubtasks())
+	require.True(t, d.isFenced("a"))
+	d.fencedNodes = map[string]struct{}{"a:4000": {}}
+	require.Equal(t, []*infosync.ServerInfo{{IP: "b", Port: 4000}}, d.filterFencedNodes([]*infosync.ServerInfo{{IP: "a", Port: 4000}, {IP: "b", Port: 4000}}))
+	// all the nodes are fenced, keep them.
+	d.fencedNodes["b:4000"] = struct{}{}
+	require.Len(t, d.filterFencedNodes([]*infosync.ServerInfo{{IP: "a", Port: 4000}, {IP: "b", Port: 4000}}), 2)
+
+	// the single node runs the stuck subtask again.
+	d.taskNodes = []string{"a"}
+	d.fencedNodes = nil
+	subtasks = subtasks[:1]
+	taskMgr.EXPECT().GetSubtasksByStep(int64(1), proto.StepOne).Return(subtasks, nil)
+	taskMgr.EXPECT().RescheduleSubtask(int64(1), "a").Return(false, nil)
+	require.NoError(t, d.rescheduleStuckSubtasks())
+}
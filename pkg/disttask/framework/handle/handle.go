@@ -31,8 +31,13 @@ var (
 	checkTaskFinishInterval = 300 * time.Millisecond
 )
 
-// SubmitGlobalTask submits a global task.
+// SubmitGlobalTask submits a global task of normal priority.
 func SubmitGlobalTask(taskKey string, taskType proto.TaskType, concurrency int, taskMeta []byte) (*proto.Task, error) {
+	return SubmitGlobalTaskWithPriority(taskKey, taskType, concurrency, proto.NormalPriority, taskMeta)
+}
+
+// SubmitGlobalTaskWithPriority submits a global task with the priority.
+func SubmitGlobalTaskWithPriority(taskKey string, taskType proto.TaskType, concurrency, priority int, taskMeta []byte) (*proto.Task, error) {
 	globalTaskManager, err := storage.GetTaskManager()
 	if err != nil {
 		return nil, err
@@ -43,7 +48,7 @@ func SubmitGlobalTask(taskKey string, taskType proto.TaskType, concurrency int,
 	}
 
 	if globalTask == nil {
-		taskID, err := globalTaskManager.AddNewGlobalTask(taskKey, taskType, concurrency, taskMeta)
+		taskID, err := globalTaskManager.AddNewGlobalTaskWithPriority(taskKey, taskType, concurrency, priority, taskMeta)
 		if err != nil {
 			return nil, err
 		}
@@ -102,9 +107,9 @@ func WaitGlobalTask(ctx context.Context, globalTask *proto.Task) error {
 	}
 }
 
-// SubmitAndRunGlobalTask submits a global task and wait for it to finish.
-func SubmitAndRunGlobalTask(ctx context.Context, taskKey string, taskType proto.TaskType, concurrency int, taskMeta []byte) error {
-	globalTask, err := SubmitGlobalTask(taskKey, taskType, concurrency, taskMeta)
+// SubmitAndRunGlobalTask submits a global task with the priority and wait for it to finish.
+func SubmitAndRunGlobalTask(ctx context.Context, taskKey string, taskType proto.TaskType, concurrency, priority int, taskMeta []byte) error {
+	globalTask, err := SubmitGlobalTaskWithPriority(taskKey, taskType, concurrency, priority, taskMeta)
 	if err != nil {
 		return err
 	}
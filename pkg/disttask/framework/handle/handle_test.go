@@ -45,7 +45,7 @@ func TestHandle(t *testing.T) {
 	storage.SetTaskManager(mgr)
 
 	// no dispatcher registered
-	err := handle.SubmitAndRunGlobalTask(ctx, "1", proto.TaskTypeExample, 2, []byte("byte"))
+	err := handle.SubmitAndRunGlobalTask(ctx, "1", proto.TaskTypeExample, 2, proto.LowPriority, []byte("byte"))
 	require.Error(t, err)
 
 	task, err := mgr.GetGlobalTaskByID(1)
@@ -58,11 +58,13 @@ func TestHandle(t *testing.T) {
 	require.Equal(t, proto.StepInit, task.Step)
 	require.Equal(t, uint64(2), task.Concurrency)
 	require.Equal(t, []byte("byte"), task.Meta)
+	require.Equal(t, proto.LowPriority, task.Priority)
 
 	require.NoError(t, handle.CancelGlobalTask("1"))
 
 	task, err = handle.SubmitGlobalTask("2", proto.TaskTypeExample, 2, []byte("byte"))
 	require.NoError(t, err)
+	require.Equal(t, proto.NormalPriority, task.Priority)
 	require.NoError(t, handle.PauseTask("2"))
 	require.NoError(t, handle.ResumeTask("2"))
 }
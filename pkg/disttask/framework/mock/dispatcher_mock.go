@@ -134,6 +134,21 @@ func (m *MockTaskManager) EXPECT() *MockTaskManagerMockRecorder {
 	return m.recorder
 }
 
+// AddSpeculativeSubtask mocks base method.
+func (m *MockTaskManager) AddSpeculativeSubtask(arg0 int64, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSpeculativeSubtask", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AddSpeculativeSubtask indicates an expected call of AddSpeculativeSubtask.
+func (mr *MockTaskManagerMockRecorder) AddSpeculativeSubtask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSpeculativeSubtask", reflect.TypeOf((*MockTaskManager)(nil).AddSpeculativeSubtask), arg0, arg1)
+}
+
 // CancelGlobalTask mocks base method.
 func (m *MockTaskManager) CancelGlobalTask(arg0 int64) error {
 	m.ctrl.T.Helper()
@@ -177,6 +192,20 @@ func (mr *MockTaskManagerMockRecorder) CollectSubTaskError(arg0 interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CollectSubTaskError", reflect.TypeOf((*MockTaskManager)(nil).CollectSubTaskError), arg0)
 }
 
+// DiscardSubtask mocks base method.
+func (m *MockTaskManager) DiscardSubtask(arg0 int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DiscardSubtask", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DiscardSubtask indicates an expected call of DiscardSubtask.
+func (mr *MockTaskManagerMockRecorder) DiscardSubtask(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DiscardSubtask", reflect.TypeOf((*MockTaskManager)(nil).DiscardSubtask), arg0)
+}
+
 // GCSubtasks mocks base method.
 func (m *MockTaskManager) GCSubtasks() error {
 	m.ctrl.T.Helper()
@@ -305,6 +334,21 @@ func (mr *MockTaskManagerMockRecorder) GetSubtaskInStatesCnt(arg0 interface{}, a
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtaskInStatesCnt", reflect.TypeOf((*MockTaskManager)(nil).GetSubtaskInStatesCnt), varargs...)
 }
 
+// GetSubtasksByStep mocks base method.
+func (m *MockTaskManager) GetSubtasksByStep(arg0 int64, arg1 proto.Step) ([]*proto.Subtask, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSubtasksByStep", arg0, arg1)
+	ret0, _ := ret[0].([]*proto.Subtask)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSubtasksByStep indicates an expected call of GetSubtasksByStep.
+func (mr *MockTaskManagerMockRecorder) GetSubtasksByStep(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSubtasksByStep", reflect.TypeOf((*MockTaskManager)(nil).GetSubtasksByStep), arg0, arg1)
+}
+
 // GetSucceedSubtasksByStep mocks base method.
 func (m *MockTaskManager) GetSucceedSubtasksByStep(arg0 int64, arg1 proto.Step) ([]*proto.Subtask, error) {
 	m.ctrl.T.Helper()
@@ -335,6 +379,21 @@ func (mr *MockTaskManagerMockRecorder) PauseTask(arg0 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PauseTask", reflect.TypeOf((*MockTaskManager)(nil).PauseTask), arg0)
 }
 
+// RescheduleSubtask mocks base method.
+func (m *MockTaskManager) RescheduleSubtask(arg0 int64, arg1 string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RescheduleSubtask", arg0, arg1)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RescheduleSubtask indicates an expected call of RescheduleSubtask.
+func (mr *MockTaskManagerMockRecorder) RescheduleSubtask(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RescheduleSubtask", reflect.TypeOf((*MockTaskManager)(nil).RescheduleSubtask), arg0, arg1)
+}
+
 // ResumeSubtasks mocks base method.
 func (m *MockTaskManager) ResumeSubtasks(arg0 int64) error {
 	m.ctrl.T.Helper()
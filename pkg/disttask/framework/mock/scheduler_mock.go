@@ -159,6 +159,21 @@ func (mr *MockTaskTableMockRecorder) IsSchedulerCanceled(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSchedulerCanceled", reflect.TypeOf((*MockTaskTable)(nil).IsSchedulerCanceled), arg0, arg1)
 }
 
+// IsSubtaskDiscarded mocks base method.
+func (m *MockTaskTable) IsSubtaskDiscarded(arg0 int64) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsSubtaskDiscarded", arg0)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IsSubtaskDiscarded indicates an expected call of IsSubtaskDiscarded.
+func (mr *MockTaskTableMockRecorder) IsSubtaskDiscarded(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsSubtaskDiscarded", reflect.TypeOf((*MockTaskTable)(nil).IsSubtaskDiscarded), arg0)
+}
+
 // PauseSubtasks mocks base method.
 func (m *MockTaskTable) PauseSubtasks(arg0 string, arg1 int64) error {
 	m.ctrl.T.Helper()
@@ -14,7 +14,10 @@
 
 package planner
 
-import "github.com/pingcap/tidb/pkg/disttask/framework/storage"
+import (
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	"github.com/pingcap/tidb/pkg/disttask/framework/storage"
+)
 
 // Planner represents a distribute plan planner.
 type Planner struct{}
@@ -41,6 +44,7 @@ func (*Planner) Run(planCtx PlanCtx, plan LogicalPlan) (int64, error) {
 		planCtx.TaskKey,
 		planCtx.TaskType,
 		planCtx.ThreadCnt,
+		proto.NormalPriority,
 		taskMeta,
 	)
 }
@@ -119,6 +119,15 @@ const (
 // TaskIDLabelName is the label name of task id.
 const TaskIDLabelName = "task_id"
 
+// The priority of task, the smaller value means the higher priority.
+// When the dispatching slots are not enough, the pending task of higher
+// priority is dispatched first.
+const (
+	HighPriority   = 1
+	NormalPriority = 512
+	LowPriority    = 1024
+)
+
 // Task represents the task of distributed framework.
 type Task struct {
 	ID    int64
@@ -133,6 +142,8 @@ type Task struct {
 	StateUpdateTime time.Time
 	Meta            []byte
 	Error           error
+	// Priority is the priority of the task, see NormalPriority.
+	Priority int
 }
 
 // IsFinished checks if the task is finished.
@@ -161,6 +172,9 @@ type Subtask struct {
 	UpdateTime time.Time
 	Meta       []byte
 	Summary    string
+	// OriginID is the ID of the subtask which this subtask runs again speculatively,
+	// it's 0 if the subtask isn't a speculative one.
+	OriginID int64
 }
 
 // IsFinished checks if the subtask is finished.
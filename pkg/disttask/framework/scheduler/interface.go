@@ -36,6 +36,7 @@ type TaskTable interface {
 	HasSubtasksInStates(tidbID string, taskID int64, step proto.Step, states ...interface{}) (bool, error)
 	UpdateErrorToSubtask(tidbID string, taskID int64, err error) error
 	IsSchedulerCanceled(tidbID string, taskID int64) (bool, error)
+	IsSubtaskDiscarded(subtaskID int64) (bool, error)
 	PauseSubtasks(tidbID string, taskID int64) error
 }
 
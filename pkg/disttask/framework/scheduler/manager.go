@@ -241,7 +241,7 @@ func (m *Manager) onPausingTasks(tasks []*proto.Task) error {
 		if cancel, ok := m.mu.handlingTasks[task.ID]; ok && cancel != nil {
 			// Pause all running subtasks, don't mark subtasks as canceled.
 			// Should not change the subtask's state.
-			cancel(nil)
+			cancel(ErrPauseSubtask)
 		}
 		if err := m.taskTable.PauseSubtasks(m.id, task.ID); err != nil {
 			return err
@@ -42,10 +42,16 @@ const (
 var (
 	// ErrCancelSubtask is the cancel cause when cancelling subtasks.
 	ErrCancelSubtask = errors.New("cancel subtasks")
+	// ErrPauseSubtask is the cancel cause when pausing subtasks, the subtask executor
+	// can stop at a safe point to resume from it later.
+	ErrPauseSubtask = errors.New("pause subtasks")
 	// ErrFinishSubtask is the cancel cause when scheduler successfully processed subtasks.
 	ErrFinishSubtask = errors.New("finish subtasks")
 	// ErrFinishRollback is the cancel cause when scheduler rollback successfully.
 	ErrFinishRollback = errors.New("finish rollback")
+	// ErrDiscardSubtask is the cancel cause when the running subtask is discarded by the dispatcher,
+	// e.g. its speculative copy has finished first.
+	ErrDiscardSubtask = errors.New("discard subtask")
 
 	// TestSyncChan is used to sync the test.
 	TestSyncChan = make(chan struct{})
@@ -67,6 +73,10 @@ type BaseScheduler struct {
 		handled bool
 		// runtimeCancel is used to cancel the Run/Rollback when error occurs.
 		runtimeCancel context.CancelCauseFunc
+		// runningSubtaskID is the ID of the running subtask, and subtaskCancel cancels
+		// it only when it's discarded.
+		runningSubtaskID int64
+		subtaskCancel    context.CancelCauseFunc
 	}
 }
 
@@ -104,7 +114,9 @@ func (s *BaseScheduler) startCancelCheck(ctx context.Context, wg *sync.WaitGroup
 						// Should not change the subtask's state.
 						cancelFn(nil)
 					}
+					continue
 				}
+				s.cancelSubtaskIfDiscarded()
 			}
 		}
 	}()
@@ -256,8 +268,42 @@ func (s *BaseScheduler) run(ctx context.Context, task *proto.Task) error {
 	return s.getError()
 }
 
+// cancelSubtaskIfDiscarded cancels the running subtask if it has been discarded by the dispatcher.
+func (s *BaseScheduler) cancelSubtaskIfDiscarded() {
+	s.mu.RLock()
+	subtaskID, cancel := s.mu.runningSubtaskID, s.mu.subtaskCancel
+	s.mu.RUnlock()
+	if cancel == nil {
+		return
+	}
+	discarded, err := s.taskTable.IsSubtaskDiscarded(subtaskID)
+	if err != nil || !discarded {
+		return
+	}
+	logutil.Logger(s.logCtx).Info("subtask discarded, cancel it", zap.Int64("subtask-id", subtaskID))
+	cancel(ErrDiscardSubtask)
+}
+
+func (s *BaseScheduler) registerRunningSubtask(subtaskID int64, cancel context.CancelCauseFunc) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.mu.runningSubtaskID = subtaskID
+	s.mu.subtaskCancel = cancel
+}
+
 func (s *BaseScheduler) runSubtask(ctx context.Context, executor execute.SubtaskExecutor, subtask *proto.Subtask) {
-	err := executor.RunSubtask(ctx, subtask)
+	subtaskCtx, subtaskCancel := context.WithCancelCause(ctx)
+	s.registerRunningSubtask(subtask.ID, subtaskCancel)
+	err := executor.RunSubtask(subtaskCtx, subtask)
+	s.registerRunningSubtask(0, nil)
+	subtaskCancel(nil)
+	if context.Cause(subtaskCtx) == ErrDiscardSubtask {
+		// The output of the discarded subtask is not used, and the subtask has been deleted.
+		logutil.Logger(s.logCtx).Info("subtask is discarded", zap.Int64("subtask-id", subtask.ID), zap.Error(err))
+		metrics.DecDistTaskSubTaskCnt(subtask)
+		metrics.EndDistTaskSubTask(subtask)
+		return
+	}
 	failpoint.Inject("MockRunSubtaskCancel", func(val failpoint.Value) {
 		if val.(bool) {
 			err = ErrCancelSubtask
@@ -584,6 +630,9 @@ func (s *BaseScheduler) markSubTaskCanceledOrFailed(ctx context.Context, subtask
 		if ctx.Err() != nil && context.Cause(ctx) == ErrCancelSubtask {
 			logutil.Logger(s.logCtx).Warn("subtask canceled", zap.Error(err))
 			s.updateSubtaskStateAndError(subtask, proto.TaskStateCanceled, nil)
+		} else if ctx.Err() != nil && context.Cause(ctx) == ErrPauseSubtask {
+			// The subtask is marked as paused by the manager.
+			logutil.Logger(s.logCtx).Info("subtask paused", zap.Error(err))
 		} else if common.IsRetryableError(err) {
 			logutil.Logger(s.logCtx).Warn("met retryable error", zap.Error(err))
 		} else if errors.Cause(err) != context.Canceled {
@@ -49,6 +49,7 @@ func TestSchedulerRun(t *testing.T) {
 
 	// we don't test cancelCheck here, but in case the test is slow and trigger it.
 	mockSubtaskTable.EXPECT().IsSchedulerCanceled(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mockSubtaskTable.EXPECT().IsSubtaskDiscarded(gomock.Any()).Return(false, nil).AnyTimes()
 
 	// 1. no scheduler constructor
 	schedulerRegisterErr := errors.Errorf("constructor of scheduler for key not found")
@@ -313,6 +314,29 @@ func TestSchedulerPause(t *testing.T) {
 	mockSubtaskTable.EXPECT().PauseSubtasks("id", int64(1)).Return(pauseErr)
 	err := scheduler.Pause(runCtx, &proto.Task{Step: proto.StepOne, ID: 1, Type: tp})
 	require.EqualError(t, err, pauseErr.Error())
+	// the running subtask is stopped by pausing, its state is kept.
+	mockSubtaskExecutor := mockexecute.NewMockSubtaskExecutor(ctrl)
+	mockSubtaskTable.EXPECT().IsSchedulerCanceled(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mockSubtaskTable.EXPECT().IsSubtaskDiscarded(gomock.Any()).Return(false, nil).AnyTimes()
+	mockExtension.EXPECT().GetSubtaskExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockSubtaskExecutor, nil)
+	mockSubtaskExecutor.EXPECT().Init(gomock.Any()).Return(nil)
+	mockSubtaskTable.EXPECT().GetSubtasksInStates("id", int64(1), proto.StepOne,
+		unfinishedNormalSubtaskStates...).Return([]*proto.Subtask{{
+		ID: 1, Type: tp, Step: proto.StepOne, State: proto.TaskStatePending}}, nil)
+	mockSubtaskTable.EXPECT().GetFirstSubtaskInStates("id", int64(1), proto.StepOne,
+		unfinishedNormalSubtaskStates...).Return(&proto.Subtask{
+		ID: 1, Type: tp, Step: proto.StepOne, State: proto.TaskStatePending}, nil)
+	mockSubtaskTable.EXPECT().StartSubtask(int64(1)).Return(nil)
+	pauseCtx, pauseCancel := context.WithCancelCause(ctx)
+	parkErr := errors.New("park at safe point")
+	mockSubtaskExecutor.EXPECT().RunSubtask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(context.Context, *proto.Subtask) error {
+			pauseCancel(ErrPauseSubtask)
+			return parkErr
+		})
+	mockSubtaskExecutor.EXPECT().Cleanup(gomock.Any()).Return(nil)
+	err = scheduler.Run(pauseCtx, &proto.Task{Step: proto.StepOne, ID: 1, Type: tp})
+	require.EqualError(t, err, parkErr.Error())
 }
 
 func TestScheduler(t *testing.T) {
@@ -329,6 +353,7 @@ func TestScheduler(t *testing.T) {
 	mockSubtaskExecutor := mockexecute.NewMockSubtaskExecutor(ctrl)
 	mockExtension := mock.NewMockExtension(ctrl)
 	mockSubtaskTable.EXPECT().IsSchedulerCanceled(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mockSubtaskTable.EXPECT().IsSubtaskDiscarded(gomock.Any()).Return(false, nil).AnyTimes()
 
 	mockExtension.EXPECT().GetSubtaskExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockSubtaskExecutor, nil).AnyTimes()
 
@@ -362,3 +387,42 @@ func TestScheduler(t *testing.T) {
 	err = scheduler.Rollback(runCtx, &proto.Task{Step: proto.StepOne, Type: tp, ID: taskID})
 	require.NoError(t, err)
 }
+
+func TestSchedulerDiscardSubtask(t *testing.T) {
+	var tp proto.TaskType = "test_scheduler_discard"
+	var taskID int64 = 1
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	mockSubtaskTable := mock.NewMockTaskTable(ctrl)
+	mockSubtaskExecutor := mockexecute.NewMockSubtaskExecutor(ctrl)
+	mockExtension := mock.NewMockExtension(ctrl)
+	mockSubtaskTable.EXPECT().IsSchedulerCanceled(gomock.Any(), gomock.Any()).Return(false, nil).AnyTimes()
+	mockExtension.EXPECT().GetSubtaskExecutor(gomock.Any(), gomock.Any(), gomock.Any()).Return(mockSubtaskExecutor, nil)
+
+	scheduler := NewBaseScheduler(ctx, "id", taskID, mockSubtaskTable)
+	scheduler.Extension = mockExtension
+
+	// the running subtask is canceled after it's discarded, and it's not finished.
+	mockSubtaskExecutor.EXPECT().Init(gomock.Any()).Return(nil)
+	mockSubtaskTable.EXPECT().GetSubtasksInStates("id", taskID, proto.StepOne,
+		unfinishedNormalSubtaskStates...).Return([]*proto.Subtask{{
+		ID: 2, Type: tp, Step: proto.StepOne, State: proto.TaskStatePending}}, nil)
+	mockSubtaskTable.EXPECT().GetFirstSubtaskInStates("id", taskID, proto.StepOne,
+		unfinishedNormalSubtaskStates...).Return(&proto.Subtask{
+		ID: 2, Type: tp, Step: proto.StepOne, State: proto.TaskStatePending}, nil)
+	mockSubtaskTable.EXPECT().StartSubtask(int64(2)).Return(nil)
+	mockSubtaskTable.EXPECT().IsSubtaskDiscarded(int64(2)).Return(true, nil).MinTimes(1)
+	mockSubtaskExecutor.EXPECT().RunSubtask(gomock.Any(), gomock.Any()).DoAndReturn(
+		func(ctx context.Context, _ *proto.Subtask) error {
+			<-ctx.Done()
+			return ctx.Err()
+		})
+	mockSubtaskTable.EXPECT().GetFirstSubtaskInStates("id", taskID, proto.StepOne,
+		unfinishedNormalSubtaskStates...).Return(nil, nil)
+	mockSubtaskTable.EXPECT().GetGlobalTaskByID(taskID).Return(
+		&proto.Task{ID: taskID, Type: tp, Step: proto.StepTwo, State: proto.TaskStateRunning}, nil)
+	mockSubtaskExecutor.EXPECT().Cleanup(gomock.Any()).Return(nil)
+	err := scheduler.Run(ctx, &proto.Task{Step: proto.StepOne, Type: tp, ID: taskID, State: proto.TaskStateRunning})
+	require.NoError(t, err)
+}
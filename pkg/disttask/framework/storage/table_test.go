@@ -106,6 +106,12 @@ func TestGlobalTaskTable(t *testing.T) {
 	require.Len(t, task5, 1)
 	require.Equal(t, task.State, task6.State)
 
+	// test update meta of the running task
+	require.NoError(t, gm.UpdateGlobalTaskMeta(task.ID, []byte("test-updated")))
+	task7, err := gm.GetGlobalTaskByID(task.ID)
+	require.NoError(t, err)
+	require.Equal(t, []byte("test-updated"), task7.Meta)
+
 	// test cannot insert task with dup key
 	_, err = gm.AddNewGlobalTask("key1", "test2", 4, []byte("test2"))
 	require.EqualError(t, err, "[kv:1062]Duplicate entry 'key1' for key 'tidb_global_task.task_key'")
@@ -124,6 +130,35 @@ func TestGlobalTaskTable(t *testing.T) {
 	require.True(t, cancelling)
 }
 
+func TestGlobalTaskPriority(t *testing.T) {
+	pool := GetResourcePool(t)
+	gm := GetTaskManager(t, pool)
+	defer pool.Close()
+	id1, err := gm.AddNewGlobalTask("key1", "test", 4, nil)
+	require.NoError(t, err)
+	id2, err := gm.AddNewGlobalTaskWithPriority("key2", "test", 4, proto.LowPriority, nil)
+	require.NoError(t, err)
+	id3, err := gm.AddNewGlobalTaskWithPriority("key3", "test", 4, proto.HighPriority, nil)
+	require.NoError(t, err)
+
+	// the pending task of higher priority is dispatched first.
+	task, err := gm.GetNewGlobalTask()
+	require.NoError(t, err)
+	require.Equal(t, id3, task.ID)
+	require.Equal(t, proto.HighPriority, task.Priority)
+	tasks, err := gm.GetGlobalTasksInStates(proto.TaskStatePending)
+	require.NoError(t, err)
+	require.Len(t, tasks, 3)
+	require.Equal(t, []int64{id3, id1, id2}, []int64{tasks[0].ID, tasks[1].ID, tasks[2].ID})
+	require.Equal(t, proto.NormalPriority, tasks[1].Priority)
+
+	// the priority is kept in the history table.
+	require.NoError(t, gm.TransferTasks2History(tasks[2:]))
+	task, err = gm.GetTaskByIDWithHistory(id2)
+	require.NoError(t, err)
+	require.Equal(t, proto.LowPriority, task.Priority)
+}
+
 func TestSubTaskTable(t *testing.T) {
 	pool := GetResourcePool(t)
 	sm := GetTaskManager(t, pool)
@@ -155,6 +190,13 @@ func TestSubTaskTable(t *testing.T) {
 	require.Len(t, ids, 1)
 	require.Equal(t, "tidb1", ids[0])
 
+	subtasks, err := sm.GetSubtasksByType(proto.TaskTypeExample)
+	require.NoError(t, err)
+	require.Equal(t, []*proto.Subtask{subtask}, subtasks)
+	subtasks, err = sm.GetSubtasksByType(proto.ImportInto)
+	require.NoError(t, err)
+	require.Empty(t, subtasks)
+
 	ids, err = sm.GetSchedulerIDsByTaskID(3)
 	require.NoError(t, err)
 	require.Len(t, ids, 0)
@@ -219,7 +261,7 @@ func TestSubTaskTable(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(1), cnt)
 
-	subtasks, err := sm.GetSucceedSubtasksByStep(2, proto.StepInit)
+	subtasks, err = sm.GetSucceedSubtasksByStep(2, proto.StepInit)
 	require.NoError(t, err)
 	require.Len(t, subtasks, 0)
 
@@ -259,12 +301,19 @@ func TestSubTaskTable(t *testing.T) {
 	require.NoError(t, err)
 	require.Greater(t, subtask.StartTime, ts)
 	require.Greater(t, subtask.UpdateTime, ts)
+	// test UpdateSubtaskMeta only updates the running subtask
+	require.NoError(t, sm.UpdateSubtaskMeta(subtask.ID, []byte("checkpoint")))
+	subtask, err = sm.GetFirstSubtaskInStates("for_test1", 4, proto.StepInit, proto.TaskStateRunning)
+	require.NoError(t, err)
+	require.Equal(t, []byte("checkpoint"), subtask.Meta)
 	time.Sleep(time.Second)
 	require.NoError(t, sm.FinishSubtask(subtask.ID, []byte{}))
+	require.NoError(t, sm.UpdateSubtaskMeta(subtask.ID, []byte("checkpoint2")))
 	subtask2, err = sm.GetFirstSubtaskInStates("for_test1", 4, proto.StepInit, proto.TaskStateSucceed)
 	require.NoError(t, err)
 	require.Equal(t, subtask2.StartTime, subtask.StartTime)
 	require.Greater(t, subtask2.UpdateTime, subtask.UpdateTime)
+	require.Empty(t, subtask2.Meta)
 
 	// test UpdateFailedSchedulerIDs and IsSchedulerCanceled
 	canceled, err := sm.IsSchedulerCanceled("for_test999", 4)
@@ -590,3 +639,100 @@ func TestPauseAndResume(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(2), cnt)
 }
+
+func TestSpeculativeSubtask(t *testing.T) {
+	pool := GetResourcePool(t)
+	sm := GetTaskManager(t, pool)
+	defer pool.Close()
+	require.NoError(t, sm.AddNewSubTask(1, proto.StepOne, "tidb1", []byte("test1"), proto.TaskTypeExample, false))
+	require.NoError(t, sm.AddNewSubTask(1, proto.StepOne, "tidb2", []byte("test2"), proto.TaskTypeExample, false))
+	// only the running subtask can be run speculatively.
+	added, err := sm.AddSpeculativeSubtask(1, "tidb3")
+	require.NoError(t, err)
+	require.False(t, added)
+	require.NoError(t, sm.StartSubtask(1))
+	require.NoError(t, sm.UpdateSubtaskMeta(1, []byte("checkpoint")))
+	added, err = sm.AddSpeculativeSubtask(1, "tidb3")
+	require.NoError(t, err)
+	require.True(t, added)
+	// a subtask has at most one copy.
+	added, err = sm.AddSpeculativeSubtask(1, "tidb2")
+	require.NoError(t, err)
+	require.False(t, added)
+
+	subtasks, err := sm.GetSubtasksByStep(1, proto.StepOne)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 3)
+	copied := subtasks[2]
+	require.Equal(t, int64(1), copied.OriginID)
+	require.Equal(t, "tidb3", copied.SchedulerID)
+	require.Equal(t, proto.TaskStatePending, copied.State)
+	require.Equal(t, []byte("checkpoint"), copied.Meta)
+	require.Equal(t, proto.TaskTypeExample, copied.Type)
+	require.Equal(t, int64(0), subtasks[0].OriginID)
+
+	discarded, err := sm.IsSubtaskDiscarded(1)
+	require.NoError(t, err)
+	require.False(t, discarded)
+	require.NoError(t, sm.DiscardSubtask(1))
+	discarded, err = sm.IsSubtaskDiscarded(1)
+	require.NoError(t, err)
+	require.True(t, discarded)
+	subtasks, err = sm.GetSubtasksByStep(1, proto.StepOne)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 2)
+}
+
+func TestRescheduleSubtask(t *testing.T) {
+	pool := GetResourcePool(t)
+	sm := GetTaskManager(t, pool)
+	defer pool.Close()
+	require.NoError(t, sm.AddNewSubTask(1, proto.StepOne, "tidb1", []byte("test1"), proto.TaskTypeExample, false))
+	// only the running subtask can be rescheduled.
+	rescheduled, err := sm.RescheduleSubtask(1, "tidb2")
+	require.NoError(t, err)
+	require.False(t, rescheduled)
+	require.NoError(t, sm.StartSubtask(1))
+	require.NoError(t, sm.UpdateSubtaskMeta(1, []byte("checkpoint")))
+	require.NoError(t, sm.UpdateSubtaskRowCount(1, 100))
+	rescheduled, err = sm.RescheduleSubtask(1, "tidb2")
+	require.NoError(t, err)
+	require.True(t, rescheduled)
+
+	discarded, err := sm.IsSubtaskDiscarded(1)
+	require.NoError(t, err)
+	require.True(t, discarded)
+	subtasks, err := sm.GetSubtasksByStep(1, proto.StepOne)
+	require.NoError(t, err)
+	require.Len(t, subtasks, 1)
+	require.Equal(t, "tidb2", subtasks[0].SchedulerID)
+	require.Equal(t, proto.TaskStatePending, subtasks[0].State)
+	require.Equal(t, []byte("checkpoint"), subtasks[0].Meta)
+	rowCount, err := sm.GetSubtaskRowCount(1, proto.StepOne)
+	require.NoError(t, err)
+	require.Equal(t, int64(100), rowCount)
+}
+
+func TestMoveSubtask(t *testing.T) {
+	pool := GetResourcePool(t)
+	sm := GetTaskManager(t, pool)
+	defer pool.Close()
+	require.NoError(t, sm.AddNewSubTask(1, proto.StepOne, "tidb1", []byte("test1"), proto.TaskTypeExample, false))
+	require.NoError(t, sm.AddNewSubTask(1, proto.StepOne, "tidb1", []byte("test2"), proto.TaskTypeExample, false))
+	moved, err := sm.MoveSubtask(1, "tidb1", "tidb2")
+	require.NoError(t, err)
+	require.True(t, moved)
+	// the subtask is not on the scheduler any more.
+	moved, err = sm.MoveSubtask(1, "tidb1", "tidb3")
+	require.NoError(t, err)
+	require.False(t, moved)
+	// the started subtask can't be moved.
+	require.NoError(t, sm.StartSubtask(2))
+	moved, err = sm.MoveSubtask(2, "tidb1", "tidb2")
+	require.NoError(t, err)
+	require.False(t, moved)
+
+	ids, err := sm.GetSchedulerIDsByTaskIDAndStep(1, proto.StepOne)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"tidb1", "tidb2"}, ids)
+}
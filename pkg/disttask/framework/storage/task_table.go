@@ -29,6 +29,7 @@ import (
 	"github.com/pingcap/tidb/pkg/kv"
 	"github.com/pingcap/tidb/pkg/parser/terror"
 	"github.com/pingcap/tidb/pkg/sessionctx"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/intest"
 	"github.com/pingcap/tidb/pkg/util/logutil"
@@ -39,6 +40,9 @@ import (
 
 const defaultSubtaskKeepDays = 14
 
+// originIDPath is the path of the origin subtask ID in the summary of a speculative subtask.
+var originIDPath, _ = types.ParseJSONPathExpr("$.origin_id")
+
 // SessionExecutor defines the interface for executing SQLs in a session.
 type SessionExecutor interface {
 	// WithNewSession executes the function with a new session.
@@ -115,6 +119,7 @@ func row2GlobeTask(r chunk.Row) *proto.Task {
 		Meta:         r.GetBytes(7),
 		Concurrency:  uint64(r.GetInt64(8)),
 		Step:         proto.Step(r.GetInt64(9)),
+		Priority:     int(r.GetInt64(11)),
 	}
 	if !r.IsNull(10) {
 		errBytes := r.GetBytes(10)
@@ -186,22 +191,27 @@ func (stm *TaskManager) executeSQLWithNewSession(ctx context.Context, sql string
 	return
 }
 
-// AddNewGlobalTask adds a new task to global task table.
+// AddNewGlobalTask adds a new task of normal priority to global task table.
 func (stm *TaskManager) AddNewGlobalTask(key string, tp proto.TaskType, concurrency int, meta []byte) (taskID int64, err error) {
+	return stm.AddNewGlobalTaskWithPriority(key, tp, concurrency, proto.NormalPriority, meta)
+}
+
+// AddNewGlobalTaskWithPriority adds a new task with the priority to global task table.
+func (stm *TaskManager) AddNewGlobalTaskWithPriority(key string, tp proto.TaskType, concurrency, priority int, meta []byte) (taskID int64, err error) {
 	err = stm.WithNewSession(func(se sessionctx.Context) error {
 		var err2 error
-		taskID, err2 = stm.AddGlobalTaskWithSession(se, key, tp, concurrency, meta)
+		taskID, err2 = stm.AddGlobalTaskWithSession(se, key, tp, concurrency, priority, meta)
 		return err2
 	})
 	return
 }
 
 // AddGlobalTaskWithSession adds a new task to global task table with session.
-func (stm *TaskManager) AddGlobalTaskWithSession(se sessionctx.Context, key string, tp proto.TaskType, concurrency int, meta []byte) (taskID int64, err error) {
+func (stm *TaskManager) AddGlobalTaskWithSession(se sessionctx.Context, key string, tp proto.TaskType, concurrency, priority int, meta []byte) (taskID int64, err error) {
 	_, err = ExecSQL(stm.ctx, se,
-		`insert into mysql.tidb_global_task(task_key, type, state, concurrency, step, meta, start_time, state_update_time)
-		values (%?, %?, %?, %?, %?, %?, CURRENT_TIMESTAMP(), CURRENT_TIMESTAMP())`,
-		key, tp, proto.TaskStatePending, concurrency, proto.StepInit, meta)
+		`insert into mysql.tidb_global_task(task_key, type, state, concurrency, step, meta, priority, start_time, state_update_time)
+		values (%?, %?, %?, %?, %?, %?, %?, CURRENT_TIMESTAMP(), CURRENT_TIMESTAMP())`,
+		key, tp, proto.TaskStatePending, concurrency, proto.StepInit, meta, priority)
 	if err != nil {
 		return 0, err
 	}
@@ -219,7 +229,7 @@ func (stm *TaskManager) AddGlobalTaskWithSession(se sessionctx.Context, key stri
 
 // GetNewGlobalTask get a new task from global task table, it's used by dispatcher only.
 func (stm *TaskManager) GetNewGlobalTask() (task *proto.Task, err error) {
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task where state = %? limit 1", proto.TaskStatePending)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task where state = %? order by priority, id limit 1", proto.TaskStatePending)
 	if err != nil {
 		return task, err
 	}
@@ -237,7 +247,7 @@ func (stm *TaskManager) GetGlobalTasksInStates(states ...interface{}) (task []*p
 		return task, nil
 	}
 
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task where state in ("+strings.Repeat("%?,", len(states)-1)+"%?)", states...)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task where state in ("+strings.Repeat("%?,", len(states)-1)+"%?) order by priority, id", states...)
 	if err != nil {
 		return task, err
 	}
@@ -254,7 +264,7 @@ func (stm *TaskManager) GetGlobalTasksFromHistoryInStates(states ...interface{})
 		return task, nil
 	}
 
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task_history where state in ("+strings.Repeat("%?,", len(states)-1)+"%?)", states...)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task_history where state in ("+strings.Repeat("%?,", len(states)-1)+"%?)", states...)
 	if err != nil {
 		return task, err
 	}
@@ -267,7 +277,7 @@ func (stm *TaskManager) GetGlobalTasksFromHistoryInStates(states ...interface{})
 
 // GetGlobalTaskByID gets the task by the global task ID.
 func (stm *TaskManager) GetGlobalTaskByID(taskID int64) (task *proto.Task, err error) {
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task where id = %?", taskID)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task where id = %?", taskID)
 	if err != nil {
 		return task, err
 	}
@@ -280,8 +290,8 @@ func (stm *TaskManager) GetGlobalTaskByID(taskID int64) (task *proto.Task, err e
 
 // GetTaskByIDWithHistory gets the task by the global task ID from both tidb_global_task and tidb_global_task_history.
 func (stm *TaskManager) GetTaskByIDWithHistory(taskID int64) (task *proto.Task, err error) {
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task where id = %? "+
-		"union select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task_history where id = %?", taskID, taskID)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task where id = %? "+
+		"union select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task_history where id = %?", taskID, taskID)
 	if err != nil {
 		return task, err
 	}
@@ -294,7 +304,7 @@ func (stm *TaskManager) GetTaskByIDWithHistory(taskID int64) (task *proto.Task,
 
 // GetGlobalTaskByKey gets the task by the task key.
 func (stm *TaskManager) GetGlobalTaskByKey(key string) (task *proto.Task, err error) {
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task where task_key = %?", key)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task where task_key = %?", key)
 	if err != nil {
 		return task, err
 	}
@@ -307,8 +317,8 @@ func (stm *TaskManager) GetGlobalTaskByKey(key string) (task *proto.Task, err er
 
 // GetGlobalTaskByKeyWithHistory gets the task from history table by the task key.
 func (stm *TaskManager) GetGlobalTaskByKeyWithHistory(key string) (task *proto.Task, err error) {
-	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task where task_key = %?"+
-		"union select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error from mysql.tidb_global_task_history where task_key = %?", key, key)
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, "select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task where task_key = %?"+
+		"union select id, task_key, type, dispatcher_id, state, start_time, state_update_time, meta, concurrency, step, error, priority from mysql.tidb_global_task_history where task_key = %?", key, key)
 	if err != nil {
 		return task, err
 	}
@@ -339,10 +349,16 @@ func row2SubTask(r chunk.Row) *proto.Subtask {
 		SchedulerID: r.GetString(6),
 		State:       proto.TaskState(r.GetString(8)),
 		Meta:        r.GetBytes(12),
-		Summary:     r.GetString(14),
 		StartTime:   startTime,
 		UpdateTime:  updateTime,
 	}
+	if !r.IsNull(14) {
+		summary := r.GetJSON(14)
+		task.Summary = summary.String()
+		if originID, found := summary.Extract([]types.JSONPathExpression{originIDPath}); found {
+			task.OriginID = originID.GetInt64()
+		}
+	}
 	tid, err := strconv.Atoi(r.GetString(3))
 	if err != nil {
 		logutil.BgLogger().Warn("unexpected task ID", zap.String("task ID", r.GetString(3)))
@@ -458,6 +474,99 @@ func (stm *TaskManager) GetSucceedSubtasksByStep(taskID int64, step proto.Step)
 	return subtasks, nil
 }
 
+// GetSubtasksByStep gets all the subtasks of the step.
+func (stm *TaskManager) GetSubtasksByStep(taskID int64, step proto.Step) ([]*proto.Subtask, error) {
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, `select * from mysql.tidb_background_subtask
+		where task_key = %? and step = %?`, taskID, step)
+	if err != nil {
+		return nil, err
+	}
+	subtasks := make([]*proto.Subtask, 0, len(rs))
+	for _, r := range rs {
+		subtasks = append(subtasks, row2SubTask(r))
+	}
+	return subtasks, nil
+}
+
+// AddSpeculativeSubtask adds a copy of the running subtask to the scheduler execID, which runs the
+// subtask again speculatively. The copy is linked to the origin subtask by the origin_id in its summary,
+// and it starts from the latest meta of the origin subtask.
+// It returns false if the origin subtask is not running or it already has a copy.
+func (stm *TaskManager) AddSpeculativeSubtask(subtaskID int64, execID string) (bool, error) {
+	added := false
+	err := stm.WithNewSession(func(se sessionctx.Context) error {
+		_, err := ExecSQL(stm.ctx, se, `insert into mysql.tidb_background_subtask
+			(step, task_key, exec_id, meta, state, type, checkpoint, summary)
+			select step, task_key, %?, meta, %?, type, checkpoint, json_object('origin_id', id)
+			from mysql.tidb_background_subtask where id = %? and state = %? and not exists (
+				select 1 from mysql.tidb_background_subtask where json_extract(summary, '$.origin_id') = %?)`,
+			execID, proto.TaskStatePending, subtaskID, proto.TaskStateRunning, subtaskID)
+		if err != nil {
+			return err
+		}
+		added = se.GetSessionVars().StmtCtx.AffectedRows() != 0
+		return nil
+	})
+	return added, err
+}
+
+// DiscardSubtask deletes the subtask whose output is discarded, such as the slower one of a subtask
+// and its speculative copy. The scheduler running it stops when it finds the subtask is deleted.
+func (stm *TaskManager) DiscardSubtask(subtaskID int64) error {
+	_, err := stm.executeSQLWithNewSession(stm.ctx, `delete from mysql.tidb_background_subtask where id = %?`, subtaskID)
+	return err
+}
+
+// RescheduleSubtask replaces the stuck running subtask with a pending copy on the scheduler execID,
+// which starts from the latest meta of the subtask. The scheduler running the stuck one stops when
+// it finds the subtask is deleted. It returns false if the subtask is not running any more.
+func (stm *TaskManager) RescheduleSubtask(subtaskID int64, execID string) (bool, error) {
+	rescheduled := false
+	err := stm.WithNewTxn(stm.ctx, func(se sessionctx.Context) error {
+		_, err := ExecSQL(stm.ctx, se, `insert into mysql.tidb_background_subtask
+			(step, task_key, exec_id, meta, state, type, checkpoint, summary)
+			select step, task_key, %?, meta, %?, type, checkpoint, summary
+			from mysql.tidb_background_subtask where id = %? and state = %?`,
+			execID, proto.TaskStatePending, subtaskID, proto.TaskStateRunning)
+		if err != nil {
+			return err
+		}
+		if se.GetSessionVars().StmtCtx.AffectedRows() == 0 {
+			return nil
+		}
+		rescheduled = true
+		_, err = ExecSQL(stm.ctx, se, `delete from mysql.tidb_background_subtask where id = %?`, subtaskID)
+		return err
+	})
+	return rescheduled, err
+}
+
+// MoveSubtask moves the pending subtask from the scheduler fromExecID to toExecID, it's used to
+// rebalance the subtasks when the nodes change. It returns false if the subtask has been started.
+func (stm *TaskManager) MoveSubtask(subtaskID int64, fromExecID, toExecID string) (bool, error) {
+	moved := false
+	err := stm.WithNewSession(func(se sessionctx.Context) error {
+		_, err := ExecSQL(stm.ctx, se, `update mysql.tidb_background_subtask set exec_id = %?
+			where id = %? and exec_id = %? and state = %?`,
+			toExecID, subtaskID, fromExecID, proto.TaskStatePending)
+		if err != nil {
+			return err
+		}
+		moved = se.GetSessionVars().StmtCtx.AffectedRows() != 0
+		return nil
+	})
+	return moved, err
+}
+
+// IsSubtaskDiscarded checks whether the subtask is discarded by the dispatcher.
+func (stm *TaskManager) IsSubtaskDiscarded(subtaskID int64) (bool, error) {
+	rs, err := stm.executeSQLWithNewSession(stm.ctx, `select 1 from mysql.tidb_background_subtask where id = %?`, subtaskID)
+	if err != nil {
+		return false, err
+	}
+	return len(rs) == 0, nil
+}
+
 // GetSubtaskRowCount gets the subtask row count.
 func (stm *TaskManager) GetSubtaskRowCount(taskID int64, step proto.Step) (int64, error) {
 	rs, err := stm.executeSQLWithNewSession(stm.ctx, `select
@@ -473,10 +582,10 @@ func (stm *TaskManager) GetSubtaskRowCount(taskID int64, step proto.Step) (int64
 	return rs[0].GetInt64(0), nil
 }
 
-// UpdateSubtaskRowCount updates the subtask row count.
+// UpdateSubtaskRowCount updates the subtask row count, it's taken as the progress of the subtask too.
 func (stm *TaskManager) UpdateSubtaskRowCount(subtaskID int64, rowCount int64) error {
 	_, err := stm.executeSQLWithNewSession(stm.ctx, `update mysql.tidb_background_subtask
-		set summary = json_set(summary, '$.row_count', %?) where id = %?`,
+		set summary = json_set(summary, '$.row_count', %?), state_update_time = unix_timestamp() where id = %?`,
 		rowCount, subtaskID)
 	return err
 }
@@ -554,6 +663,24 @@ func (stm *TaskManager) StartManager(tidbID string, role string) error {
 	return err
 }
 
+// UpdateSubtaskMeta updates the meta of a running subtask, it's used to record the checkpoint of the subtask.
+// The subtask which is being paused is also updated, since it records the checkpoint before it stops.
+func (stm *TaskManager) UpdateSubtaskMeta(subtaskID int64, meta []byte) error {
+	_, err := stm.executeSQLWithNewSession(stm.ctx, `update mysql.tidb_background_subtask
+		set meta = %?, state_update_time = unix_timestamp() where id = %? and state in (%?, %?)`,
+		meta, subtaskID, proto.TaskStateRunning, proto.TaskStatePaused)
+	return err
+}
+
+// UpdateGlobalTaskMeta updates the meta of a task which is not finished, it's used to change the
+// parameters of a running task, such as the concurrency.
+func (stm *TaskManager) UpdateGlobalTaskMeta(taskID int64, meta []byte) error {
+	_, err := stm.executeSQLWithNewSession(stm.ctx, `update mysql.tidb_global_task
+		set meta = %?, state_update_time = CURRENT_TIMESTAMP() where id = %? and state in (%?, %?, %?)`,
+		meta, taskID, proto.TaskStatePending, proto.TaskStateRunning, proto.TaskStatePaused)
+	return err
+}
+
 // UpdateSubtaskStateAndError updates the subtask state.
 func (stm *TaskManager) UpdateSubtaskStateAndError(id int64, state proto.TaskState, subTaskErr error) error {
 	_, err := stm.executeSQLWithNewSession(stm.ctx, `update mysql.tidb_background_subtask
@@ -875,6 +1002,21 @@ func (stm *TaskManager) ResumeTask(taskKey string) (bool, error) {
 	return found, nil
 }
 
+// GetSubtasksByType gets all the subtasks of the task type in the subtask table, the subtasks which
+// are transferred to the history table are not included.
+func (stm *TaskManager) GetSubtasksByType(tp proto.TaskType) ([]*proto.Subtask, error) {
+	rs, err := stm.executeSQLWithNewSession(stm.ctx,
+		"select * from mysql.tidb_background_subtask where type = %? order by id", proto.Type2Int(tp))
+	if err != nil {
+		return nil, err
+	}
+	subtasks := make([]*proto.Subtask, 0, len(rs))
+	for _, r := range rs {
+		subtasks = append(subtasks, row2SubTask(r))
+	}
+	return subtasks, nil
+}
+
 // GetSubtasksForImportInto gets the subtasks for import into(show import jobs).
 func (stm *TaskManager) GetSubtasksForImportInto(taskID int64, step proto.Step) ([]*proto.Subtask, error) {
 	var (
@@ -955,7 +1097,7 @@ func (stm *TaskManager) TransferTasks2History(tasks []*proto.Task) error {
 		insertSQL := new(strings.Builder)
 		if err := sqlexec.FormatSQL(insertSQL, "replace into mysql.tidb_global_task_history"+
 			"(id, task_key, type, dispatcher_id, state, start_time, state_update_time,"+
-			"meta, concurrency, step, error) values"); err != nil {
+			"meta, concurrency, step, error, priority) values"); err != nil {
 			return err
 		}
 
@@ -965,10 +1107,10 @@ func (stm *TaskManager) TransferTasks2History(tasks []*proto.Task) error {
 					return err
 				}
 			}
-			if err := sqlexec.FormatSQL(insertSQL, "(%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)",
+			if err := sqlexec.FormatSQL(insertSQL, "(%?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?, %?)",
 				task.ID, task.Key, task.Type, task.DispatcherID,
 				task.State, task.StartTime, task.StateUpdateTime,
-				task.Meta, task.Concurrency, task.Step, serializeErr(task.Error)); err != nil {
+				task.Meta, task.Concurrency, task.Step, serializeErr(task.Error), task.Priority); err != nil {
 				return err
 			}
 		}
@@ -76,6 +76,16 @@ func (c *AsyncOperator[T, R]) Close() error {
 	return nil
 }
 
+// TunePool tunes the worker number of the pool.
+func (c *AsyncOperator[T, R]) TunePool(workerNum int32) {
+	c.pool.Tune(workerNum)
+}
+
+// GetWorkerPoolSize returns the worker number of the pool.
+func (c *AsyncOperator[T, R]) GetWorkerPoolSize() int32 {
+	return c.pool.Cap()
+}
+
 // String show the name.
 func (*AsyncOperator[T, R]) String() string {
 	var zT T
@@ -1811,24 +1811,114 @@ func (do *Domain) BindHandle() *bindinfo.BindHandle {
 
 // LoadBindInfoLoop create a goroutine loads BindInfo in a loop, it should
 // be called only once in BootstrapSession.
-func (do *Domain) LoadBindInfoLoop(ctxForHandle sessionctx.Context, ctxForEvolve sessionctx.Context) error {
+func (do *Domain) LoadBindInfoLoop(ctxForHandle sessionctx.Context, ctxsForEvolve []sessionctx.Context) error {
 	ctxForHandle.GetSessionVars().InRestrictedSQL = true
-	ctxForEvolve.GetSessionVars().InRestrictedSQL = true
+	for _, ctx := range ctxsForEvolve {
+		ctx.GetSessionVars().InRestrictedSQL = true
+	}
 	if !do.bindHandle.CompareAndSwap(nil, bindinfo.NewBindHandle(ctxForHandle)) {
 		do.bindHandle.Load().Reset(ctxForHandle)
 	}
 
-	err := do.bindHandle.Load().Update(true)
-	if err != nil || bindinfo.Lease == 0 {
-		return err
+	if bindinfo.AsyncWarmUp {
+		do.warmUpBindCache()
+	} else {
+		if err := do.bindHandle.Load().Update(true); err != nil {
+			return err
+		}
+		do.reconcileBindingsFile()
+	}
+	if err := do.bindHandle.Load().ReplayPendingTasks(); err != nil {
+		logutil.BgLogger().Warn("replay pending bind tasks failed", zap.Error(err))
+	}
+	if bindinfo.Lease == 0 {
+		return nil
 	}
 
 	owner := do.newOwnerManager(bindinfo.Prompt, bindinfo.OwnerKey)
 	do.globalBindHandleWorkerLoop(owner)
-	do.handleEvolvePlanTasksLoop(ctxForEvolve, owner)
+	do.handleEvolvePlanTasksLoop(ctxsForEvolve, owner)
+	do.watchBindingChangeLoop()
 	return nil
 }
 
+// watchBindingChangeLoop creates a goroutine that watches the binding change key in etcd. Once a
+// binding is changed on any TiDB instance, the binding cache is reloaded immediately instead of
+// waiting for the next bind-info lease, so cached prepared plans of the digest are not reused.
+func (do *Domain) watchBindingChangeLoop() {
+	if do.etcdClient == nil {
+		return
+	}
+	watchCh := do.etcdClient.Watch(context.Background(), bindInfoChangeKey)
+	do.wg.Run(func() {
+		defer func() {
+			logutil.BgLogger().Info("watchBindingChangeLoop exited.")
+		}()
+		defer util.Recover(metrics.LabelDomain, "watchBindingChangeLoop", nil, false)
+
+		var count int
+		for {
+			var (
+				resp clientv3.WatchResponse
+				ok   bool
+			)
+			select {
+			case <-do.exit:
+				return
+			case resp, ok = <-watchCh:
+			}
+			if !ok {
+				logutil.BgLogger().Error("watchBindingChangeLoop watch channel closed")
+				watchCh = do.etcdClient.Watch(context.Background(), bindInfoChangeKey)
+				count++
+				if count > 10 {
+					time.Sleep(time.Duration(count) * time.Second)
+				}
+				continue
+			}
+			count = 0
+			for _, event := range resp.Events {
+				logutil.BgLogger().Debug("binding changed", zap.String("category", "sql-bind"), zap.ByteString("sqlDigest", event.Kv.Value))
+			}
+			if err := do.bindHandle.Load().Update(false); err != nil {
+				logutil.BgLogger().Error("update bindinfo failed", zap.Error(err))
+			}
+		}
+	}, "watchBindingChangeLoop")
+}
+
+// warmUpBindCache creates a goroutine that loads the bind records into the bind cache batch by batch.
+func (do *Domain) warmUpBindCache() {
+	do.wg.Run(func() {
+		defer util.Recover(metrics.LabelDomain, "warmUpBindCache", nil, false)
+		bindHandle := do.bindHandle.Load()
+		if err := bindHandle.WarmUp(bindinfo.WarmUpBatchSize, do.exit); err != nil {
+			logutil.BgLogger().Warn("warm up bind cache failed, reload all the bindings", zap.Error(err))
+			if err = bindHandle.ReloadBindings(); err != nil {
+				logutil.BgLogger().Error("reload bindings failed", zap.Error(err))
+			}
+		}
+		do.reconcileBindingsFile()
+	}, "warmUpBindCache")
+}
+
+// reconcileBindingsFile reconciles the global bindings with the bindings file if it is configured. It is
+// called after the bind cache is loaded since the existing bindings are looked up from the cache.
+func (do *Domain) reconcileBindingsFile() {
+	if bindinfo.BindingsFile == "" {
+		return
+	}
+	created, dropped, err := do.bindHandle.Load().ReconcileBindingsFile(bindinfo.BindingsFile, bindinfo.PruneBindingsFile)
+	if err != nil {
+		logutil.BgLogger().Error("reconcile bindings file failed", zap.String("file", bindinfo.BindingsFile), zap.Error(err))
+	}
+	if created > 0 || dropped > 0 {
+		logutil.BgLogger().Info("bindings file reconciled", zap.String("file", bindinfo.BindingsFile),
+			zap.Int("created", created), zap.Int("dropped", dropped))
+		do.NotifyUpdateBinding("")
+	}
+}
+
 func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 	do.wg.Run(func() {
 		defer func() {
@@ -1838,9 +1928,11 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 
 		bindWorkerTicker := time.NewTicker(bindinfo.Lease)
 		gcBindTicker := time.NewTicker(100 * bindinfo.Lease)
+		healthCheckTicker := time.NewTicker(bindinfo.HealthCheckInterval)
 		defer func() {
 			bindWorkerTicker.Stop()
 			gcBindTicker.Stop()
+			healthCheckTicker.Stop()
 		}()
 		for {
 			select {
@@ -1863,16 +1955,28 @@ func (do *Domain) globalBindHandleWorkerLoop(owner owner.Manager) {
 				if !owner.IsOwner() {
 					continue
 				}
-				err := do.bindHandle.Load().GCBindRecord()
+				_, err := do.bindHandle.Load().GCBindRecord()
 				if err != nil {
 					logutil.BgLogger().Error("GC bind record failed", zap.Error(err))
 				}
+				switched, err := do.bindHandle.Load().FailoverBindings()
+				if err != nil {
+					logutil.BgLogger().Error("failover bindings failed", zap.Error(err))
+				}
+				for _, sqlDigest := range switched {
+					do.NotifyUpdateBinding(sqlDigest)
+				}
+			case <-healthCheckTicker.C:
+				// The results are kept in memory, so every instance checks the bindings by itself.
+				if unhealthy := do.bindHandle.Load().CheckBindingsHealth(); unhealthy > 0 {
+					logutil.BgLogger().Warn("some bindings failed the health check", zap.String("category", "sql-bind"), zap.Int("count", unhealthy))
+				}
 			}
 		}
 	}, "globalBindHandleWorkerLoop")
 }
 
-func (do *Domain) handleEvolvePlanTasksLoop(ctx sessionctx.Context, owner owner.Manager) {
+func (do *Domain) handleEvolvePlanTasksLoop(ctxs []sessionctx.Context, owner owner.Manager) {
 	do.wg.Run(func() {
 		defer func() {
 			logutil.BgLogger().Info("handleEvolvePlanTasksLoop exited.")
@@ -1887,7 +1991,7 @@ func (do *Domain) handleEvolvePlanTasksLoop(ctx sessionctx.Context, owner owner.
 			case <-time.After(bindinfo.Lease):
 			}
 			if owner.IsOwner() {
-				err := do.bindHandle.Load().HandleEvolvePlanTask(ctx, false)
+				err := do.bindHandle.Load().HandleEvolvePlanTask(ctxs, false)
 				if err != nil {
 					logutil.BgLogger().Info("evolve plan failed", zap.Error(err))
 				}
@@ -2515,6 +2619,7 @@ func (do *Domain) ServerMemoryLimitHandle() *servermemorylimit.Handle {
 const (
 	privilegeKey          = "/tidb/privilege"
 	sysVarCacheKey        = "/tidb/sysvars"
+	bindInfoChangeKey     = "/tidb/bindinfo/change"
 	tiflashComputeNodeKey = "/tiflash/new_tiflash_compute_nodes"
 )
 
@@ -2568,6 +2673,22 @@ func (do *Domain) NotifyUpdateSysVarCache(updateLocal bool) {
 	}
 }
 
+// NotifyUpdateBinding updates the binding change key in etcd with the sql digest of the changed
+// binding. TiDB instances watching the key reload their binding cache at once, so the plans cached
+// by prepared statements for the digest are invalidated and the binding takes effect cluster-wide.
+// It is called when a global binding is created, dropped, enabled or disabled, so that a dropped or
+// disabled binding stops being used on other instances without waiting for the bind-info lease.
+// An empty sqlDigest means the bindings of several sql digests are changed.
+func (do *Domain) NotifyUpdateBinding(sqlDigest string) {
+	if do.etcdClient != nil {
+		row := do.etcdClient.KV
+		_, err := row.Put(context.Background(), bindInfoChangeKey, sqlDigest)
+		if err != nil {
+			logutil.BgLogger().Warn("notify update binding failed", zap.Error(err))
+		}
+	}
+}
+
 // LoadSigningCertLoop loads the signing cert periodically to make sure it's fresh new.
 func (do *Domain) LoadSigningCertLoop(signingCert, signingKey string) {
 	sessionstates.SetCertPath(signingCert)
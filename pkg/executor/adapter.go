@@ -1924,6 +1924,7 @@ func (a *ExecStmt) SummaryStmt(succ bool) {
 		Succeed:             succ,
 		PlanInCache:         sessVars.FoundInPlanCache,
 		PlanInBinding:       sessVars.FoundInBinding,
+		BindSQL:             stmtCtx.BindSQL,
 		ExecRetryCount:      a.retryCount,
 		StmtExecDetails:     stmtDetail,
 		ResultRows:          resultRows,
@@ -1935,6 +1936,10 @@ func (a *ExecStmt) SummaryStmt(succ bool) {
 	if a.retryCount > 0 {
 		stmtExecInfo.ExecRetryTime = costTime - sessVars.DurationParse - sessVars.DurationCompile - time.Since(a.retryStartTime)
 	}
+	if a.isPreparedStmt {
+		// The parameter values are sampled for the plan evolution of the prepared statements.
+		stmtExecInfo.ParamValues = sessVars.PlanCacheParams.AllParamValues()
+	}
 	stmtsummaryv2.Add(stmtExecInfo)
 }
 
@@ -21,8 +21,10 @@ import (
 	"github.com/pingcap/tidb/pkg/bindinfo"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
+	"github.com/pingcap/tidb/pkg/parser"
 	"github.com/pingcap/tidb/pkg/parser/ast"
 	plannercore "github.com/pingcap/tidb/pkg/planner/core"
+	"github.com/pingcap/tidb/pkg/sessionctx"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 )
 
@@ -42,6 +44,8 @@ type SQLBindExec struct {
 	source       string // by manual or from history, only in create stmt
 	sqlDigest    string
 	planDigest   string
+	comment      string
+	table        string
 }
 
 // Next implements the Executor Next interface.
@@ -54,6 +58,8 @@ func (e *SQLBindExec) Next(_ context.Context, req *chunk.Chunk) error {
 		return e.dropSQLBind()
 	case plannercore.OpSQLBindDropByDigest:
 		return e.dropSQLBindByDigest()
+	case plannercore.OpSQLBindDropByTable:
+		return e.dropSQLBindByTable()
 	case plannercore.OpFlushBindings:
 		return e.flushBindings()
 	case plannercore.OpCaptureBindings:
@@ -62,6 +68,8 @@ func (e *SQLBindExec) Next(_ context.Context, req *chunk.Chunk) error {
 		return e.evolveBindings()
 	case plannercore.OpReloadBindings:
 		return e.reloadBindings()
+	case plannercore.OpGCBindInfo:
+		return e.gcBindInfo()
 	case plannercore.OpSetBindingStatus:
 		return e.setBindingStatus()
 	case plannercore.OpSetBindingStatusByDigest:
@@ -86,8 +94,12 @@ func (e *SQLBindExec) dropSQLBind() error {
 		err := handle.DropBindRecord(e.normdOrigSQL, e.db, bindInfo)
 		return err
 	}
-	affectedRows, err := domain.GetDomain(e.Ctx()).BindHandle().DropBindRecord(e.normdOrigSQL, e.db, bindInfo)
+	dom := domain.GetDomain(e.Ctx())
+	affectedRows, err := dom.BindHandle().DropBindRecord(e.normdOrigSQL, e.db, bindInfo)
 	e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(affectedRows)
+	if err == nil && affectedRows > 0 {
+		dom.NotifyUpdateBinding(parser.DigestNormalized(e.normdOrigSQL).String())
+	}
 	return err
 }
 
@@ -100,8 +112,27 @@ func (e *SQLBindExec) dropSQLBindByDigest() error {
 		err := handle.DropBindRecordByDigest(e.sqlDigest)
 		return err
 	}
-	affectedRows, err := domain.GetDomain(e.Ctx()).BindHandle().DropBindRecordByDigest(e.sqlDigest)
+	dom := domain.GetDomain(e.Ctx())
+	affectedRows, err := dom.BindHandle().DropBindRecordByDigest(e.sqlDigest)
+	e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(affectedRows)
+	if err == nil && affectedRows > 0 {
+		dom.NotifyUpdateBinding(e.sqlDigest)
+	}
+	return err
+}
+
+func (e *SQLBindExec) dropSQLBindByTable() error {
+	if !e.isGlobal {
+		handle := e.Ctx().Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
+		return handle.DropBindingsByTable(e.db, e.table)
+	}
+	dom := domain.GetDomain(e.Ctx())
+	affectedRows, err := dom.BindHandle().DropBindingsByTable(e.db, e.table)
 	e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(affectedRows)
+	if err == nil && affectedRows > 0 {
+		// The bindings of several sql digests may be dropped, notify without a specific digest.
+		dom.NotifyUpdateBinding("")
+	}
 	return err
 }
 
@@ -114,20 +145,28 @@ func (e *SQLBindExec) setBindingStatus() error {
 			Collation: e.collation,
 		}
 	}
-	ok, err := domain.GetDomain(e.Ctx()).BindHandle().SetBindRecordStatus(e.normdOrigSQL, bindInfo, e.newStatus)
+	dom := domain.GetDomain(e.Ctx())
+	ok, err := dom.BindHandle().SetBindRecordStatus(e.normdOrigSQL, bindInfo, e.newStatus)
 	if err == nil && !ok {
 		warningMess := errors.New("There are no bindings can be set the status. Please check the SQL text")
 		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(warningMess)
 	}
+	if err == nil && ok {
+		dom.NotifyUpdateBinding(parser.DigestNormalized(e.normdOrigSQL).String())
+	}
 	return err
 }
 
 func (e *SQLBindExec) setBindingStatusByDigest() error {
-	ok, err := domain.GetDomain(e.Ctx()).BindHandle().SetBindRecordStatusByDigest(e.newStatus, e.sqlDigest)
+	dom := domain.GetDomain(e.Ctx())
+	ok, err := dom.BindHandle().SetBindRecordStatusByDigest(e.newStatus, e.sqlDigest)
 	if err == nil && !ok {
 		warningMess := errors.New("There are no bindings can be set the status. Please check the SQL text")
 		e.Ctx().GetSessionVars().StmtCtx.AppendWarning(warningMess)
 	}
+	if err == nil && ok {
+		dom.NotifyUpdateBinding(e.sqlDigest)
+	}
 	return err
 }
 
@@ -151,6 +190,7 @@ func (e *SQLBindExec) createSQLBind() error {
 		Source:     e.source,
 		SQLDigest:  e.sqlDigest,
 		PlanDigest: e.planDigest,
+		Comment:    e.comment,
 	}
 	record := &bindinfo.BindRecord{
 		OriginalSQL: e.normdOrigSQL,
@@ -161,7 +201,12 @@ func (e *SQLBindExec) createSQLBind() error {
 		handle := e.Ctx().Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
 		return handle.CreateBindRecord(e.Ctx(), record)
 	}
-	return domain.GetDomain(e.Ctx()).BindHandle().CreateBindRecord(e.Ctx(), record)
+	dom := domain.GetDomain(e.Ctx())
+	if err := dom.BindHandle().CreateBindRecord(e.Ctx(), record); err != nil {
+		return err
+	}
+	dom.NotifyUpdateBinding(parser.DigestNormalized(e.normdOrigSQL).String())
+	return nil
 }
 
 func (e *SQLBindExec) flushBindings() error {
@@ -173,9 +218,48 @@ func (e *SQLBindExec) captureBindings() {
 }
 
 func (e *SQLBindExec) evolveBindings() error {
-	return domain.GetDomain(e.Ctx()).BindHandle().HandleEvolvePlanTask(e.Ctx(), true)
+	return domain.GetDomain(e.Ctx()).BindHandle().HandleEvolvePlanTask([]sessionctx.Context{e.Ctx()}, true)
 }
 
 func (e *SQLBindExec) reloadBindings() error {
 	return domain.GetDomain(e.Ctx()).BindHandle().ReloadBindings()
 }
+
+func (e *SQLBindExec) gcBindInfo() error {
+	deletedRows, err := domain.GetDomain(e.Ctx()).BindHandle().GCBindRecord()
+	e.Ctx().GetSessionVars().StmtCtx.AddAffectedRows(deletedRows)
+	return err
+}
+
+// AdminCheckBindingsExec is an executor for ADMIN CHECK BINDINGS.
+type AdminCheckBindingsExec struct {
+	exec.BaseExecutor
+	done bool
+}
+
+// Next implements the Executor Next interface.
+func (e *AdminCheckBindingsExec) Next(_ context.Context, req *chunk.Chunk) error {
+	req.Reset()
+	if e.done {
+		return nil
+	}
+	e.done = true
+	conflicts, err := domain.GetDomain(e.Ctx()).BindHandle().CheckBindings()
+	if err != nil {
+		return err
+	}
+	for _, conflict := range conflicts {
+		for _, record := range conflict.Bindings {
+			for _, binding := range record.Bindings {
+				req.AppendString(0, conflict.SQLDigest)
+				req.AppendString(1, conflict.OriginalSQL)
+				req.AppendString(2, conflict.Type)
+				req.AppendString(3, record.Db)
+				req.AppendString(4, binding.BindSQL)
+				req.AppendString(5, binding.Status)
+				req.AppendString(6, conflict.Reason)
+			}
+		}
+	}
+	return nil
+}
@@ -310,6 +310,8 @@ func (b *executorBuilder) build(p plannercore.Plan) exec.Executor {
 		return b.buildAdminShowTelemetry(v)
 	case *plannercore.AdminResetTelemetryID:
 		return b.buildAdminResetTelemetryID(v)
+	case *plannercore.AdminCheckBindings:
+		return b.buildAdminCheckBindings(v)
 	case *plannercore.PhysicalCTE:
 		return b.buildCTE(v)
 	case *plannercore.PhysicalCTETable:
@@ -2116,7 +2118,11 @@ func (b *executorBuilder) buildMemTable(v *plannercore.PhysicalMemTable) exec.Ex
 			strings.ToLower(infoschema.ClusterTableMemoryUsageOpsHistory),
 			strings.ToLower(infoschema.TableResourceGroups),
 			strings.ToLower(infoschema.TableRunawayWatches),
-			strings.ToLower(infoschema.TableCheckConstraints):
+			strings.ToLower(infoschema.TableCheckConstraints),
+			strings.ToLower(infoschema.TableBindingsCacheStatus),
+			strings.ToLower(infoschema.TableBindingsCoverage),
+			strings.ToLower(infoschema.TableBindingsPlanDrift),
+			strings.ToLower(infoschema.TableDDLBackfillSubtasks):
 			return &MemTableReaderExec{
 				BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID()),
 				table:        v.Table,
@@ -4912,6 +4918,8 @@ func (b *executorBuilder) buildSQLBindExec(v *plannercore.SQLBindPlan) exec.Exec
 		source:       v.Source,
 		sqlDigest:    v.SQLDigest,
 		planDigest:   v.PlanDigest,
+		comment:      v.Comment,
+		table:        v.Table,
 	}
 	return e
 }
@@ -5147,6 +5155,10 @@ func (b *executorBuilder) buildAdminResetTelemetryID(v *plannercore.AdminResetTe
 	return &AdminResetTelemetryIDExec{BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())}
 }
 
+func (b *executorBuilder) buildAdminCheckBindings(v *plannercore.AdminCheckBindings) exec.Executor {
+	return &AdminCheckBindingsExec{BaseExecutor: exec.NewBaseExecutor(b.ctx, v.Schema(), v.ID())}
+}
+
 func (builder *dataReaderBuilder) partitionPruning(tbl table.PartitionedTable, conds []expression.Expression, partitionNames []model.CIStr,
 	columns []*expression.Column, columnNames types.NameSlice) ([]table.PhysicalTable, error) {
 	builder.once.Do(func() {
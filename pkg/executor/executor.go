@@ -27,6 +27,7 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/docker/go-units"
 	"github.com/opentracing/opentracing-go"
 	"github.com/pingcap/errors"
 	"github.com/pingcap/failpoint"
@@ -472,7 +473,7 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 	req.AppendInt64(0, job.ID)
 	req.AppendString(1, schemaName)
 	req.AppendString(2, tableName)
-	req.AppendString(3, job.Type.String()+showAddIdxReorgTp(job))
+	req.AppendString(3, job.Type.String()+showAddIdxReorgTp(job, e.TZLoc))
 	req.AppendString(4, job.SchemaState.String())
 	req.AppendInt64(5, job.SchemaID)
 	req.AppendInt64(6, job.TableID)
@@ -516,7 +517,7 @@ func (e *DDLJobRetriever) appendJobToChunk(req *chunk.Chunk, job *model.Job, che
 	}
 }
 
-func showAddIdxReorgTp(job *model.Job) string {
+func showAddIdxReorgTp(job *model.Job, loc *time.Location) string {
 	if job.Type == model.ActionAddIndex || job.Type == model.ActionAddPrimaryKey {
 		if job.ReorgMeta != nil {
 			sb := strings.Builder{}
@@ -527,6 +528,19 @@ func showAddIdxReorgTp(job *model.Job) string {
 				if job.ReorgMeta.UseCloudStorage {
 					sb.WriteString(" cloud")
 				}
+				// The progress estimated by the distributed reorganization.
+				if job.State == model.JobStateRunning && job.ReorgMeta.Throughput > 0 {
+					fmt.Fprintf(&sb, ", %.0f rows/s", job.ReorgMeta.Throughput)
+					if job.ReorgMeta.ETA > 0 {
+						sb.WriteString(", eta ")
+						sb.WriteString(time.Unix(job.ReorgMeta.ETA, 0).In(loc).Format(time.DateTime))
+					}
+				}
+				if stats := job.ReorgMeta.GlobalSortStats; stats != nil {
+					fmt.Fprintf(&sb, ", sorted %s in %d data files and %d stat files, %d merge sort passes, %d range splits",
+						units.BytesSize(float64(stats.TotalKVSize)), stats.DataFiles, stats.StatFiles,
+						stats.MergeSortPasses, stats.RangeSplits)
+				}
 				sb.WriteString(" */")
 			}
 			return sb.String()
@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/pkg/executor/internal/exec"
 	"github.com/pingcap/tidb/pkg/expression"
 	"github.com/pingcap/tidb/pkg/kv"
+	"github.com/pingcap/tidb/pkg/parser/model"
 	plannerutil "github.com/pingcap/tidb/pkg/planner/util"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/tablecodec"
@@ -270,6 +271,31 @@ func TestFilterTemporaryTableKeys(t *testing.T) {
 	require.Len(t, res, 1)
 }
 
+func TestShowAddIdxReorgTp(t *testing.T) {
+	job := &model.Job{
+		Type:  model.ActionAddIndex,
+		State: model.JobStateRunning,
+		ReorgMeta: &model.DDLReorgMeta{
+			ReorgTp:         model.ReorgTypeLitMerge,
+			UseCloudStorage: true,
+		},
+	}
+	require.Equal(t, " /* ingest cloud */", showAddIdxReorgTp(job, time.UTC))
+	job.ReorgMeta.Throughput = 1234.5
+	require.Equal(t, " /* ingest cloud, 1234 rows/s */", showAddIdxReorgTp(job, time.UTC))
+	job.ReorgMeta.ETA = time.Date(2023, 11, 1, 10, 20, 30, 0, time.UTC).Unix()
+	require.Equal(t, " /* ingest cloud, 1234 rows/s, eta 2023-11-01 10:20:30 */", showAddIdxReorgTp(job, time.UTC))
+	// the progress is only shown for the running job.
+	job.State = model.JobStateDone
+	require.Equal(t, " /* ingest cloud */", showAddIdxReorgTp(job, time.UTC))
+	// the statistics of the global sort are kept after the job is done.
+	job.ReorgMeta.GlobalSortStats = &model.GlobalSortStats{
+		TotalKVSize: 3 * 1024 * 1024, DataFiles: 4, StatFiles: 4, MergeSortPasses: 1, RangeSplits: 2,
+	}
+	require.Equal(t, " /* ingest cloud, sorted 3MiB in 4 data files and 4 stat files, 1 merge sort passes, 2 range splits */",
+		showAddIdxReorgTp(job, time.UTC))
+}
+
 func TestSortSpillDisk(t *testing.T) {
 	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/executor/testSortedRowContainerSpill", "return(true)"))
 	defer func() {
@@ -926,7 +926,13 @@ func (e *LoadDataController) InitDataStore(ctx context.Context) error {
 		if err != nil {
 			return err
 		}
-		e.GlobalSortStore = s
+		// the sorted KV and stat files contain the raw data, encrypt them if the
+		// master key is configured.
+		masterKey, err4 := tidb.GetGlobalConfig().Security.LoadMasterKey()
+		if err4 != nil {
+			return err4
+		}
+		e.GlobalSortStore = storage.WithEncryption(s, masterKey)
 	}
 	return nil
 }
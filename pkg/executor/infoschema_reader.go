@@ -31,8 +31,11 @@ import (
 	"github.com/pingcap/kvproto/pkg/deadlock"
 	"github.com/pingcap/kvproto/pkg/kvrpcpb"
 	rmpb "github.com/pingcap/kvproto/pkg/resource_manager"
+	"github.com/pingcap/tidb/pkg/ddl"
 	"github.com/pingcap/tidb/pkg/ddl/label"
 	"github.com/pingcap/tidb/pkg/ddl/placement"
+	"github.com/pingcap/tidb/pkg/disttask/framework/proto"
+	fstorage "github.com/pingcap/tidb/pkg/disttask/framework/storage"
 	"github.com/pingcap/tidb/pkg/domain"
 	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/pingcap/tidb/pkg/domain/resourcegroup"
@@ -195,6 +198,14 @@ func (e *memtableRetriever) retrieve(ctx context.Context, sctx sessionctx.Contex
 			err = e.setDataFromRunawayWatches(sctx)
 		case infoschema.TableCheckConstraints:
 			err = e.setDataFromCheckConstraints(sctx, dbs)
+		case infoschema.TableBindingsCacheStatus:
+			e.setDataForBindingsCacheStatus(sctx)
+		case infoschema.TableBindingsCoverage:
+			e.setDataForBindingsCoverage(sctx)
+		case infoschema.TableBindingsPlanDrift:
+			e.setDataForBindingsPlanDrift(sctx)
+		case infoschema.TableDDLBackfillSubtasks:
+			err = e.setDataForDDLBackfillSubtasks(sctx)
 		}
 		if err != nil {
 			return nil, err
@@ -656,6 +667,157 @@ func (e *memtableRetriever) setDataFromCheckConstraints(sctx sessionctx.Context,
 	return nil
 }
 
+func (e *memtableRetriever) setDataForBindingsCacheStatus(sctx sessionctx.Context) {
+	bindHandle := domain.GetDomain(sctx).BindHandle()
+	if bindHandle == nil {
+		return
+	}
+	status := bindHandle.GetBindCacheStatus()
+	rows := make([][]types.Datum, 0, len(status.Records))
+	for _, record := range status.Records {
+		bindingCount, originalSQL, db := 0, "", ""
+		for _, bindRecord := range record.Records {
+			bindingCount += len(bindRecord.Bindings)
+			originalSQL, db = bindRecord.OriginalSQL, bindRecord.Db
+		}
+		rows = append(rows, types.MakeDatums(
+			record.SQLDigest,    // SQL_DIGEST
+			originalSQL,         // ORIGINAL_SQL
+			db,                  // DEFAULT_DB
+			bindingCount,        // BINDING_COUNT
+			record.MemUsage,     // MEMORY_USAGE
+			status.MemUsage,     // CACHE_MEMORY_USAGE
+			status.MemCapacity,  // CACHE_MEMORY_CAPACITY
+			status.EvictedCount, // CACHE_EVICTED_COUNT
+		))
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataForBindingsCoverage(sctx sessionctx.Context) {
+	bindHandle := domain.GetDomain(sctx).BindHandle()
+	if bindHandle == nil {
+		return
+	}
+	// The time window is the whole statement summary in memory.
+	coverages := bindHandle.GetBindingsCoverage(time.Time{})
+	rows := make([][]types.Datum, 0, len(coverages))
+	for _, coverage := range coverages {
+		var lastMatchedTime any
+		if !coverage.LastMatchedTime.IsZero() {
+			lastMatchedTime = types.NewTime(types.FromGoTime(coverage.LastMatchedTime.In(sctx.GetSessionVars().Location())), mysql.TypeTimestamp, 0)
+		}
+		rows = append(rows, types.MakeDatums(
+			coverage.OriginalSQL,                    // ORIGINAL_SQL
+			coverage.BindSQL,                        // BIND_SQL
+			coverage.Db,                             // DEFAULT_DB
+			coverage.Status,                         // STATUS
+			coverage.Source,                         // SOURCE
+			coverage.SQLDigest,                      // SQL_DIGEST
+			strings.Join(coverage.StmtDigests, ","), // MATCHED_DIGESTS
+			coverage.ExecCount,                      // MATCHED_EXEC_COUNT
+			lastMatchedTime,                         // LAST_MATCHED_TIME
+		))
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataForBindingsPlanDrift(sctx sessionctx.Context) {
+	bindHandle := domain.GetDomain(sctx).BindHandle()
+	if bindHandle == nil {
+		return
+	}
+	drifts := bindHandle.GetPlanDrifts()
+	rows := make([][]types.Datum, 0, len(drifts))
+	for _, drift := range drifts {
+		lastDriftTime := types.NewTime(types.FromGoTime(drift.LastDriftTime.In(sctx.GetSessionVars().Location())), mysql.TypeTimestamp, 0)
+		rows = append(rows, types.MakeDatums(
+			drift.OriginalSQL,        // ORIGINAL_SQL
+			drift.BindSQL,            // BIND_SQL
+			drift.Db,                 // DEFAULT_DB
+			drift.Scope,              // SCOPE
+			drift.ExpectedPlanDigest, // EXPECTED_PLAN_DIGEST
+			drift.ActualPlanDigest,   // ACTUAL_PLAN_DIGEST
+			drift.DriftCount,         // DRIFT_COUNT
+			lastDriftTime,            // LAST_DRIFT_TIME
+		))
+	}
+	e.rows = rows
+}
+
+func (e *memtableRetriever) setDataForDDLBackfillSubtasks(sctx sessionctx.Context) error {
+	if !hasPriv(sctx, mysql.ProcessPriv) {
+		return plannercore.ErrSpecificAccessDenied.GenWithStackByArgs("PROCESS")
+	}
+	taskMgr, err := fstorage.GetTaskManager()
+	if err != nil {
+		return err
+	}
+	subtasks, err := taskMgr.GetSubtasksByType(proto.Backfill)
+	if err != nil {
+		return err
+	}
+	loc := sctx.GetSessionVars().Location()
+	toDatetime := func(t time.Time) any {
+		if t.IsZero() || t.Unix() == 0 {
+			return nil
+		}
+		return types.NewTime(types.FromGoTime(t.In(loc)), mysql.TypeDatetime, 0)
+	}
+	jobIDs := make(map[int64]int64)
+	rows := make([][]types.Datum, 0, len(subtasks))
+	for _, st := range subtasks {
+		jobID, ok := jobIDs[st.TaskID]
+		if !ok {
+			task, err := taskMgr.GetTaskByIDWithHistory(st.TaskID)
+			if err != nil {
+				return err
+			}
+			if task != nil {
+				var taskMeta ddl.BackfillGlobalMeta
+				if err := json.Unmarshal(task.Meta, &taskMeta); err != nil {
+					return err
+				}
+				jobID = taskMeta.Job.ID
+			}
+			jobIDs[st.TaskID] = jobID
+		}
+		var meta ddl.BackfillSubTaskMeta
+		if err := json.Unmarshal(st.Meta, &meta); err != nil {
+			return err
+		}
+		kvSize := meta.TotalKVSize
+		for _, g := range meta.MetaGroups {
+			kvSize += g.TotalKVSize
+		}
+		var summary struct {
+			RowCount int64 `json:"row_count"`
+		}
+		if len(st.Summary) > 0 {
+			if err := json.Unmarshal([]byte(st.Summary), &summary); err != nil {
+				return err
+			}
+		}
+		rows = append(rows, types.MakeDatums(
+			jobID,                             // JOB_ID
+			st.TaskID,                         // TASK_ID
+			st.ID,                             // SUBTASK_ID
+			ddl.StepStr(st.Step),              // STEP
+			string(st.State),                  // STATE
+			st.SchedulerID,                    // EXEC_ID
+			meta.PhysicalTableID,              // PHYSICAL_TABLE_ID
+			hex.EncodeToString(meta.StartKey), // START_KEY
+			hex.EncodeToString(meta.EndKey),   // END_KEY
+			summary.RowCount,                  // ROW_COUNT
+			kvSize,                            // KV_SIZE
+			toDatetime(st.StartTime),          // START_TIME
+			toDatetime(st.UpdateTime),         // UPDATE_TIME
+		))
+	}
+	e.rows = rows
+	return nil
+}
+
 func (e *hugeMemTableRetriever) setDataForColumns(ctx context.Context, sctx sessionctx.Context, extractor *plannercore.ColumnsTableExtractor) error {
 	checker := privilege.GetPrivilegeManager(sctx)
 	e.rows = e.rows[:0]
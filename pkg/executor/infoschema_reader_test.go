@@ -266,6 +266,34 @@ func TestDDLJobs(t *testing.T) {
 		testkit.Rows("test_ddl_jobs tt alter table multi-schema change", "test_ddl_jobs tt add column /* subjob */", "test_ddl_jobs tt add index /* subjob */ /* txn-merge */"))
 }
 
+func TestDDLBackfillSubtasks(t *testing.T) {
+	store := testkit.CreateMockStore(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustQuery("select * from information_schema.ddl_backfill_subtasks").Check(testkit.Rows())
+
+	tk.MustExec(`insert into mysql.tidb_global_task(id, task_key, type, state, meta, step)
+		values (1, 'ddl/backfill/100', 'backfill', 'running', '{"job":{"id":100}}', 1)`)
+	tk.MustExec(`insert into mysql.tidb_background_subtask(task_key, step, exec_id, meta, state, type, checkpoint, summary, start_time)
+		values ('1', 1, '127.0.0.1:4000', '{"physical_table_id":101,"start_key":"dAE=","end_key":"dAI="}', 'running', 0, '', '{"row_count":10}', unix_timestamp()),
+		('1', 2, '127.0.0.1:4001', '{"physical_table_id":101,"total-kv-size":20,"meta_groups":[{"total-kv-size":30}]}', 'pending', 0, '', '{}', null)`)
+	tk.MustQuery(`select job_id, task_id, step, state, exec_id, physical_table_id, start_key, end_key, row_count, kv_size,
+		start_time is null from information_schema.ddl_backfill_subtasks order by subtask_id`).Check(testkit.Rows(
+		"100 1 read-index running 127.0.0.1:4000 101 7401 7402 10 0 0",
+		"100 1 merge-sort pending 127.0.0.1:4001 101   0 50 1",
+	))
+
+	// the subtasks of the other task types are not shown.
+	tk.MustExec(`insert into mysql.tidb_background_subtask(task_key, step, exec_id, meta, state, type, checkpoint, summary)
+		values ('2', 1, '127.0.0.1:4000', '{}', 'running', 2, '', '{}')`)
+	tk.MustQuery("select count(*) from information_schema.ddl_backfill_subtasks").Check(testkit.Rows("2"))
+
+	tk.MustExec("create user ddl_backfill_subtasks_tester")
+	tk1 := testkit.NewTestKit(t, store)
+	require.NoError(t, tk1.Session().Auth(&auth.UserIdentity{Username: "ddl_backfill_subtasks_tester", Hostname: "127.0.0.1"}, nil, nil, nil))
+	err := tk1.QueryToErr("select * from information_schema.ddl_backfill_subtasks")
+	require.ErrorContains(t, err, "PROCESS")
+}
+
 func TestKeyColumnUsage(t *testing.T) {
 	store := testkit.CreateMockStore(t)
 	tk := testkit.NewTestKit(t, store)
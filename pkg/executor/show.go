@@ -379,6 +379,23 @@ func (e *ShowExec) fetchShowBind() error {
 			if !checker.ok {
 				continue
 			}
+			var comment any
+			if hint.Comment != "" {
+				comment = hint.Comment
+			}
+			// Only the captured bindings have the provenance.
+			var captureUsers, captureSampleSQL, captureAvgLatency, captureExecCount any
+			if hint.Source == bindinfo.Capture {
+				captureUsers, captureSampleSQL = hint.CaptureUsers, hint.CaptureSampleSQL
+				captureAvgLatency, captureExecCount = hint.CaptureAvgLatency, hint.CaptureExecCount
+			}
+			// The health of the bindings is only checked for the global bindings.
+			var healthCheckFailure any
+			if e.GlobalScope {
+				if reason := domain.GetDomain(e.Ctx()).BindHandle().GetBindingHealthCheckFailure(bindData.OriginalSQL, bindData.Db, hint.BindSQL); reason != "" {
+					healthCheckFailure = reason
+				}
+			}
 			e.appendRow([]any{
 				bindData.OriginalSQL,
 				hint.BindSQL,
@@ -391,6 +408,12 @@ func (e *ShowExec) fetchShowBind() error {
 				hint.Source,
 				hint.SQLDigest,
 				hint.PlanDigest,
+				comment,
+				captureUsers,
+				captureSampleSQL,
+				captureAvgLatency,
+				captureExecCount,
+				healthCheckFailure,
 			})
 		}
 	}
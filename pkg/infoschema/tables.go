@@ -209,6 +209,14 @@ const (
 	TableRunawayWatches = "RUNAWAY_WATCHES"
 	// TableCheckConstraints is the list of CHECK constraints.
 	TableCheckConstraints = "CHECK_CONSTRAINTS"
+	// TableBindingsCacheStatus is the memory usage and eviction status of the bind cache.
+	TableBindingsCacheStatus = "BINDINGS_CACHE_STATUS"
+	// TableBindingsCoverage is the matching status of the bindings in the statement summary.
+	TableBindingsCoverage = "BINDINGS_COVERAGE"
+	// TableBindingsPlanDrift is the bindings whose generated plans don't match their plan digests.
+	TableBindingsPlanDrift = "BINDINGS_PLAN_DRIFT"
+	// TableDDLBackfillSubtasks is the progress of the subtasks of the distributed backfill tasks.
+	TableDDLBackfillSubtasks = "DDL_BACKFILL_SUBTASKS"
 )
 
 const (
@@ -318,6 +326,10 @@ var tableIDMap = map[string]int64{
 	TableResourceGroups:                  autoid.InformationSchemaDBID + 88,
 	TableRunawayWatches:                  autoid.InformationSchemaDBID + 89,
 	TableCheckConstraints:                autoid.InformationSchemaDBID + 90,
+	TableBindingsCacheStatus:             autoid.InformationSchemaDBID + 91,
+	TableBindingsCoverage:                autoid.InformationSchemaDBID + 92,
+	TableBindingsPlanDrift:               autoid.InformationSchemaDBID + 93,
+	TableDDLBackfillSubtasks:             autoid.InformationSchemaDBID + 94,
 }
 
 // columnInfo represents the basic column information of all kinds of INFORMATION_SCHEMA tables
@@ -1637,6 +1649,56 @@ var tableCheckConstraintsCols = []columnInfo{
 	{name: "CHECK_CLAUSE", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength, flag: mysql.NotNullFlag},
 }
 
+var tableBindingsCacheStatusCols = []columnInfo{
+	{name: "SQL_DIGEST", tp: mysql.TypeVarchar, size: 64},
+	{name: "ORIGINAL_SQL", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "DEFAULT_DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "BINDING_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Number of the bindings of the statement in the cache"},
+	{name: "MEMORY_USAGE", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Memory usage of the bindings of the statement in bytes"},
+	{name: "CACHE_MEMORY_USAGE", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Total memory usage of the bind cache in bytes"},
+	{name: "CACHE_MEMORY_CAPACITY", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Memory capacity of the bind cache in bytes"},
+	{name: "CACHE_EVICTED_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Number of the bind records evicted from the bind cache"},
+}
+
+var tableBindingsCoverageCols = []columnInfo{
+	{name: "ORIGINAL_SQL", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "BIND_SQL", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "DEFAULT_DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "STATUS", tp: mysql.TypeVarchar, size: 64},
+	{name: "SOURCE", tp: mysql.TypeVarchar, size: 64},
+	{name: "SQL_DIGEST", tp: mysql.TypeVarchar, size: 64},
+	{name: "MATCHED_DIGESTS", tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "Digests of the statements in the statement summary whose plans are generated by the binding"},
+	{name: "MATCHED_EXEC_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Number of the executions whose plans are generated by the binding"},
+	{name: "LAST_MATCHED_TIME", tp: mysql.TypeTimestamp, size: 26, comment: "The last time the binding is matched, NULL if it is never matched"},
+}
+
+var tableBindingsPlanDriftCols = []columnInfo{
+	{name: "ORIGINAL_SQL", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "BIND_SQL", tp: mysql.TypeLongBlob, size: types.UnspecifiedLength},
+	{name: "DEFAULT_DB", tp: mysql.TypeVarchar, size: 64},
+	{name: "SCOPE", tp: mysql.TypeVarchar, size: 64},
+	{name: "EXPECTED_PLAN_DIGEST", tp: mysql.TypeVarchar, size: 64, comment: "The plan digest of the binding"},
+	{name: "ACTUAL_PLAN_DIGEST", tp: mysql.TypeVarchar, size: 64, comment: "The digest of the plan generated by the binding last time"},
+	{name: "DRIFT_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Number of the plans generated by the binding which don't match the plan digest"},
+	{name: "LAST_DRIFT_TIME", tp: mysql.TypeTimestamp, size: 26},
+}
+
+var tableDDLBackfillSubtasksCols = []columnInfo{
+	{name: "JOB_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "TASK_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "SUBTASK_ID", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag},
+	{name: "STEP", tp: mysql.TypeVarchar, size: 64},
+	{name: "STATE", tp: mysql.TypeVarchar, size: 64},
+	{name: "EXEC_ID", tp: mysql.TypeVarchar, size: 256, comment: "The TiDB node which the subtask is dispatched to"},
+	{name: "PHYSICAL_TABLE_ID", tp: mysql.TypeLonglong, size: 21},
+	{name: "START_KEY", tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "The start key of the subtask in hex"},
+	{name: "END_KEY", tp: mysql.TypeBlob, size: types.UnspecifiedLength, comment: "The end key of the subtask in hex"},
+	{name: "ROW_COUNT", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Number of the rows read by the subtask"},
+	{name: "KV_SIZE", tp: mysql.TypeLonglong, size: 21, flag: mysql.NotNullFlag, comment: "Size of the sorted KV written or handled by the subtask in bytes, only for global sort"},
+	{name: "START_TIME", tp: mysql.TypeDatetime, size: 19},
+	{name: "UPDATE_TIME", tp: mysql.TypeDatetime, size: 19},
+}
+
 // GetShardingInfo returns a nil or description string for the sharding information of given TableInfo.
 // The returned description string may be:
 //   - "NOT_SHARDED": for tables that SHARD_ROW_ID_BITS is not specified.
@@ -2175,6 +2237,10 @@ var tableNameToColumns = map[string][]columnInfo{
 	TableResourceGroups:                     tableResourceGroupsCols,
 	TableRunawayWatches:                     tableRunawayWatchListCols,
 	TableCheckConstraints:                   tableCheckConstraintsCols,
+	TableBindingsCacheStatus:                tableBindingsCacheStatusCols,
+	TableBindingsCoverage:                   tableBindingsCoverageCols,
+	TableBindingsPlanDrift:                  tableBindingsPlanDriftCols,
+	TableDDLBackfillSubtasks:                tableDDLBackfillSubtasksCols,
 }
 
 func createInfoSchemaTable(_ autoid.Allocators, meta *model.TableInfo) (table.Table, error) {
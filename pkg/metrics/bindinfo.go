@@ -21,6 +21,8 @@ var (
 	BindUsageCounter *prometheus.CounterVec
 	BindTotalGauge   *prometheus.GaugeVec
 	BindMemoryUsage  *prometheus.GaugeVec
+
+	BindPlanDigestMismatchCounter *prometheus.CounterVec
 )
 
 // InitBindInfoMetrics initializes bindinfo metrics.
@@ -48,4 +50,12 @@ func InitBindInfoMetrics() {
 			Name:      "bind_memory_usage",
 			Help:      "Memory usage of sql bind",
 		}, []string{LabelScope, LblType})
+
+	BindPlanDigestMismatchCounter = NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "bindinfo",
+			Name:      "plan_digest_mismatch_counter",
+			Help:      "Counter of the plans generated by sql bind which don't match the plan digest of the bind",
+		}, []string{LabelScope})
 }
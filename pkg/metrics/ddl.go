@@ -61,6 +61,7 @@ var (
 	DDLJobTableDuration   *prometheus.HistogramVec
 	DDLRunningJobCount    *prometheus.GaugeVec
 	AddIndexScanRate      *prometheus.HistogramVec
+	GlobalSortLeakedBytes prometheus.Counter
 )
 
 // InitDDLMetrics initializes defines DDL metrics.
@@ -174,6 +175,14 @@ func InitDDLMetrics() {
 		Help:      "scan rate",
 		Buckets:   prometheus.ExponentialBuckets(0.05, 2, 20),
 	}, []string{LblType})
+
+	GlobalSortLeakedBytes = NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "tidb",
+			Subsystem: "ddl",
+			Name:      "global_sort_leaked_bytes",
+			Help:      "Counter of the bytes of global sort files which are failed to be cleaned up",
+		})
 }
 
 // Label constants.
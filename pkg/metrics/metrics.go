@@ -127,6 +127,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(BindUsageCounter)
 	prometheus.MustRegister(BindTotalGauge)
 	prometheus.MustRegister(BindMemoryUsage)
+	prometheus.MustRegister(BindPlanDigestMismatchCounter)
 	prometheus.MustRegister(CampaignOwnerCounter)
 	prometheus.MustRegister(ConnGauge)
 	prometheus.MustRegister(DisconnectionCounter)
@@ -138,6 +139,7 @@ func RegisterMetrics() {
 	prometheus.MustRegister(DDLWorkerHistogram)
 	prometheus.MustRegister(DDLJobTableDuration)
 	prometheus.MustRegister(DDLRunningJobCount)
+	prometheus.MustRegister(GlobalSortLeakedBytes)
 	prometheus.MustRegister(DeploySyncerHistogram)
 	prometheus.MustRegister(DistSQLPartialCountHistogram)
 	prometheus.MustRegister(DistSQLCoprCacheCounter)
@@ -1961,6 +1961,8 @@ type CreateBindingStmt struct {
 	OriginNode  StmtNode
 	HintedNode  StmtNode
 	PlanDigest  string
+	// Comment is the user-defined comment of the binding, e.g. the ticket number or the owner.
+	Comment string
 }
 
 func (n *CreateBindingStmt) Restore(ctx *format.RestoreCtx) error {
@@ -1970,11 +1972,17 @@ func (n *CreateBindingStmt) Restore(ctx *format.RestoreCtx) error {
 	} else {
 		ctx.WriteKeyWord("SESSION ")
 	}
+	ctx.WriteKeyWord("BINDING ")
+	if n.Comment != "" {
+		ctx.WriteKeyWord("COMMENT ")
+		ctx.WriteString(n.Comment)
+		ctx.WritePlain(" ")
+	}
 	if n.OriginNode == nil {
-		ctx.WriteKeyWord("BINDING FROM HISTORY USING PLAN DIGEST ")
+		ctx.WriteKeyWord("FROM HISTORY USING PLAN DIGEST ")
 		ctx.WriteString(n.PlanDigest)
 	} else {
-		ctx.WriteKeyWord("BINDING FOR ")
+		ctx.WriteKeyWord("FOR ")
 		if err := n.OriginNode.Restore(ctx); err != nil {
 			return errors.Trace(err)
 		}
@@ -2015,6 +2023,8 @@ type DropBindingStmt struct {
 	OriginNode  StmtNode
 	HintedNode  StmtNode
 	SQLDigest   string
+	// Table is not nil means dropping all the bindings referencing the table.
+	Table *TableName
 }
 
 func (n *DropBindingStmt) Restore(ctx *format.RestoreCtx) error {
@@ -2024,6 +2034,13 @@ func (n *DropBindingStmt) Restore(ctx *format.RestoreCtx) error {
 	} else {
 		ctx.WriteKeyWord("SESSION ")
 	}
+	if n.Table != nil {
+		ctx.WriteKeyWord("BINDINGS FOR TABLE ")
+		if err := n.Table.Restore(ctx); err != nil {
+			return errors.Annotate(err, "An error occurred while restore DropBindingStmt.Table")
+		}
+		return nil
+	}
 	ctx.WriteKeyWord("BINDING FOR ")
 	if n.OriginNode == nil {
 		ctx.WriteKeyWord("SQL DIGEST ")
@@ -2048,6 +2065,13 @@ func (n *DropBindingStmt) Accept(v Visitor) (Node, bool) {
 		return v.Leave(newNode)
 	}
 	n = newNode.(*DropBindingStmt)
+	if n.Table != nil {
+		node, ok := n.Table.Accept(v)
+		if !ok {
+			return n, false
+		}
+		n.Table = node.(*TableName)
+	}
 	if n.OriginNode != nil {
 		//  OriginNode is nil means we build drop binding by sql digest
 		origNode, ok := n.OriginNode.Accept(v)
@@ -2073,6 +2097,7 @@ type BindingStatusType int8
 const (
 	BindingStatusTypeEnabled BindingStatusType = iota
 	BindingStatusTypeDisabled
+	BindingStatusTypeStandby
 )
 
 // SetBindingStmt sets sql binding status.
@@ -2093,6 +2118,8 @@ func (n *SetBindingStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("ENABLED ")
 	case BindingStatusTypeDisabled:
 		ctx.WriteKeyWord("DISABLED ")
+	case BindingStatusTypeStandby:
+		ctx.WriteKeyWord("STANDBY ")
 	}
 	ctx.WriteKeyWord("FOR ")
 	if n.OriginNode == nil {
@@ -2318,6 +2345,8 @@ const (
 	AdminResetTelemetryID
 	AdminReloadStatistics
 	AdminFlushPlanCache
+	AdminCheckBindings
+	AdminGCBindInfo
 )
 
 // HandleRange represents a range where handle value >= Begin and < End.
@@ -2553,6 +2582,10 @@ func (n *AdminStmt) Restore(ctx *format.RestoreCtx) error {
 		ctx.WriteKeyWord("EVOLVE BINDINGS")
 	case AdminReloadBindings:
 		ctx.WriteKeyWord("RELOAD BINDINGS")
+	case AdminCheckBindings:
+		ctx.WriteKeyWord("CHECK BINDINGS")
+	case AdminGCBindInfo:
+		ctx.WriteKeyWord("GC BINDINFO")
 	case AdminShowTelemetry:
 		ctx.WriteKeyWord("SHOW TELEMETRY")
 	case AdminResetTelemetryID:
@@ -3498,6 +3531,15 @@ func RedactURL(str string) string {
 			}
 		}
 		u.RawQuery = values.Encode()
+	case "azure", "azblob":
+		values := u.Query()
+		for k := range values {
+			normalizedKey := strings.ToLower(strings.ReplaceAll(k, "_", "-"))
+			if normalizedKey == "account-key" || normalizedKey == "sas-token" || normalizedKey == "encryption-key" {
+				values[k] = []string{"xxxxxx"}
+			}
+		}
+		u.RawQuery = values.Encode()
 	}
 	return u.String()
 }
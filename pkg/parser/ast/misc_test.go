@@ -414,6 +414,10 @@ func TestRedactURL(t *testing.T) {
 		// underline
 		{args{"s3://bucket/file?access_key=123"}, "s3://bucket/file?access_key=xxxxxx"},
 		{args{"s3://bucket/file?secret_access_key=123"}, "s3://bucket/file?secret_access_key=xxxxxx"},
+		{args{"azure://bucket/file?account-name=abc&account-key=123"}, "azure://bucket/file?account-key=xxxxxx&account-name=abc"},
+		{args{"azblob://bucket/file?sas-token=123"}, "azblob://bucket/file?sas-token=xxxxxx"},
+		{args{"azure://bucket/file?encryption_key=123"}, "azure://bucket/file?encryption_key=xxxxxx"},
+		{args{"local:///mnt/nfs/sort?access-key=123"}, "local:///mnt/nfs/sort?access-key=123"},
 	}
 	for _, tt := range tests {
 		t.Run(tt.args.str, func(t *testing.T) {
@@ -97,7 +97,19 @@ func Normalize(sql string) (result string) {
 // for example: NormalizeForBinding('select 1 from b where a = 1') => 'select ? from b where a = ?'
 func NormalizeForBinding(sql string) (result string) {
 	d := digesterPool.Get().(*sqlDigester)
-	result = d.doNormalizeForBinding(sql, false)
+	result = d.doNormalizeForBinding(sql, false, false)
+	digesterPool.Put(d)
+	return
+}
+
+// NormalizeForBindingCollapseInList is like NormalizeForBinding, but it additionally collapses
+// the whole IN lists, even if they contain non-literal items, so the statements differ only in
+// the IN lists have the same normalized form.
+//
+// for example: NormalizeForBindingCollapseInList('select 1 from b where a in (@a, 1+1)') => 'select ? from b where a in ( ... )'
+func NormalizeForBindingCollapseInList(sql string) (result string) {
+	d := digesterPool.Get().(*sqlDigester)
+	result = d.doNormalizeForBinding(sql, false, true)
 	digesterPool.Put(d)
 	return
 }
@@ -125,7 +137,15 @@ func NormalizeDigest(sql string) (normalized string, digest *Digest) {
 // NormalizeDigestForBinding combines Normalize and DigestNormalized into one method with additional binding rules.
 func NormalizeDigestForBinding(sql string) (normalized string, digest *Digest) {
 	d := digesterPool.Get().(*sqlDigester)
-	normalized, digest = d.doNormalizeDigestForBinding(sql)
+	normalized, digest = d.doNormalizeDigestForBinding(sql, false)
+	digesterPool.Put(d)
+	return
+}
+
+// NormalizeDigestForBindingCollapseInList combines NormalizeForBindingCollapseInList and DigestNormalized into one method.
+func NormalizeDigestForBindingCollapseInList(sql string) (normalized string, digest *Digest) {
+	d := digesterPool.Get().(*sqlDigester)
+	normalized, digest = d.doNormalizeDigestForBinding(sql, true)
 	digesterPool.Put(d)
 	return
 }
@@ -161,7 +181,7 @@ func (d *sqlDigester) doDigestNormalized(normalized string) (digest *Digest) {
 }
 
 func (d *sqlDigester) doDigest(sql string) (digest *Digest) {
-	d.normalize(sql, false, false)
+	d.normalize(sql, false, false, false)
 	d.hasher.Write(d.buffer.Bytes())
 	d.buffer.Reset()
 	digest = NewDigest(d.hasher.Sum(nil))
@@ -170,21 +190,21 @@ func (d *sqlDigester) doDigest(sql string) (digest *Digest) {
 }
 
 func (d *sqlDigester) doNormalize(sql string, keepHint bool) (result string) {
-	d.normalize(sql, keepHint, false)
+	d.normalize(sql, keepHint, false, false)
 	result = d.buffer.String()
 	d.buffer.Reset()
 	return
 }
 
-func (d *sqlDigester) doNormalizeForBinding(sql string, keepHint bool) (result string) {
-	d.normalize(sql, keepHint, true)
+func (d *sqlDigester) doNormalizeForBinding(sql string, keepHint bool, collapseInList bool) (result string) {
+	d.normalize(sql, keepHint, true, collapseInList)
 	result = d.buffer.String()
 	d.buffer.Reset()
 	return
 }
 
 func (d *sqlDigester) doNormalizeDigest(sql string) (normalized string, digest *Digest) {
-	d.normalize(sql, false, false)
+	d.normalize(sql, false, false, false)
 	normalized = d.buffer.String()
 	d.hasher.Write(d.buffer.Bytes())
 	d.buffer.Reset()
@@ -193,8 +213,8 @@ func (d *sqlDigester) doNormalizeDigest(sql string) (normalized string, digest *
 	return
 }
 
-func (d *sqlDigester) doNormalizeDigestForBinding(sql string) (normalized string, digest *Digest) {
-	d.normalize(sql, false, true)
+func (d *sqlDigester) doNormalizeDigestForBinding(sql string, collapseInList bool) (normalized string, digest *Digest) {
+	d.normalize(sql, false, true, collapseInList)
 	normalized = d.buffer.String()
 	d.hasher.Write(d.buffer.Bytes())
 	d.buffer.Reset()
@@ -212,7 +232,7 @@ const (
 	genericSymbolList = -2
 )
 
-func (d *sqlDigester) normalize(sql string, keepHint bool, forBinding bool) {
+func (d *sqlDigester) normalize(sql string, keepHint bool, forBinding bool, collapseInList bool) {
 	d.lexer.reset(sql)
 	d.lexer.setKeepHint(keepHint)
 	for {
@@ -235,6 +255,10 @@ func (d *sqlDigester) normalize(sql string, keepHint bool, forBinding bool) {
 		if forBinding {
 			// IN (?) => IN ( ... ) #44298
 			d.reduceInListWithSingleLiteral(&currTok)
+			// IN (@a, ?+?) => IN ( ... )
+			if collapseInList && d.reduceInList(&currTok) {
+				continue
+			}
 		}
 
 		if currTok.tok == identifier {
@@ -391,6 +415,58 @@ func (d *sqlDigester) reduceInListWithSingleLiteral(currTok *token) {
 	}
 }
 
+// reduceInList collapses the items of the IN list closed by currTok into "...", unless it is a subquery.
+// It returns true if currTok is consumed, which means the IN list has been collapsed.
+func (d *sqlDigester) reduceInList(currTok *token) (reduced bool) {
+	if !d.isRightParen(*currTok) {
+		return false
+	}
+	// Find the left parenthesis which matches currTok.
+	depth := 0
+	for i := len(d.tokens) - 1; i >= 0; i-- {
+		if d.isRightParen(d.tokens[i]) {
+			depth++
+			continue
+		}
+		if !d.isLeftParen(d.tokens[i]) {
+			continue
+		}
+		if depth > 0 {
+			depth--
+			continue
+		}
+		if i == 0 || !d.isInKeyword(d.tokens[i-1]) || i == len(d.tokens)-1 {
+			return false
+		}
+		items := d.tokens[i+1:]
+		// The items are row constructors, e.g. (a, b) IN ((?, ?), (?, ?)), keep the parentheses of the rows.
+		isRowList := d.isLeftParen(items[0])
+		if (len(items) == 1 && items[0].tok == genericSymbolList) ||
+			(isRowList && len(items) == 3 && items[1].tok == genericSymbolList && d.isRightParen(items[2])) {
+			return false
+		}
+		for _, item := range items {
+			// IN (SELECT ...) is a subquery rather than a list.
+			switch item.lit {
+			case "select", "with", "table", "values":
+				return false
+			}
+		}
+		leftParen := items[0]
+		d.tokens.popBack(len(items))
+		if isRowList {
+			d.tokens.pushBack(leftParen)
+			d.tokens.pushBack(token{genericSymbolList, "..."})
+			d.tokens.pushBack(*currTok)
+		} else {
+			d.tokens.pushBack(token{genericSymbolList, "..."})
+		}
+		d.tokens.pushBack(*currTok)
+		return true
+	}
+	return false
+}
+
 func (d *sqlDigester) isPrefixByUnary(currTok int) (isUnary bool) {
 	if !d.isNumLit(currTok) {
 		return
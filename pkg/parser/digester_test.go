@@ -99,6 +99,32 @@ func TestNormalize(t *testing.T) {
 		normalized2, digest2 := parser.NormalizeDigestForBinding(test.input)
 		require.Equal(t, normalized, normalized2)
 		require.Equalf(t, digest.String(), digest2.String(), "%+v", test)
+
+		// The IN lists of literals are always collapsed for binding.
+		require.Equal(t, normalized, parser.NormalizeForBindingCollapseInList(test.input))
+	}
+
+	testsForCollapsingInList := []struct {
+		input  string
+		expect string
+	}{
+		{"select * from t where a in (@a)", "select * from `t` where `a` in ( ... )"},
+		{"select * from t where a in (@a, 1, 2)", "select * from `t` where `a` in ( ... )"},
+		{"select * from t where a in (1 + 1, abs(b), 3) and b not in (c, d)", "select * from `t` where `a` in ( ... ) and `b` not in ( ... )"},
+		{"select * from t where (a, b) in ((1, c), (2, 2))", "select * from `t` where ( `a` , `b` ) in ( ( ... ) )"},
+		{"select * from t where a in (select b from t1)", "select * from `t` where `a` in ( select `b` from `t1` )"},
+		{"select * from t where a in (1, (select b from t1))", "select * from `t` where `a` in ( ? , ( select `b` from `t1` ) )"},
+		{"select * from t where a in ()", "select * from `t` where `a` in ( )"},
+		{"select a in (b, c) from t where f(a, b)", "select `a` in ( ... ) from `t` where `f` ( `a` , `b` )"},
+	}
+	for _, test := range testsForCollapsingInList {
+		normalized := parser.NormalizeForBindingCollapseInList(test.input)
+		digest := parser.DigestNormalized(normalized)
+		require.Equal(t, test.expect, normalized)
+
+		normalized2, digest2 := parser.NormalizeDigestForBindingCollapseInList(test.input)
+		require.Equal(t, normalized, normalized2)
+		require.Equalf(t, digest.String(), digest2.String(), "%+v", test)
 	}
 }
 
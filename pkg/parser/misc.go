@@ -191,6 +191,7 @@ var tokenMap = map[string]int{
 	"BINARY":                   binaryType,
 	"BINDING":                  binding,
 	"BINDING_CACHE":            bindingCache,
+	"BINDINFO":                 bindinfo,
 	"BINDINGS":                 bindings,
 	"BINLOG":                   binlog,
 	"BIT_AND":                  bitAnd,
@@ -391,6 +392,7 @@ var tokenMap = map[string]int{
 	"FULL_BACKUP_STORAGE":      fullBackupStorage,
 	"FULLTEXT":                 fulltext,
 	"FUNCTION":                 function,
+	"GC":                       gc,
 	"GC_TTL":                   gcTTL,
 	"GENERAL":                  general,
 	"GENERATED":                generated,
@@ -736,6 +738,7 @@ var tokenMap = map[string]int{
 	"SQLWARNING":               sqlwarning,
 	"SSL":                      ssl,
 	"STALENESS":                staleness,
+	"STANDBY":                  standby,
 	"START":                    start,
 	"START_TIME":               startTime,
 	"START_TS":                 startTS,
@@ -32,6 +32,33 @@ type DDLReorgMeta struct {
 	UseCloudStorage   bool                             `json:"use_cloud_storage"`
 	ResourceGroupName string                           `json:"resource_group_name"`
 	Version           int64                            `json:"version"`
+	// Throughput is the number of the rows backfilled per second, and ETA is the estimated unix time
+	// the backfilling finishes. They are estimated by the dispatcher of the distributed reorganization.
+	Throughput float64 `json:"throughput,omitempty"`
+	ETA        int64   `json:"eta,omitempty"`
+	// ReplicaRead is the replica read type of the table scans of the backfilling, such as "follower".
+	// The leaders are read if it's empty. MaxStaleness is the max staleness in seconds of the table scans,
+	// 0 means reading the latest data.
+	ReplicaRead  string `json:"replica_read,omitempty"`
+	MaxStaleness int64  `json:"max_staleness,omitempty"`
+	// MaxScanSpeed is the max number of rows per second each TiDB node scans from the table, 0 means no limit.
+	MaxScanSpeed int64 `json:"max_scan_speed,omitempty"`
+	// GlobalSortStats is the statistics of the global sort, it's nil if the global sort is not used.
+	GlobalSortStats *GlobalSortStats `json:"global_sort_stats,omitempty"`
+}
+
+// GlobalSortStats is the statistics of the sorted KV written to the cloud storage by the distributed
+// reorganization with global sort.
+type GlobalSortStats struct {
+	// TotalKVSize is the size of the sorted KV written by the read index step.
+	TotalKVSize uint64 `json:"total_kv_size"`
+	// DataFiles and StatFiles are the numbers of the files written by the read index step.
+	DataFiles int `json:"data_files"`
+	StatFiles int `json:"stat_files"`
+	// MergeSortPasses is the number of times the sorted KV are merged before they are ingested.
+	MergeSortPasses int `json:"merge_sort_passes"`
+	// RangeSplits is the number of the keys the ranges of the ingest subtasks are split by.
+	RangeSplits int `json:"range_splits"`
 }
 
 const (
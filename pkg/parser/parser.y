@@ -620,6 +620,7 @@ import (
 	sqlTsiSecond          "SQL_TSI_SECOND"
 	sqlTsiWeek            "SQL_TSI_WEEK"
 	sqlTsiYear            "SQL_TSI_YEAR"
+	standby               "STANDBY"
 	start                 "START"
 	statsAutoRecalc       "STATS_AUTO_RECALC"
 	statsPersistent       "STATS_PERSISTENT"
@@ -799,6 +800,7 @@ import (
 	/* The following tokens belong to TiDBKeyword. Notice: make sure these tokens are contained in TiDBKeyword. */
 	admin                      "ADMIN"
 	batch                      "BATCH"
+	bindinfo                   "BINDINFO"
 	buckets                    "BUCKETS"
 	builtins                   "BUILTINS"
 	cancel                     "CANCEL"
@@ -811,6 +813,7 @@ import (
 	depth                      "DEPTH"
 	drainer                    "DRAINER"
 	dry                        "DRY"
+	gc                         "GC"
 	jobs                       "JOBS"
 	job                        "JOB"
 	nodeID                     "NODE_ID"
@@ -1503,6 +1506,7 @@ import (
 
 %type	<ident>
 	AsOpt             "AS or EmptyString"
+	BindingCommentOpt "optional COMMENT of binding"
 	KeyOrIndex        "{KEY|INDEX}"
 	ColumnKeywordOpt  "Column keyword or empty"
 	PrimaryOpt        "Optional primary keyword"
@@ -4021,6 +4025,10 @@ BindingStatusType:
 	{
 		$$ = ast.BindingStatusTypeDisabled
 	}
+|	"STANDBY"
+	{
+		$$ = ast.BindingStatusTypeStandby
+	}
 
 CreateStatisticsStmt:
 	"CREATE" "STATISTICS" IfNotExists Identifier '(' StatsType ')' "ON" TableName '(' ColumnNameList ')'
@@ -6606,6 +6614,7 @@ UnReservedKeyword:
 |	"SHARD_ROW_ID_BITS"
 |	"SHUTDOWN"
 |	"SNAPSHOT"
+|	"STANDBY"
 |	"START"
 |	"STATUS"
 |	"OPEN"
@@ -6879,6 +6888,7 @@ UnReservedKeyword:
 TiDBKeyword:
 	"ADMIN"
 |	"BATCH"
+|	"BINDINFO"
 |	"BUCKETS"
 |	"BUILTINS"
 |	"CANCEL"
@@ -6921,6 +6931,7 @@ TiDBKeyword:
 |	"RESET"
 |	"DRY"
 |	"RUN"
+|	"GC"
 
 NotKeywordToken:
 	"ADDDATE"
@@ -11059,6 +11070,18 @@ AdminStmt:
 			Tp: ast.AdminReloadBindings,
 		}
 	}
+|	"ADMIN" "CHECK" "BINDINGS"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminCheckBindings,
+		}
+	}
+|	"ADMIN" "GC" "BINDINFO"
+	{
+		$$ = &ast.AdminStmt{
+			Tp: ast.AdminGCBindInfo,
+		}
+	}
 |	"ADMIN" "RELOAD" "STATS_EXTENDED"
 	{
 		$$ = &ast.AdminStmt{
@@ -13713,41 +13736,53 @@ BindableStmt:
  *      CREATE GLOBAL BINDING FOR select Col1,Col2 from table USING select Col1,Col2 from table use index(Col1)
  *******************************************************************/
 CreateBindingStmt:
-	"CREATE" GlobalScope "BINDING" "FOR" BindableStmt "USING" BindableStmt
+	"CREATE" GlobalScope "BINDING" BindingCommentOpt "FOR" BindableStmt "USING" BindableStmt
 	{
 		startOffset := parser.startOffset(&yyS[yypt-2])
 		endOffset := parser.startOffset(&yyS[yypt-1])
-		originStmt := $5
+		originStmt := $6
 		originStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:endOffset]))
 
 		startOffset = parser.startOffset(&yyS[yypt])
-		hintedStmt := $7
+		hintedStmt := $8
 		hintedStmt.SetText(parser.lexer.client, strings.TrimSpace(parser.src[startOffset:]))
 
 		x := &ast.CreateBindingStmt{
 			OriginNode:  originStmt,
 			HintedNode:  hintedStmt,
 			GlobalScope: $2.(bool),
+			Comment:     $4,
 		}
 
 		$$ = x
 	}
-|	"CREATE" GlobalScope "BINDING" "FROM" "HISTORY" "USING" "PLAN" "DIGEST" stringLit
+|	"CREATE" GlobalScope "BINDING" BindingCommentOpt "FROM" "HISTORY" "USING" "PLAN" "DIGEST" stringLit
 	{
 		x := &ast.CreateBindingStmt{
 			GlobalScope: $2.(bool),
-			PlanDigest:  $9,
+			PlanDigest:  $10,
+			Comment:     $4,
 		}
 
 		$$ = x
 	}
 
+BindingCommentOpt:
+	{
+		$$ = ""
+	}
+|	"COMMENT" stringLit
+	{
+		$$ = $2
+	}
+
 /*******************************************************************
  *
  *  Drop Binding Statement
  *
  *  Example:
  *      DROP GLOBAL BINDING FOR select Col1,Col2 from table
+ *      DROP GLOBAL BINDINGS FOR TABLE db.table
  *******************************************************************/
 DropBindingStmt:
 	"DROP" GlobalScope "BINDING" "FOR" BindableStmt
@@ -13789,6 +13824,15 @@ DropBindingStmt:
 			SQLDigest:   $7,
 		}
 
+		$$ = x
+	}
+|	"DROP" GlobalScope "BINDINGS" "FOR" "TABLE" TableName
+	{
+		x := &ast.DropBindingStmt{
+			GlobalScope: $2.(bool),
+			Table:       $6.(*ast.TableName),
+		}
+
 		$$ = x
 	}
 
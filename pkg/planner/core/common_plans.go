@@ -264,6 +264,10 @@ const (
 	OpSQLBindDropByDigest
 	// OpSetBindingStatusByDigest represents the operation to set SQL binding status by sql digest.
 	OpSetBindingStatusByDigest
+	// OpSQLBindDropByTable is used to drop SQL binds referencing a table.
+	OpSQLBindDropByTable
+	// OpGCBindInfo is used to physically remove the deleted bindings in mysql.bind_info.
+	OpGCBindInfo
 )
 
 // SQLBindPlan represents a plan for SQL bind.
@@ -282,6 +286,13 @@ type SQLBindPlan struct {
 	Source       string // Source indicate how this binding was created, eg: bindinfo.Manual or bindinfo.History
 	SQLDigest    string
 	PlanDigest   string
+	Comment      string
+	Table        string // Table is the table referenced by the bindings to drop, only in drop by table stmt
+}
+
+// AdminCheckBindings represents a plan for ADMIN CHECK BINDINGS, which reports the conflicting global bindings.
+type AdminCheckBindings struct {
+	baseSchemaProducer
 }
 
 // Simple represents a simple statement plan which doesn't need any optimization.
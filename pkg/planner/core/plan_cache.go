@@ -136,6 +136,7 @@ func planCachePreprocess(ctx context.Context, sctx sessionctx.Context, isNonPrep
 		stmtAst.CachedPlan = nil
 		vars.LastUpdateTime4PC = expiredTimeStamp4PC
 	}
+
 	return nil
 }
 
@@ -190,6 +191,13 @@ func GetPlanFromSessionPlanCache(ctx context.Context, sctx sessionctx.Context,
 		}
 	}
 
+	if stmtCtx.UseCache && stmtAst.CachedPlan != nil && stmt.CachedPlanBindSQL != bindSQL {
+		// The binding has been changed since the point plan was cached, maybe on another TiDB
+		// instance and pushed to this one by NotifyUpdateBinding, so the cached point plan is stale.
+		stmtAst.CachedPlan = nil
+		stmt.Executor = nil
+	}
+
 	if stmtCtx.UseCache && stmtAst.CachedPlan != nil { // special code path for fast point plan
 		if plan, names, ok, err := getCachedPointPlan(stmtAst, sessVars, stmtCtx); ok {
 			return plan, names, err
@@ -285,6 +293,7 @@ func getCachedPlan(sctx sessionctx.Context, isNonPrepared bool, cacheKey kvcache
 		// When the `len(bindSQL) > 0`, it means we use the binding.
 		// So we need to record this.
 		sessVars.FoundInBinding = true
+		stmtCtx.BindSQL = bindSQL
 	}
 	if metrics.ResettablePlanCacheCounterFortTest {
 		metrics.PlanCacheCounter.WithLabelValues("prepare").Inc()
@@ -312,7 +321,7 @@ func generateNewPlan(ctx context.Context, sctx sessionctx.Context, isNonPrepared
 	if err != nil {
 		return nil, nil, err
 	}
-	err = tryCachePointPlan(ctx, sctx, stmt, p, names)
+	err = tryCachePointPlan(ctx, sctx, stmt, p, names, bindSQL)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -754,7 +763,7 @@ func CheckPreparedPriv(sctx sessionctx.Context, stmt *PlanCacheStmt, is infosche
 // tryCachePointPlan will try to cache point execution plan, there may be some
 // short paths for these executions, currently "point select" and "point update"
 func tryCachePointPlan(_ context.Context, sctx sessionctx.Context,
-	stmt *PlanCacheStmt, p Plan, names types.NameSlice) error {
+	stmt *PlanCacheStmt, p Plan, names types.NameSlice, bindSQL string) error {
 	if !sctx.GetSessionVars().StmtCtx.UseCache {
 		return nil
 	}
@@ -777,6 +786,7 @@ func tryCachePointPlan(_ context.Context, sctx sessionctx.Context,
 	if ok {
 		// just cache point plan now
 		stmtAst.CachedPlan = p
+		stmt.CachedPlanBindSQL = bindSQL
 		stmtAst.CachedNames = names
 		stmt.NormalizedPlan, stmt.PlanDigest = NormalizePlan(p)
 		sctx.GetSessionVars().StmtCtx.SetPlan(p)
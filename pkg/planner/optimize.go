@@ -207,13 +207,7 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	}()
 
 	warns = warns[:0]
-	for name, val := range sessVars.StmtCtx.StmtHints.SetVars {
-		oldV, err := sessVars.SetSystemVarWithOldValAsRet(name, val)
-		if err != nil {
-			sessVars.StmtCtx.AppendWarning(err)
-		}
-		sessVars.StmtCtx.AddSetVarHintRestore(name, oldV)
-	}
+	setVarsByHint(sessVars, sessVars.StmtCtx.StmtHints.SetVars)
 	if len(sessVars.StmtCtx.StmtHints.SetVars) > 0 {
 		sctx.GetSessionVars().StmtCtx.SetSkipPlanCache(errors.Errorf("SET_VAR is used in the SQL"))
 	}
@@ -286,6 +280,9 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 	if useBinding {
 		minCost := math.MaxFloat64
 		var bindStmtHints stmtctx.StmtHints
+		// bindSetVarOldVals records the values of the session variables before they are changed by the SET_VAR
+		// hints of the binding being tried, so that every binding is optimized with its own variables.
+		var bindSetVarOldVals map[string]string
 		originHints := hint.CollectHint(stmtNode)
 		// bindRecord must be not nil when coming here, try to find the best binding.
 		for _, binding := range bindRecord.Bindings {
@@ -300,13 +297,8 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 			curStmtHints, _, curWarns := handleStmtHints(binding.Hint.GetFirstTableHints())
 			sessVars.StmtCtx.StmtHints = curStmtHints
 			// update session var by hint /set_var/
-			for name, val := range sessVars.StmtCtx.StmtHints.SetVars {
-				oldV, err := sessVars.SetSystemVarWithOldValAsRet(name, val)
-				if err != nil {
-					sessVars.StmtCtx.AppendWarning(err)
-				}
-				sessVars.StmtCtx.AddSetVarHintRestore(name, oldV)
-			}
+			resetVarsSetByHint(sessVars, bindSetVarOldVals)
+			bindSetVarOldVals = setVarsByHint(sessVars, sessVars.StmtCtx.StmtHints.SetVars)
 			plan, curNames, cost, err := optimize(ctx, sctx, node, is)
 			if err != nil {
 				binding.Status = bindinfo.Invalid
@@ -321,16 +313,23 @@ func Optimize(ctx context.Context, sctx sessionctx.Context, node ast.Node, is in
 				bindStmtHints, warns, minCost, names, bestPlanFromBind, chosenBinding = curStmtHints, curWarns, cost, curNames, plan, binding
 			}
 		}
+		resetVarsSetByHint(sessVars, bindSetVarOldVals)
 		if bestPlanFromBind == nil {
 			sessVars.StmtCtx.AppendWarning(errors.New("no plan generated from bindings"))
 		} else {
 			bestPlan = bestPlanFromBind
 			sessVars.StmtCtx.StmtHints = bindStmtHints
+			// The plan is executed with the session variables set by the chosen binding.
+			setVarsByHint(sessVars, bindStmtHints.SetVars)
+			if len(bindStmtHints.SetVars) > 0 {
+				sessVars.StmtCtx.SetSkipPlanCache(errors.Errorf("SET_VAR is used in the binding"))
+			}
 			for _, warn := range warns {
 				sessVars.StmtCtx.AppendWarning(warn)
 			}
 			sessVars.StmtCtx.BindSQL = chosenBinding.BindSQL
 			sessVars.FoundInBinding = true
+			checkBindingPlanDigest(sctx, scope, bindRecord, &chosenBinding, bestPlan)
 			if sessVars.StmtCtx.InVerboseExplain {
 				sessVars.StmtCtx.AppendNote(errors.Errorf("Using the bindSQL: %v", chosenBinding.BindSQL))
 			} else {
@@ -602,7 +601,7 @@ func ExtractSelectAndNormalizeDigest(stmtNode ast.StmtNode, specifiledDB string,
 			var normalizeSQL string
 			if forBinding {
 				// Apply additional binding rules if enabled
-				normalizeSQL = parser.NormalizeForBinding(utilparser.RestoreWithDefaultDB(x.Stmt, specifiledDB, x.Text()))
+				normalizeSQL = bindinfo.NormalizeForBinding(utilparser.RestoreWithDefaultDB(x.Stmt, specifiledDB, x.Text()))
 			} else {
 				normalizeSQL = parser.Normalize(utilparser.RestoreWithDefaultDB(x.Stmt, specifiledDB, x.Text()))
 			}
@@ -621,7 +620,7 @@ func ExtractSelectAndNormalizeDigest(stmtNode ast.StmtNode, specifiledDB string,
 
 			if forBinding {
 				// Apply additional binding rules
-				normalizeExplainSQL = parser.NormalizeForBinding(explainSQL)
+				normalizeExplainSQL = bindinfo.NormalizeForBinding(explainSQL)
 			} else {
 				normalizeExplainSQL = parser.Normalize(x.Text())
 			}
@@ -655,7 +654,7 @@ func ExtractSelectAndNormalizeDigest(stmtNode ast.StmtNode, specifiledDB string,
 		var hash *parser.Digest
 		if forBinding {
 			// Apply additional binding rules
-			normalizedSQL, hash = parser.NormalizeDigestForBinding(utilparser.RestoreWithDefaultDB(x, specifiledDB, x.Text()))
+			normalizedSQL, hash = bindinfo.NormalizeDigestForBinding(utilparser.RestoreWithDefaultDB(x, specifiledDB, x.Text()))
 		} else {
 			normalizedSQL, hash = parser.NormalizeDigest(utilparser.RestoreWithDefaultDB(x, specifiledDB, x.Text()))
 		}
@@ -690,6 +689,28 @@ func getBindRecord(ctx sessionctx.Context, stmt ast.StmtNode) (*bindinfo.BindRec
 	return bindRecord, metrics.ScopeGlobal, nil
 }
 
+// checkBindingPlanDigest compares the digest of the plan generated by the binding with the plan digest of the
+// binding, the mismatch is reported according to tidb_binding_plan_digest_check.
+func checkBindingPlanDigest(sctx sessionctx.Context, scope string, bindRecord *bindinfo.BindRecord, binding *bindinfo.Binding, plan core.Plan) {
+	mode := variable.BindingPlanDigestCheck.Load()
+	if mode == variable.Off || binding.PlanDigest == "" {
+		return
+	}
+	_, digest := core.NormalizeFlatPlan(core.FlattenPhysicalPlan(plan, false))
+	planDigest := digest.String()
+	if planDigest == "" || planDigest == binding.PlanDigest {
+		return
+	}
+	metrics.BindPlanDigestMismatchCounter.WithLabelValues(scope).Inc()
+	if globalHandle := domain.GetDomain(sctx).BindHandle(); globalHandle != nil {
+		globalHandle.RecordPlanDrift(bindRecord, binding, scope, planDigest)
+	}
+	if mode == variable.Warn {
+		sctx.GetSessionVars().StmtCtx.AppendWarning(errors.Errorf("The plan digest %s generated by the binding doesn't match the plan digest %s of the binding: %v",
+			planDigest, binding.PlanDigest, binding.BindSQL))
+	}
+}
+
 func handleInvalidBindRecord(ctx context.Context, sctx sessionctx.Context, level string, bindRecord bindinfo.BindRecord) {
 	sessionHandle := sctx.Value(bindinfo.SessionBindInfoKeyType).(*bindinfo.SessionHandle)
 	err := sessionHandle.DropBindRecord(bindRecord.OriginalSQL, bindRecord.Db, &bindRecord.Bindings[0])
@@ -705,7 +726,7 @@ func handleInvalidBindRecord(ctx context.Context, sctx sessionctx.Context, level
 }
 
 func handleEvolveTasks(ctx context.Context, sctx sessionctx.Context, br *bindinfo.BindRecord, stmtNode ast.StmtNode, planHint string) {
-	bindSQL := bindinfo.GenerateBindSQL(ctx, stmtNode, planHint, false, br.Db)
+	bindSQL := bindinfo.GenerateBindSQL(ctx, stmtNode, planHint, br.Db)
 	if bindSQL == "" {
 		return
 	}
@@ -723,6 +744,31 @@ func handleEvolveTasks(ctx context.Context, sctx sessionctx.Context, br *bindinf
 	globalHandle.AddEvolvePlanTask(br.OriginalSQL, br.Db, binding)
 }
 
+// setVarsByHint updates the session variables by the SET_VAR hints, and returns the old values of them.
+// The old values are also recorded in the statement context, and restored when the statement finishes.
+func setVarsByHint(sessVars *variable.SessionVars, setVars map[string]string) map[string]string {
+	oldVals := make(map[string]string, len(setVars))
+	for name, val := range setVars {
+		oldV, err := sessVars.SetSystemVarWithOldValAsRet(name, val)
+		if err != nil {
+			sessVars.StmtCtx.AppendWarning(err)
+			continue
+		}
+		sessVars.StmtCtx.AddSetVarHintRestore(name, oldV)
+		oldVals[name] = oldV
+	}
+	return oldVals
+}
+
+// resetVarsSetByHint resets the session variables changed by setVarsByHint to their old values.
+func resetVarsSetByHint(sessVars *variable.SessionVars, oldVals map[string]string) {
+	for name, val := range oldVals {
+		if err := sessVars.SetSystemVar(name, val); err != nil {
+			sessVars.StmtCtx.AppendWarning(err)
+		}
+	}
+}
+
 func handleStmtHints(hints []*ast.TableOptimizerHint) (stmtHints stmtctx.StmtHints, offs []int, warns []error) {
 	if len(hints) == 0 {
 		return
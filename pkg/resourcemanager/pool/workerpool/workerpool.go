@@ -183,7 +183,11 @@ func (p *WorkerPool[T, R]) Tune(numWorkers int32) {
 	} else if diff < 0 {
 		// Remove workers
 		for i := 0; i < int(-diff); i++ {
-			p.quitChan <- struct{}{}
+			// The workers may have quit if the pool is canceled.
+			select {
+			case p.quitChan <- struct{}{}:
+			case <-p.ctx.Done():
+			}
 		}
 	}
 	p.numWorkers = numWorkers
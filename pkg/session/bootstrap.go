@@ -291,6 +291,11 @@ const (
 		source VARCHAR(10) NOT NULL DEFAULT 'unknown',
 		sql_digest varchar(64),
 		plan_digest varchar(64),
+		comment TEXT,
+		capture_users TEXT,
+		capture_sample_sql TEXT,
+		capture_avg_latency BIGINT,
+		capture_exec_count BIGINT,
 		INDEX sql_index(original_sql(700),default_db(68)) COMMENT "accelerate the speed when add global binding query",
 		INDEX time_index(update_time) COMMENT "accelerate the speed when querying with last update time"
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
@@ -579,6 +584,7 @@ const (
 		concurrency INT(11),
 		step INT(11),
 		error BLOB,
+		priority INT DEFAULT 512 NOT NULL,
 		key(state),
       	UNIQUE KEY task_key(task_key)
 	);`
@@ -596,6 +602,7 @@ const (
 		concurrency INT(11),
 		step INT(11),
 		error BLOB,
+		priority INT DEFAULT 512 NOT NULL,
 		key(state),
       	UNIQUE KEY task_key(task_key)
 	);`
@@ -639,6 +646,40 @@ const (
 		INDEX time_index(time) COMMENT "accelerate the speed when querying with active watch"
 	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
 
+	// CreateBindPendingTasksTable stores the binding operations found during querying which are not flushed to
+	// mysql.bind_info yet, i.e. dropping the invalid bindings and adding the bindings to be verified by the plan
+	// evolution. They are replayed when tidb starts up, so the operations are not lost after restarts.
+	CreateBindPendingTasksTable = `CREATE TABLE IF NOT EXISTS mysql.bind_pending_tasks (
+		task_type VARCHAR(10) NOT NULL,
+		original_sql TEXT NOT NULL,
+		default_db TEXT NOT NULL,
+		bind_sql TEXT NOT NULL,
+		status TEXT NOT NULL,
+		charset TEXT NOT NULL,
+		collation TEXT NOT NULL,
+		source VARCHAR(10) NOT NULL DEFAULT 'unknown',
+		sql_digest varchar(64),
+		create_time TIMESTAMP(3) NOT NULL,
+		INDEX sql_index(original_sql(700),default_db(68))
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
+	// CreateDDLIndexConflictsTable stores the conflicting rows found when ingesting the unique indexes, so users
+	// can find and fix the rows which fail the adding of the unique index.
+	CreateDDLIndexConflictsTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_ddl_index_conflicts (
+		id BIGINT(20) NOT NULL AUTO_INCREMENT PRIMARY KEY,
+		job_id BIGINT(20) NOT NULL,
+		table_schema VARCHAR(64) NOT NULL,
+		table_name VARCHAR(64) NOT NULL,
+		physical_table_id BIGINT(20) NOT NULL,
+		index_name VARCHAR(64) NOT NULL,
+		index_values TEXT NOT NULL,
+		handle TEXT NOT NULL,
+		raw_key BLOB NOT NULL,
+		raw_value BLOB NOT NULL,
+		create_time TIMESTAMP(6) NOT NULL DEFAULT CURRENT_TIMESTAMP(6),
+		INDEX idx_job_id(job_id)
+	) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_bin;`
+
 	// CreateRunawayWatchTable stores the condition which is used to check whether query should be quarantined.
 	CreateRunawayWatchTable = `CREATE TABLE IF NOT EXISTS mysql.tidb_runaway_watch (
 		id BIGINT(20) NOT NULL AUTO_INCREMENT PRIMARY KEY,
@@ -1014,11 +1055,32 @@ const (
 	// version 177
 	//   add `mysql.dist_framework_meta`
 	version177 = 177
+
+	// version 178
+	//   add column `comment` to `mysql.bind_info`.
+	version178 = 178
+
+	// version 179
+	//   add columns `capture_users`, `capture_sample_sql`, `capture_avg_latency` and `capture_exec_count`
+	//   to `mysql.bind_info`.
+	version179 = 179
+
+	// version 180
+	//   create table `mysql.bind_pending_tasks`.
+	version180 = 180
+
+	// version 181
+	//   create table `mysql.tidb_ddl_index_conflicts`.
+	version181 = 181
+
+	// version 182
+	//   add column `priority` to `mysql.tidb_global_task` and `mysql.tidb_global_task_history`.
+	version182 = 182
 )
 
 // currentBootstrapVersion is defined as a variable, so we can modify its value for testing.
 // please make sure this is the largest version
-var currentBootstrapVersion int64 = version177
+var currentBootstrapVersion int64 = version182
 
 // DDL owner key's expired time is ManagerSessionTTL seconds, we should wait the time and give more time to have a chance to finish it.
 var internalSQLTimeout = owner.ManagerSessionTTL + 15
@@ -1171,6 +1233,11 @@ var (
 		upgradeToVer175,
 		upgradeToVer176,
 		upgradeToVer177,
+		upgradeToVer178,
+		upgradeToVer179,
+		upgradeToVer180,
+		upgradeToVer181,
+		upgradeToVer182,
 	}
 )
 
@@ -2846,6 +2913,45 @@ func upgradeToVer177(s Session, ver int64) {
 	}
 }
 
+func upgradeToVer178(s Session, ver int64) {
+	if ver >= version178 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.bind_info ADD COLUMN IF NOT EXISTS `comment` TEXT")
+}
+
+func upgradeToVer179(s Session, ver int64) {
+	if ver >= version179 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.bind_info ADD COLUMN IF NOT EXISTS `capture_users` TEXT")
+	doReentrantDDL(s, "ALTER TABLE mysql.bind_info ADD COLUMN IF NOT EXISTS `capture_sample_sql` TEXT")
+	doReentrantDDL(s, "ALTER TABLE mysql.bind_info ADD COLUMN IF NOT EXISTS `capture_avg_latency` BIGINT")
+	doReentrantDDL(s, "ALTER TABLE mysql.bind_info ADD COLUMN IF NOT EXISTS `capture_exec_count` BIGINT")
+}
+
+func upgradeToVer180(s Session, ver int64) {
+	if ver >= version180 {
+		return
+	}
+	doReentrantDDL(s, CreateBindPendingTasksTable)
+}
+
+func upgradeToVer181(s Session, ver int64) {
+	if ver >= version181 {
+		return
+	}
+	doReentrantDDL(s, CreateDDLIndexConflictsTable)
+}
+
+func upgradeToVer182(s Session, ver int64) {
+	if ver >= version182 {
+		return
+	}
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_global_task ADD COLUMN `priority` INT DEFAULT 512 NOT NULL AFTER `error`", infoschema.ErrColumnExists)
+	doReentrantDDL(s, "ALTER TABLE mysql.tidb_global_task_history ADD COLUMN `priority` INT DEFAULT 512 NOT NULL AFTER `error`", infoschema.ErrColumnExists)
+}
+
 func writeOOMAction(s Session) {
 	comment := "oom-action is `log` by default in v3.0.x, `cancel` by default in v4.0.11+"
 	mustExecute(s, `INSERT HIGH_PRIORITY INTO %n.%n VALUES (%?, %?, %?) ON DUPLICATE KEY UPDATE VARIABLE_VALUE= %?`,
@@ -2975,6 +3081,10 @@ func doDDLWorks(s Session) {
 	mustExecute(s, CreateDoneRunawayWatchTable)
 	// create dist_framework_meta
 	mustExecute(s, CreateDistFrameworkMeta)
+	// create bind_pending_tasks
+	mustExecute(s, CreateBindPendingTasksTable)
+	// create tidb_ddl_index_conflicts
+	mustExecute(s, CreateDDLIndexConflictsTable)
 }
 
 // doBootstrapSQLFile executes SQL commands in a file as the last stage of bootstrap.
@@ -557,6 +557,11 @@ func TestUpdateBindInfo(t *testing.T) {
 	defer dom.Close()
 	se := CreateSessionAndSetID(t, store)
 
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_exec_count")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_avg_latency")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_sample_sql")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_users")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists comment")
 	MustExec(t, se, "alter table mysql.bind_info drop column if exists plan_digest")
 	MustExec(t, se, "alter table mysql.bind_info drop column if exists sql_digest")
 	for _, bindCase := range bindCases {
@@ -597,6 +602,11 @@ func TestUpdateDuplicateBindInfo(t *testing.T) {
 	defer func() { require.NoError(t, store.Close()) }()
 	defer dom.Close()
 	se := CreateSessionAndSetID(t, store)
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_exec_count")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_avg_latency")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_sample_sql")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_users")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists comment")
 	MustExec(t, se, "alter table mysql.bind_info drop column if exists plan_digest")
 	MustExec(t, se, "alter table mysql.bind_info drop column if exists sql_digest")
 
@@ -925,6 +935,11 @@ func TestUpgradeToVer85(t *testing.T) {
 	defer func() { require.NoError(t, store.Close()) }()
 	defer dom.Close()
 	se := CreateSessionAndSetID(t, store)
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_exec_count")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_avg_latency")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_sample_sql")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists capture_users")
+	MustExec(t, se, "alter table mysql.bind_info drop column if exists comment")
 	MustExec(t, se, "alter table mysql.bind_info drop column if exists plan_digest")
 	MustExec(t, se, "alter table mysql.bind_info drop column if exists sql_digest")
 
@@ -2000,9 +2015,9 @@ func TestTiDBBindingInListToVer175(t *testing.T) {
 	// create some bindings at version174
 	MustExec(t, seV174, "use test")
 	MustExec(t, seV174, "create table t (a int, b int, c int, key(c))")
-	MustExec(t, seV174, "insert into mysql.bind_info values ('select * from `test` . `t` where `a` in ( ... )', 'SELECT /*+ use_index(`t` `c`)*/ * FROM `test`.`t` WHERE `a` IN (1,2,3)', 'test', 'enabled', '2023-09-13 14:41:38.319', '2023-09-13 14:41:35.319', 'utf8', 'utf8_general_ci', 'manual', '', '')")
-	MustExec(t, seV174, "insert into mysql.bind_info values ('select * from `test` . `t` where `a` in ( ? )', 'SELECT /*+ use_index(`t` `c`)*/ * FROM `test`.`t` WHERE `a` IN (1)', 'test', 'enabled', '2023-09-13 14:41:38.319', '2023-09-13 14:41:36.319', 'utf8', 'utf8_general_ci', 'manual', '', '')")
-	MustExec(t, seV174, "insert into mysql.bind_info values ('select * from `test` . `t` where `a` in ( ? ) and `b` in ( ... )', 'SELECT /*+ use_index(`t` `c`)*/ * FROM `test`.`t` WHERE `a` IN (1) AND `b` IN (1,2,3)', 'test', 'enabled', '2023-09-13 14:41:37.319', '2023-09-13 14:41:38.319', 'utf8', 'utf8_general_ci', 'manual', '', '')")
+	MustExec(t, seV174, "insert into mysql.bind_info values ('select * from `test` . `t` where `a` in ( ... )', 'SELECT /*+ use_index(`t` `c`)*/ * FROM `test`.`t` WHERE `a` IN (1,2,3)', 'test', 'enabled', '2023-09-13 14:41:38.319', '2023-09-13 14:41:35.319', 'utf8', 'utf8_general_ci', 'manual', '', '', NULL, NULL, NULL, NULL, NULL)")
+	MustExec(t, seV174, "insert into mysql.bind_info values ('select * from `test` . `t` where `a` in ( ? )', 'SELECT /*+ use_index(`t` `c`)*/ * FROM `test`.`t` WHERE `a` IN (1)', 'test', 'enabled', '2023-09-13 14:41:38.319', '2023-09-13 14:41:36.319', 'utf8', 'utf8_general_ci', 'manual', '', '', NULL, NULL, NULL, NULL, NULL)")
+	MustExec(t, seV174, "insert into mysql.bind_info values ('select * from `test` . `t` where `a` in ( ? ) and `b` in ( ... )', 'SELECT /*+ use_index(`t` `c`)*/ * FROM `test`.`t` WHERE `a` IN (1) AND `b` IN (1,2,3)', 'test', 'enabled', '2023-09-13 14:41:37.319', '2023-09-13 14:41:38.319', 'utf8', 'utf8_general_ci', 'manual', '', '', NULL, NULL, NULL, NULL, NULL)")
 
 	showBindings := func(s Session) (records []string) {
 		MustExec(t, s, "admin reload bindings")
@@ -2118,3 +2133,104 @@ func TestTiDBUpgradeToVer177(t *testing.T) {
 	MustExec(t, seV176, "SELECT * from mysql.dist_framework_meta")
 	dom.Close()
 }
+
+func TestTiDBUpgradeToVer180(t *testing.T) {
+	store, _ := CreateStoreAndBootstrap(t)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	ver179 := version179
+	seV179 := CreateSessionAndSetID(t, store)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMeta(txn)
+	err = m.FinishBootstrap(int64(ver179))
+	require.NoError(t, err)
+	MustExec(t, seV179, fmt.Sprintf("update mysql.tidb set variable_value=%d where variable_name='tidb_server_version'", ver179))
+	MustExec(t, seV179, "drop table mysql.bind_pending_tasks")
+	err = txn.Commit(context.Background())
+	require.NoError(t, err)
+
+	unsetStoreBootstrapped(store.UUID())
+	ver, err := getBootstrapVersion(seV179)
+	require.NoError(t, err)
+	require.Equal(t, int64(ver179), ver)
+
+	dom, err := BootstrapSession(store)
+	require.NoError(t, err)
+	ver, err = getBootstrapVersion(seV179)
+	require.NoError(t, err)
+	require.Less(t, int64(ver179), ver)
+	MustExec(t, seV179, "SELECT * from mysql.bind_pending_tasks")
+	dom.Close()
+}
+
+func TestTiDBUpgradeToVer181(t *testing.T) {
+	store, _ := CreateStoreAndBootstrap(t)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	ver180 := version180
+	seV180 := CreateSessionAndSetID(t, store)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMeta(txn)
+	err = m.FinishBootstrap(int64(ver180))
+	require.NoError(t, err)
+	MustExec(t, seV180, fmt.Sprintf("update mysql.tidb set variable_value=%d where variable_name='tidb_server_version'", ver180))
+	MustExec(t, seV180, "drop table mysql.tidb_ddl_index_conflicts")
+	err = txn.Commit(context.Background())
+	require.NoError(t, err)
+
+	unsetStoreBootstrapped(store.UUID())
+	ver, err := getBootstrapVersion(seV180)
+	require.NoError(t, err)
+	require.Equal(t, int64(ver180), ver)
+
+	dom, err := BootstrapSession(store)
+	require.NoError(t, err)
+	ver, err = getBootstrapVersion(seV180)
+	require.NoError(t, err)
+	require.Less(t, int64(ver180), ver)
+	MustExec(t, seV180, "SELECT * from mysql.tidb_ddl_index_conflicts")
+	dom.Close()
+}
+
+func TestTiDBUpgradeToVer182(t *testing.T) {
+	store, _ := CreateStoreAndBootstrap(t)
+	defer func() {
+		require.NoError(t, store.Close())
+	}()
+	ver181 := version181
+	seV181 := CreateSessionAndSetID(t, store)
+	txn, err := store.Begin()
+	require.NoError(t, err)
+	m := meta.NewMeta(txn)
+	err = m.FinishBootstrap(int64(ver181))
+	require.NoError(t, err)
+	MustExec(t, seV181, fmt.Sprintf("update mysql.tidb set variable_value=%d where variable_name='tidb_server_version'", ver181))
+	MustExec(t, seV181, "alter table mysql.tidb_global_task drop column priority")
+	MustExec(t, seV181, "alter table mysql.tidb_global_task_history drop column priority")
+	MustExec(t, seV181, "insert into mysql.tidb_global_task(task_key, type, state) values ('key1', 'test', 'pending')")
+	err = txn.Commit(context.Background())
+	require.NoError(t, err)
+
+	unsetStoreBootstrapped(store.UUID())
+	ver, err := getBootstrapVersion(seV181)
+	require.NoError(t, err)
+	require.Equal(t, int64(ver181), ver)
+
+	dom, err := BootstrapSession(store)
+	require.NoError(t, err)
+	ver, err = getBootstrapVersion(seV181)
+	require.NoError(t, err)
+	require.Less(t, int64(ver181), ver)
+	r := MustExecToRecodeSet(t, seV181, "select priority from mysql.tidb_global_task where task_key = 'key1'")
+	req := r.NewChunk(nil)
+	require.NoError(t, r.Next(context.Background(), req))
+	require.Equal(t, 1, req.NumRows())
+	require.Equal(t, int64(512), req.GetRow(0).GetInt64(0))
+	require.NoError(t, r.Close())
+	MustExec(t, seV181, "select priority from mysql.tidb_global_task_history")
+	dom.Close()
+}
@@ -3352,7 +3352,18 @@ func bootstrapSessionImpl(store kv.Storage, createSessionsImpl func(store kv.Sto
 	// We should make the load bind-info loop before other loops which has internal SQL.
 	// Because the internal SQL may access the global bind-info handler. As the result, the data race occurs here as the
 	// LoadBindInfoLoop inits global bind-info handler.
-	err = dom.LoadBindInfoLoop(ses[1], ses[2])
+	evolveCnt := config.GetGlobalConfig().Performance.BindInfoEvolveConcurrency
+	evolveCtxs := []sessionctx.Context{ses[2]}
+	if evolveCnt > 1 {
+		evolveSes, err := createSessions(store, int(evolveCnt)-1)
+		if err != nil {
+			return nil, err
+		}
+		for _, se := range evolveSes {
+			evolveCtxs = append(evolveCtxs, se)
+		}
+	}
+	err = dom.LoadBindInfoLoop(ses[1], evolveCtxs)
 	if err != nil {
 		return nil, err
 	}
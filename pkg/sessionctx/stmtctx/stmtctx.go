@@ -1288,10 +1288,15 @@ func (sc *StatementContext) GetStaleTSO() (uint64, error) {
 }
 
 // AddSetVarHintRestore records the variables which are affected by SET_VAR hint. And restore them to the old value later.
+// A variable may be set by the SET_VAR hints several times in a statement, e.g. by the hints of the query and the
+// bindings, only the value before the first change is recorded.
 func (sc *StatementContext) AddSetVarHintRestore(name, val string) {
 	if sc.SetVarHintRestore == nil {
 		sc.SetVarHintRestore = make(map[string]string)
 	}
+	if _, ok := sc.SetVarHintRestore[name]; ok {
+		return
+	}
 	sc.SetVarHintRestore[name] = val
 }
 
@@ -1028,6 +1028,9 @@ type SessionVars struct {
 	// DDLReorgPriority is the operation priority of adding indices.
 	DDLReorgPriority int
 
+	// DDLReorgMaxScanSpeed is the max number of rows per second each TiDB node scans when adding indices.
+	DDLReorgMaxScanSpeed int64
+
 	// EnableAutoIncrementInGenerated is used to control whether to allow auto incremented columns in generated columns.
 	EnableAutoIncrementInGenerated bool
 
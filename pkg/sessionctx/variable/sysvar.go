@@ -728,6 +728,38 @@ var defaultSysVars = []*SysVar{
 		MemQuotaBindingCache.Store(TidbOptInt64(val, DefTiDBMemQuotaBindingCache))
 		return nil
 	}},
+	{Scope: ScopeGlobal, Name: TiDBBindingGCRetention, Value: DefTiDBBindingGCRetention.String(), Type: TypeDuration, MinValue: 0, MaxValue: uint64(time.Hour * 24 * 365),
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return BindingGCRetention.Load().String(), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			d, err := time.ParseDuration(val)
+			if err != nil {
+				return err
+			}
+			BindingGCRetention.Store(d)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBBindingCollapseInList, Value: BoolToOnOff(DefTiDBBindingCollapseInList), Type: TypeBool,
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return BoolToOnOff(BindingCollapseInList.Load()), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			BindingCollapseInList.Store(TiDBOptOn(val))
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBBindingPlanDigestCheck, Value: DefTiDBBindingPlanDigestCheck, Type: TypeEnum, PossibleValues: []string{Off, On, Warn},
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return BindingPlanDigestCheck.Load(), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			BindingPlanDigestCheck.Store(val)
+			return nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBBindingFailoverLatencyRatio, Value: strconv.FormatFloat(DefTiDBBindingFailoverLatencyRatio, 'f', -1, 64), Type: TypeFloat, MinValue: 0, MaxValue: math.MaxUint64,
+		GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return strconv.FormatFloat(BindingFailoverLatencyRatio.Load(), 'f', -1, 64), nil
+		}, SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			BindingFailoverLatencyRatio.Store(tidbOptFloat64(val, DefTiDBBindingFailoverLatencyRatio))
+			return nil
+		}},
 	{Scope: ScopeGlobal, Name: TiDBDDLFlashbackConcurrency, Value: strconv.Itoa(DefTiDBDDLFlashbackConcurrency), Type: TypeUnsigned, MinValue: 1, MaxValue: MaxConfigurableConcurrency, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 		SetDDLFlashbackConcurrency(int32(tidbOptPositiveInt32(val, DefTiDBDDLFlashbackConcurrency)))
 		return nil
@@ -2311,6 +2343,59 @@ var defaultSysVars = []*SysVar{
 		DDLDiskQuota.Store(TidbOptUint64(val, DefTiDBDDLDiskQuota))
 		return nil
 	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLBackfillRegionBatch, Value: strconv.Itoa(DefTiDBDDLBackfillRegionBatch), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return strconv.FormatInt(DDLBackfillRegionBatch.Load(), 10), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLBackfillRegionBatch.Store(TidbOptInt64(val, DefTiDBDDLBackfillRegionBatch))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLReorgMaxWriteSpeed, Value: strconv.Itoa(DefTiDBDDLReorgMaxWriteSpeed), Type: TypeInt, MinValue: 0, MaxValue: 1024 * 1024, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return strconv.FormatInt(DDLReorgMaxWriteSpeed.Load(), 10), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLReorgMaxWriteSpeed.Store(TidbOptInt64(val, DefTiDBDDLReorgMaxWriteSpeed))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLGlobalSortCompression, Value: BoolToOnOff(DefTiDBDDLGlobalSortCompression), Type: TypeBool, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return BoolToOnOff(DDLGlobalSortCompression.Load()), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLGlobalSortCompression.Store(TiDBOptOn(val))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLReorgReplicaRead, Value: DefTiDBDDLReorgReplicaRead, Type: TypeEnum, PossibleValues: []string{"leader", "follower", "leader-and-follower", "closest-replicas"}, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return DDLReorgReplicaRead.Load(), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLReorgReplicaRead.Store(strings.ToLower(val))
+		return nil
+	}},
+	// The stale snapshot is kept from GC within the default tikv_gc_life_time.
+	{Scope: ScopeGlobal, Name: TiDBDDLReorgMaxStaleness, Value: strconv.Itoa(DefTiDBDDLReorgMaxStaleness), Type: TypeInt, MinValue: 0, MaxValue: 600, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return strconv.FormatInt(DDLReorgMaxStaleness.Load(), 10), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLReorgMaxStaleness.Store(TidbOptInt64(val, DefTiDBDDLReorgMaxStaleness))
+		return nil
+	}},
+	{Scope: ScopeGlobal | ScopeSession, Name: TiDBDDLReorgMaxScanSpeed, Value: strconv.Itoa(DefTiDBDDLReorgMaxScanSpeed), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32, SetSession: func(s *SessionVars, val string) error {
+		s.DDLReorgMaxScanSpeed = TidbOptInt64(val, DefTiDBDDLReorgMaxScanSpeed)
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLGlobalSortRangeGroupSize, Value: strconv.Itoa(DefTiDBDDLGlobalSortRangeGroupSize), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt64, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return strconv.FormatInt(DDLGlobalSortRangeGroupSize.Load(), 10), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLGlobalSortRangeGroupSize.Store(TidbOptInt64(val, DefTiDBDDLGlobalSortRangeGroupSize))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLGlobalSortMaxKeysPerRange, Value: strconv.Itoa(DefTiDBDDLGlobalSortMaxKeysPerRange), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return strconv.FormatInt(DDLGlobalSortMaxKeysPerRange.Load(), 10), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLGlobalSortMaxKeysPerRange.Store(TidbOptInt64(val, DefTiDBDDLGlobalSortMaxKeysPerRange))
+		return nil
+	}},
+	{Scope: ScopeGlobal, Name: TiDBDDLGlobalSortMaxRangesPerSubtask, Value: strconv.Itoa(DefTiDBDDLGlobalSortMaxRangesPerSubtask), Type: TypeInt, MinValue: 0, MaxValue: math.MaxInt32, GetGlobal: func(_ context.Context, sv *SessionVars) (string, error) {
+		return strconv.FormatInt(DDLGlobalSortMaxRangesPerSubtask.Load(), 10), nil
+	}, SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
+		DDLGlobalSortMaxRangesPerSubtask.Store(TidbOptInt64(val, DefTiDBDDLGlobalSortMaxRangesPerSubtask))
+		return nil
+	}},
 	// can't assign validate function here. Because validation function will run after GetGlobal function
 	{Scope: ScopeGlobal, Name: TiDBCloudStorageURI, Value: "", Type: TypeStr, GetGlobal: func(ctx context.Context, sv *SessionVars) (string, error) {
 		cloudStorageURI := CloudStorageURI.Load()
@@ -2939,6 +3024,37 @@ var defaultSysVars = []*SysVar{
 		}, GetGlobal: func(ctx context.Context, vars *SessionVars) (string, error) {
 			return ServiceScope.Load(), nil
 		}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskNodeLabels, Value: "", Type: TypeStr,
+		Validation: func(_ *SessionVars, normalizedValue string, originalValue string, _ ScopeFlag) (string, error) {
+			if _, err := distroleutil.ParseNodeLabels(originalValue); err != nil {
+				return normalizedValue, ErrWrongValueForVar.GenWithStackByArgs(TiDBDistTaskNodeLabels, originalValue)
+			}
+			return originalValue, nil
+		},
+		SetGlobal: func(_ context.Context, _ *SessionVars, s string) error {
+			DistTaskNodeLabels.Store(s)
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return DistTaskNodeLabels.Load(), nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskSubtaskMaxIdle, Value: DefTiDBDistTaskSubtaskMaxIdle.String(), Type: TypeDuration, MinValue: 0, MaxValue: uint64(time.Hour * 24 * 365),
+		SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			d, err := time.ParseDuration(val)
+			if err != nil {
+				return err
+			}
+			DistTaskSubtaskMaxIdle.Store(d)
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return DistTaskSubtaskMaxIdle.Load().String(), nil
+		}},
+	{Scope: ScopeGlobal, Name: TiDBDistTaskFenceStuckNode, Value: BoolToOnOff(DefTiDBDistTaskFenceStuckNode), Type: TypeBool,
+		SetGlobal: func(_ context.Context, _ *SessionVars, val string) error {
+			DistTaskFenceStuckNode.Store(TiDBOptOn(val))
+			return nil
+		}, GetGlobal: func(_ context.Context, _ *SessionVars) (string, error) {
+			return BoolToOnOff(DistTaskFenceStuckNode.Load()), nil
+		}},
 	{Scope: ScopeGlobal, Name: TiDBSchemaVersionCacheLimit, Value: strconv.Itoa(DefTiDBSchemaVersionCacheLimit), Type: TypeInt, MinValue: 2, MaxValue: math.MaxUint8, AllowEmpty: true,
 		SetGlobal: func(_ context.Context, s *SessionVars, val string) error {
 			SchemaVersionCacheLimit.Store(TidbOptInt64(val, DefTiDBSchemaVersionCacheLimit))
@@ -899,6 +899,87 @@ func TestSetTIDBDiskQuota(t *testing.T) {
 	require.Equal(t, strconv.FormatInt(pb, 10), val)
 }
 
+func TestSetTiDBDDLReorgMaxWriteSpeed(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	maxWriteSpeed := GetSysVar(TiDBDDLReorgMaxWriteSpeed)
+	// Default no limit
+	require.Equal(t, "0", maxWriteSpeed.Value)
+	defer DDLReorgMaxWriteSpeed.Store(DefTiDBDDLReorgMaxWriteSpeed)
+
+	err := mock.SetGlobalSysVar(context.Background(), TiDBDDLReorgMaxWriteSpeed, "100")
+	require.NoError(t, err)
+	val, err := mock.GetGlobalSysVar(TiDBDDLReorgMaxWriteSpeed)
+	require.NoError(t, err)
+	require.Equal(t, "100", val)
+	require.Equal(t, int64(100), DDLReorgMaxWriteSpeed.Load())
+
+	// Negative value is truncated to 0.
+	err = mock.SetGlobalSysVar(context.Background(), TiDBDDLReorgMaxWriteSpeed, "-1")
+	require.NoError(t, err)
+	require.Equal(t, int64(0), DDLReorgMaxWriteSpeed.Load())
+}
+
+func TestSetTiDBDDLReorgReplicaRead(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	require.Equal(t, "leader", GetSysVar(TiDBDDLReorgReplicaRead).Value)
+	require.Equal(t, "0", GetSysVar(TiDBDDLReorgMaxStaleness).Value)
+	defer DDLReorgReplicaRead.Store(DefTiDBDDLReorgReplicaRead)
+	defer DDLReorgMaxStaleness.Store(DefTiDBDDLReorgMaxStaleness)
+
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDDLReorgReplicaRead, "Follower"))
+	val, err := mock.GetGlobalSysVar(TiDBDDLReorgReplicaRead)
+	require.NoError(t, err)
+	require.Equal(t, "follower", val)
+	require.Equal(t, "follower", DDLReorgReplicaRead.Load())
+	require.Error(t, mock.SetGlobalSysVar(context.Background(), TiDBDDLReorgReplicaRead, "learner"))
+
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDDLReorgMaxStaleness, "30"))
+	require.Equal(t, int64(30), DDLReorgMaxStaleness.Load())
+	// The value is truncated to the range.
+	require.NoError(t, mock.SetGlobalSysVar(context.Background(), TiDBDDLReorgMaxStaleness, "3600"))
+	require.Equal(t, int64(600), DDLReorgMaxStaleness.Load())
+}
+
+func TestSetTiDBDDLGlobalSortRangeSplitter(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	defer DDLGlobalSortRangeGroupSize.Store(DefTiDBDDLGlobalSortRangeGroupSize)
+	defer DDLGlobalSortMaxKeysPerRange.Store(DefTiDBDDLGlobalSortMaxKeysPerRange)
+	defer DDLGlobalSortMaxRangesPerSubtask.Store(DefTiDBDDLGlobalSortMaxRangesPerSubtask)
+
+	for _, tc := range []struct {
+		name string
+		v    interface{ Load() int64 }
+	}{
+		{TiDBDDLGlobalSortRangeGroupSize, DDLGlobalSortRangeGroupSize},
+		{TiDBDDLGlobalSortMaxKeysPerRange, DDLGlobalSortMaxKeysPerRange},
+		{TiDBDDLGlobalSortMaxRangesPerSubtask, DDLGlobalSortMaxRangesPerSubtask},
+	} {
+		// Default computed by the dispatcher.
+		require.Equal(t, "0", GetSysVar(tc.name).Value)
+
+		err := mock.SetGlobalSysVar(context.Background(), tc.name, "1000")
+		require.NoError(t, err)
+		val, err := mock.GetGlobalSysVar(tc.name)
+		require.NoError(t, err)
+		require.Equal(t, "1000", val)
+		require.Equal(t, int64(1000), tc.v.Load())
+
+		// Negative value is truncated to 0.
+		err = mock.SetGlobalSysVar(context.Background(), tc.name, "-1")
+		require.NoError(t, err)
+		require.Equal(t, int64(0), tc.v.Load())
+	}
+}
+
 func TestTiDBServerMemoryLimit(t *testing.T) {
 	vars := NewSessionVars(nil)
 	mock := NewMockGlobalAccessor4Tests()
@@ -1429,6 +1510,14 @@ func TestSetTiDBCloudStorageURI(t *testing.T) {
 	require.Contains(t, val, "session-token=xxxxxx")
 	require.Equal(t, ks3URI, CloudStorageURI.Load())
 
+	// shared local path
+	localURI := "local://" + t.TempDir()
+	err = mock.SetGlobalSysVar(ctx, TiDBCloudStorageURI, localURI)
+	require.NoError(t, err)
+	require.Equal(t, localURI, CloudStorageURI.Load())
+	err = mock.SetGlobalSysVar(ctx, TiDBCloudStorageURI, "local://relative/path")
+	require.ErrorContains(t, err, "host is not supported")
+
 	// Set to empty, should return no error
 	err = mock.SetGlobalSysVar(ctx, TiDBCloudStorageURI, "")
 	require.NoError(t, err)
@@ -1437,3 +1526,56 @@ func TestSetTiDBCloudStorageURI(t *testing.T) {
 	require.Len(t, val, 0)
 	cancel()
 }
+
+func TestSetTiDBDistTaskNodeLabels(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	nodeLabels := GetSysVar(TiDBDistTaskNodeLabels)
+	require.Len(t, nodeLabels.Value, 0)
+	defer DistTaskNodeLabels.Store("")
+
+	ctx := context.Background()
+	require.NoError(t, mock.SetGlobalSysVar(ctx, TiDBDistTaskNodeLabels, "zone=z1,role=ddl"))
+	val, err := mock.GetGlobalSysVar(TiDBDistTaskNodeLabels)
+	require.NoError(t, err)
+	require.Equal(t, "zone=z1,role=ddl", val)
+	require.Equal(t, "zone=z1,role=ddl", DistTaskNodeLabels.Load())
+
+	for _, invalid := range []string{"zone", "zone=", "=z1", "zone=z1,zone=z2"} {
+		err = mock.SetGlobalSysVar(ctx, TiDBDistTaskNodeLabels, invalid)
+		require.ErrorContains(t, err, "Variable 'tidb_dist_task_node_labels' can't be set to the value of")
+	}
+	require.Equal(t, "zone=z1,role=ddl", DistTaskNodeLabels.Load())
+
+	require.NoError(t, mock.SetGlobalSysVar(ctx, TiDBDistTaskNodeLabels, ""))
+	require.Equal(t, "", DistTaskNodeLabels.Load())
+}
+
+func TestSetTiDBDistTaskSubtaskMaxIdle(t *testing.T) {
+	vars := NewSessionVars(nil)
+	mock := NewMockGlobalAccessor4Tests()
+	mock.SessionVars = vars
+	vars.GlobalVarsAccessor = mock
+	require.Equal(t, "0s", GetSysVar(TiDBDistTaskSubtaskMaxIdle).Value)
+	require.Equal(t, Off, GetSysVar(TiDBDistTaskFenceStuckNode).Value)
+	defer func() {
+		DistTaskSubtaskMaxIdle.Store(DefTiDBDistTaskSubtaskMaxIdle)
+		DistTaskFenceStuckNode.Store(DefTiDBDistTaskFenceStuckNode)
+	}()
+
+	ctx := context.Background()
+	require.NoError(t, mock.SetGlobalSysVar(ctx, TiDBDistTaskSubtaskMaxIdle, "10m"))
+	val, err := mock.GetGlobalSysVar(TiDBDistTaskSubtaskMaxIdle)
+	require.NoError(t, err)
+	require.Equal(t, "10m0s", val)
+	require.Equal(t, 10*time.Minute, DistTaskSubtaskMaxIdle.Load())
+	require.Error(t, mock.SetGlobalSysVar(ctx, TiDBDistTaskSubtaskMaxIdle, "abc"))
+	require.Equal(t, 10*time.Minute, DistTaskSubtaskMaxIdle.Load())
+
+	require.NoError(t, mock.SetGlobalSysVar(ctx, TiDBDistTaskFenceStuckNode, On))
+	require.True(t, DistTaskFenceStuckNode.Load())
+	require.NoError(t, mock.SetGlobalSysVar(ctx, TiDBDistTaskFenceStuckNode, Off))
+	require.False(t, DistTaskFenceStuckNode.Load())
+}
@@ -950,6 +950,19 @@ const (
 	TiDBStatsLoadPseudoTimeout = "tidb_stats_load_pseudo_timeout"
 	// TiDBMemQuotaBindingCache indicates the memory quota for the bind cache.
 	TiDBMemQuotaBindingCache = "tidb_mem_quota_binding_cache"
+	// TiDBBindingGCRetention indicates how long the deleted bindings are kept in mysql.bind_info before being
+	// physically removed. The deleted bindings are always kept for at least 10 bind info leases.
+	TiDBBindingGCRetention = "tidb_binding_gc_retention"
+	// TiDBBindingCollapseInList indicates whether to collapse the whole IN lists when normalizing the statements for
+	// bindings, so a binding matches the statements differ only in the IN lists, even if they contain non-literal items.
+	TiDBBindingCollapseInList = "tidb_binding_collapse_in_list"
+	// TiDBBindingPlanDigestCheck indicates whether to check that the plans generated by the bindings match the plan
+	// digests of the bindings. OFF disables the check, ON records the mismatches, and WARN also reports a warning.
+	TiDBBindingPlanDigestCheck = "tidb_binding_plan_digest_check"
+	// TiDBBindingFailoverLatencyRatio is the ratio of the average latency of the enabled binding in the latest statement
+	// summary interval to that in the previous intervals, beyond which a standby binding is switched to be enabled.
+	// 0 disables the automatic failover of the bindings.
+	TiDBBindingFailoverLatencyRatio = "tidb_binding_failover_latency_ratio"
 	// TiDBRCReadCheckTS indicates the tso optimization for read-consistency read is enabled.
 	TiDBRCReadCheckTS = "tidb_rc_read_check_ts"
 	// TiDBRCWriteCheckTs indicates whether some special write statements don't get latest tso from PD at RC
@@ -987,6 +1000,33 @@ const (
 	TiDBDDLDiskQuota = "tidb_ddl_disk_quota"
 	// TiDBCloudStorageURI used to set a cloud storage uri for ddl add index and import into.
 	TiDBCloudStorageURI = "tidb_cloud_storage_uri"
+	// TiDBDDLBackfillRegionBatch is the number of regions scanned by one subtask of the distributed add index.
+	// 0 means the number is computed from the region count of the table and the number of the eligible nodes.
+	TiDBDDLBackfillRegionBatch = "tidb_ddl_backfill_region_batch"
+	// TiDBDDLReorgMaxWriteSpeed is the max speed in MiB per second that each TiDB node writes the index
+	// records to TiKV when adding index with ingest. 0 means no limit.
+	TiDBDDLReorgMaxWriteSpeed = "tidb_ddl_reorg_max_write_speed"
+	// TiDBDDLGlobalSortCompression indicates whether to compress the sorted KV files written to the
+	// cloud storage by zstd when adding index with global sort.
+	TiDBDDLGlobalSortCompression = "tidb_ddl_global_sort_compression"
+	// TiDBDDLReorgReplicaRead is the replica read type of the table scans when adding index, reading
+	// the followers reduces the load of the leaders.
+	TiDBDDLReorgReplicaRead = "tidb_ddl_reorg_replica_read"
+	// TiDBDDLReorgMaxStaleness is the max staleness in seconds of the table scans when adding index,
+	// the scans read a stale snapshot within it. 0 means reading the latest data.
+	TiDBDDLReorgMaxStaleness = "tidb_ddl_reorg_max_staleness"
+	// TiDBDDLReorgMaxScanSpeed is the max number of rows per second that each TiDB node scans from the table
+	// when adding index. It's taken by the DDL job when it's submitted, 0 means no limit.
+	TiDBDDLReorgMaxScanSpeed = "tidb_ddl_reorg_max_scan_speed"
+	// TiDBDDLGlobalSortRangeGroupSize is the target size in bytes of the sorted KV ingested by one subtask
+	// when adding index with global sort. 0 means the total size is divided evenly by the number of nodes.
+	TiDBDDLGlobalSortRangeGroupSize = "tidb_ddl_global_sort_range_group_size"
+	// TiDBDDLGlobalSortMaxKeysPerRange is the max number of keys in a range ingested into TiKV when adding
+	// index with global sort. 0 means the region split keys of TiKV.
+	TiDBDDLGlobalSortMaxKeysPerRange = "tidb_ddl_global_sort_max_keys_per_range"
+	// TiDBDDLGlobalSortMaxRangesPerSubtask is the max number of ranges ingested by one subtask when adding
+	// index with global sort. 0 means no limit.
+	TiDBDDLGlobalSortMaxRangesPerSubtask = "tidb_ddl_global_sort_max_ranges_per_subtask"
 	// TiDBAutoBuildStatsConcurrency is used to set the build concurrency of auto-analyze.
 	TiDBAutoBuildStatsConcurrency = "tidb_auto_build_stats_concurrency"
 	// TiDBSysProcScanConcurrency is used to set the scan concurrency of for backend system processes, like auto-analyze.
@@ -1106,6 +1146,15 @@ const (
 	TiDBSessionAlias = "tidb_session_alias"
 	// TiDBServiceScope indicates the role for tidb for distributed task framework.
 	TiDBServiceScope = "tidb_service_scope"
+	// TiDBDistTaskNodeLabels restricts the nodes that run the subtasks of the distributed tasks to the ones
+	// carrying all the labels, in the format of "key1=value1,key2=value2".
+	TiDBDistTaskNodeLabels = "tidb_dist_task_node_labels"
+	// TiDBDistTaskSubtaskMaxIdle is the max duration that a running subtask of the distributed tasks can go without
+	// any progress, such as the checkpoint or the row count, after that it's rescheduled to run again. 0 disables it.
+	TiDBDistTaskSubtaskMaxIdle = "tidb_dist_task_subtask_max_idle"
+	// TiDBDistTaskFenceStuckNode indicates whether the node of a stuck subtask is excluded from the task
+	// after the subtask is rescheduled, see TiDBDistTaskSubtaskMaxIdle.
+	TiDBDistTaskFenceStuckNode = "tidb_dist_task_fence_stuck_node"
 	// TiDBSchemaVersionCacheLimit defines the capacity size of domain infoSchema cache.
 	TiDBSchemaVersionCacheLimit = "tidb_schema_version_cache_limit"
 	// TiDBEnableTiFlashPipelineMode means if we should use pipeline model to execute query or not in tiflash.
@@ -1176,6 +1225,10 @@ const (
 	DefWaitTimeout                                 = 28800
 	DefTiDBMemQuotaApplyCache                      = 32 << 20 // 32MB.
 	DefTiDBMemQuotaBindingCache                    = 64 << 20 // 64MB.
+	DefTiDBBindingGCRetention                      = 0 * time.Second
+	DefTiDBBindingCollapseInList                   = false
+	DefTiDBBindingPlanDigestCheck                  = Off
+	DefTiDBBindingFailoverLatencyRatio             = 0.0
 	DefTiDBGeneralLog                              = false
 	DefTiDBPProfSQLCPU                             = 0
 	DefTiDBRetryLimit                              = 10
@@ -1338,6 +1391,15 @@ const (
 	DefMemoryUsageAlarmKeepRecordNum               = 5
 	DefTiDBEnableFastReorg                         = true
 	DefTiDBDDLDiskQuota                            = 100 * 1024 * 1024 * 1024 // 100GB
+	DefTiDBDDLBackfillRegionBatch                  = 0
+	DefTiDBDDLReorgMaxWriteSpeed                   = 0
+	DefTiDBDDLGlobalSortCompression                = false
+	DefTiDBDDLReorgReplicaRead                     = "leader"
+	DefTiDBDDLReorgMaxStaleness                    = 0
+	DefTiDBDDLReorgMaxScanSpeed                    = 0
+	DefTiDBDDLGlobalSortRangeGroupSize             = 0
+	DefTiDBDDLGlobalSortMaxKeysPerRange            = 0
+	DefTiDBDDLGlobalSortMaxRangesPerSubtask        = 0
 	DefExecutorConcurrency                         = 5
 	DefTiDBEnableNonPreparedPlanCache              = false
 	DefTiDBEnableNonPreparedPlanCacheForDML        = false
@@ -1424,6 +1486,8 @@ const (
 	DefTiDBOptEnableHashJoin                          = true
 	DefTiDBOptObjective                               = OptObjectiveModerate
 	DefTiDBSchemaVersionCacheLimit                    = 16
+	DefTiDBDistTaskSubtaskMaxIdle                     = 0 * time.Second
+	DefTiDBDistTaskFenceStuckNode                     = false
 )
 
 // Process global variables.
@@ -1460,6 +1524,10 @@ var (
 	StatsLoadSyncWait                    = atomic.NewInt64(DefTiDBStatsLoadSyncWait)
 	StatsLoadPseudoTimeout               = atomic.NewBool(DefTiDBStatsLoadPseudoTimeout)
 	MemQuotaBindingCache                 = atomic.NewInt64(DefTiDBMemQuotaBindingCache)
+	BindingGCRetention                   = atomic.NewDuration(DefTiDBBindingGCRetention)
+	BindingCollapseInList                = atomic.NewBool(DefTiDBBindingCollapseInList)
+	BindingPlanDigestCheck               = atomic.NewString(DefTiDBBindingPlanDigestCheck)
+	BindingFailoverLatencyRatio          = atomic.NewFloat64(DefTiDBBindingFailoverLatencyRatio)
 	GCMaxWaitTime                        = atomic.NewInt64(DefTiDBGCMaxWaitTime)
 	StatsCacheMemQuota                   = atomic.NewInt64(DefTiDBStatsCacheMemQuota)
 	OOMAction                            = atomic.NewString(DefTiDBMemOOMAction)
@@ -1475,6 +1543,22 @@ var (
 	EnableFastReorg = atomic.NewBool(DefTiDBEnableFastReorg)
 	// DDLDiskQuota is the temporary variable for set disk quota for lightning
 	DDLDiskQuota = atomic.NewUint64(DefTiDBDDLDiskQuota)
+	// DDLBackfillRegionBatch is the number of regions scanned by one subtask of the distributed add index.
+	DDLBackfillRegionBatch = atomic.NewInt64(DefTiDBDDLBackfillRegionBatch)
+	// DDLReorgMaxWriteSpeed is the max write speed of the ingest in MiB per second.
+	DDLReorgMaxWriteSpeed = atomic.NewInt64(DefTiDBDDLReorgMaxWriteSpeed)
+	// DDLGlobalSortCompression indicates whether to compress the sorted KV files of global sort.
+	DDLGlobalSortCompression = atomic.NewBool(DefTiDBDDLGlobalSortCompression)
+	// DDLReorgReplicaRead is the replica read type of the table scans when adding index.
+	DDLReorgReplicaRead = atomic.NewString(DefTiDBDDLReorgReplicaRead)
+	// DDLReorgMaxStaleness is the max staleness in seconds of the table scans when adding index.
+	DDLReorgMaxStaleness = atomic.NewInt64(DefTiDBDDLReorgMaxStaleness)
+	// DDLGlobalSortRangeGroupSize is the target size of the sorted KV ingested by one subtask of global sort.
+	DDLGlobalSortRangeGroupSize = atomic.NewInt64(DefTiDBDDLGlobalSortRangeGroupSize)
+	// DDLGlobalSortMaxKeysPerRange is the max number of keys in a range ingested by global sort.
+	DDLGlobalSortMaxKeysPerRange = atomic.NewInt64(DefTiDBDDLGlobalSortMaxKeysPerRange)
+	// DDLGlobalSortMaxRangesPerSubtask is the max number of ranges ingested by one subtask of global sort.
+	DDLGlobalSortMaxRangesPerSubtask = atomic.NewInt64(DefTiDBDDLGlobalSortMaxRangesPerSubtask)
 	// EnableForeignKey indicates whether to enable foreign key feature.
 	EnableForeignKey    = atomic.NewBool(true)
 	EnableRCReadCheckTS = atomic.NewBool(false)
@@ -1521,6 +1605,9 @@ var (
 	SkipMissingPartitionStats = atomic.NewBool(DefTiDBSkipMissingPartitionStats)
 	TiFlashEnablePipelineMode = atomic.NewBool(DefTiDBEnableTiFlashPipelineMode)
 	ServiceScope              = atomic.NewString("")
+	DistTaskNodeLabels        = atomic.NewString("")
+	DistTaskSubtaskMaxIdle    = atomic.NewDuration(DefTiDBDistTaskSubtaskMaxIdle)
+	DistTaskFenceStuckNode    = atomic.NewBool(DefTiDBDistTaskFenceStuckNode)
 	SchemaVersionCacheLimit   = atomic.NewInt64(DefTiDBSchemaVersionCacheLimit)
 	CloudStorageURI           = atomic.NewString("")
 )
@@ -14,7 +14,10 @@
 
 package distroleutil
 
-import "strings"
+import (
+	"fmt"
+	"strings"
+)
 
 const (
 	// TiDBServiceScopeDefault indicates no role.
@@ -35,3 +38,25 @@ func ToTiDBServiceScope(name string) (string, bool) {
 		return "", false
 	}
 }
+
+// ParseNodeLabels parses the node labels in the format of "key1=value1,key2=value2", which
+// restrict the nodes that run the distributed tasks. An empty string means no restriction.
+func ParseNodeLabels(s string) (map[string]string, error) {
+	s = strings.TrimSpace(s)
+	if len(s) == 0 {
+		return nil, nil
+	}
+	labels := make(map[string]string)
+	for _, item := range strings.Split(s, ",") {
+		key, value, ok := strings.Cut(item, "=")
+		key, value = strings.TrimSpace(key), strings.TrimSpace(value)
+		if !ok || len(key) == 0 || len(value) == 0 {
+			return nil, fmt.Errorf("invalid node label %q, the format should be key=value", item)
+		}
+		if _, dup := labels[key]; dup {
+			return nil, fmt.Errorf("duplicate node label key %q", key)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
@@ -69,3 +69,24 @@ func GenerateSubtaskExecID4Test(id string) string {
 	}
 	return ""
 }
+
+// FilterServerInfosByLabels returns the serverInfos whose labels contain all the given labels.
+func FilterServerInfosByLabels(serverInfos []*infosync.ServerInfo, labels map[string]string) []*infosync.ServerInfo {
+	if len(labels) == 0 {
+		return serverInfos
+	}
+	res := make([]*infosync.ServerInfo, 0, len(serverInfos))
+	for _, serverInfo := range serverInfos {
+		matched := true
+		for k, v := range labels {
+			if serverInfo.Labels[k] != v {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			res = append(res, serverInfo)
+		}
+	}
+	return res
+}
@@ -17,6 +17,7 @@ package disttaskutil
 import (
 	"testing"
 
+	"github.com/pingcap/tidb/pkg/domain/infosync"
 	"github.com/stretchr/testify/require"
 )
 
@@ -35,3 +36,23 @@ func TestGenServerID(t *testing.T) {
 	serverIO = GenerateExecID("ABCD:EF01:2345:6789:ABCD:EF01:2345:6789", 65537)
 	require.Equal(t, serverIO, "[ABCD:EF01:2345:6789:ABCD:EF01:2345:6789]:65537")
 }
+
+func TestFilterServerInfosByLabels(t *testing.T) {
+	serverInfos := []*infosync.ServerInfo{
+		{ID: "1", Labels: map[string]string{"zone": "z1", "role": "ddl"}},
+		{ID: "2", Labels: map[string]string{"zone": "z2", "role": "ddl"}},
+		{ID: "3", Labels: map[string]string{"zone": "z1"}},
+		{ID: "4"},
+	}
+	getIDs := func(infos []*infosync.ServerInfo) []string {
+		ids := make([]string, 0, len(infos))
+		for _, info := range infos {
+			ids = append(ids, info.ID)
+		}
+		return ids
+	}
+	require.Equal(t, []string{"1", "2", "3", "4"}, getIDs(FilterServerInfosByLabels(serverInfos, nil)))
+	require.Equal(t, []string{"1", "3"}, getIDs(FilterServerInfosByLabels(serverInfos, map[string]string{"zone": "z1"})))
+	require.Equal(t, []string{"1"}, getIDs(FilterServerInfosByLabels(serverInfos, map[string]string{"zone": "z1", "role": "ddl"})))
+	require.Empty(t, FilterServerInfosByLabels(serverInfos, map[string]string{"zone": "z3"}))
+}
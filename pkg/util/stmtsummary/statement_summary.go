@@ -20,6 +20,7 @@ import (
 	"container/list"
 	"fmt"
 	"math"
+	"math/rand"
 	"slices"
 	"strconv"
 	"strings"
@@ -29,6 +30,7 @@ import (
 
 	"github.com/pingcap/failpoint"
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/chunk"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/hack"
@@ -211,6 +213,10 @@ type stmtSummaryByDigestElement struct {
 	// pessimistic execution retry information.
 	execRetryCount uint
 	execRetryTime  time.Duration
+	// paramSamples are the parameter values sampled from the executions of the prepared statement.
+	paramSamples [][]types.Datum
+	// bindingMatches are the executions whose plans are generated by bindings, the key is the bind sql.
+	bindingMatches map[string]*BindingMatch
 }
 
 // StmtExecInfo records execution information of each statement.
@@ -241,6 +247,7 @@ type StmtExecInfo struct {
 	Succeed             bool
 	PlanInCache         bool
 	PlanInBinding       bool
+	BindSQL             string
 	ExecRetryCount      uint
 	ExecRetryTime       time.Duration
 	execdetails.StmtExecDetails
@@ -249,6 +256,9 @@ type StmtExecInfo struct {
 	Prepared        bool
 	KeyspaceName    string
 	KeyspaceID      uint32
+
+	// ParamValues are the parameter values if it is a prepared statement.
+	ParamValues []types.Datum
 }
 
 // newStmtSummaryByDigestMap creates an empty stmtSummaryByDigestMap.
@@ -381,6 +391,101 @@ type BindableStmt struct {
 	Charset   string
 	Collation string
 	Users     map[string]struct{} // which users have processed this stmt
+	// ParamSamples are the parameter values sampled from the executions if it is a prepared statement.
+	ParamSamples [][]types.Datum
+	// SampleSQL is a sample of the executed statement text, it is different from Query if it is a prepared statement.
+	SampleSQL        string
+	ExecCount        int64
+	AvgLatency       time.Duration
+	AvgProcessedKeys int64
+}
+
+// MaxParamSamples is the max number of parameter value sets sampled for each prepared statement.
+const MaxParamSamples = 5
+
+// AddParamSample samples the parameter values of the execCount-th execution by reservoir sampling, so that each
+// execution has the same chance to be kept. The parameter values are copied since they are reused by the session.
+func AddParamSample(samples [][]types.Datum, params []types.Datum, execCount int64) [][]types.Datum {
+	if len(samples) < MaxParamSamples {
+		return append(samples, types.CloneRow(params))
+	}
+	if i := rand.Int63n(execCount); i < MaxParamSamples {
+		samples[i] = types.CloneRow(params)
+	}
+	return samples
+}
+
+// BindingMatch is the statistics of the executions whose plans are generated by a binding.
+type BindingMatch struct {
+	ExecCount  int64
+	SumLatency time.Duration
+	LastSeen   time.Time
+}
+
+// BindingMatchedStmt is a statement whose plans are generated by a binding in a summary interval.
+type BindingMatchedStmt struct {
+	// BindSQL is the bind sql of the binding.
+	BindSQL    string
+	SchemaName string
+	Digest     string
+	// BeginTime is the begin time of the summary interval.
+	BeginTime time.Time
+	BindingMatch
+}
+
+// AddBindingMatch records an execution whose plan is generated by the binding of bindSQL.
+func AddBindingMatch(matches map[string]*BindingMatch, bindSQL string, execTime time.Time, latency time.Duration) map[string]*BindingMatch {
+	if len(bindSQL) == 0 {
+		return matches
+	}
+	if matches == nil {
+		matches = make(map[string]*BindingMatch)
+	}
+	match, ok := matches[bindSQL]
+	if !ok {
+		match = &BindingMatch{}
+		matches[bindSQL] = match
+	}
+	match.ExecCount++
+	match.SumLatency += latency
+	if execTime.After(match.LastSeen) {
+		match.LastSeen = execTime
+	}
+	return matches
+}
+
+// GetBindingMatchedStmts gets the statements whose plans are generated by bindings in the summary intervals
+// which end after the specified time.
+func (ssMap *stmtSummaryByDigestMap) GetBindingMatchedStmts(since time.Time) []*BindingMatchedStmt {
+	ssMap.Lock()
+	values := ssMap.summaryMap.Values()
+	ssMap.Unlock()
+
+	var stmts []*BindingMatchedStmt
+	for _, value := range values {
+		ssbd := value.(*stmtSummaryByDigest)
+		ssbd.Lock()
+		for elem := ssbd.history.Back(); elem != nil; elem = elem.Prev() {
+			ssElement := elem.Value.(*stmtSummaryByDigestElement)
+			ssElement.Lock()
+			if ssElement.endTime <= since.Unix() {
+				ssElement.Unlock()
+				break
+			}
+			for bindSQL, match := range ssElement.bindingMatches {
+				stmts = append(stmts, &BindingMatchedStmt{
+					BindSQL:      bindSQL,
+					SchemaName:   ssbd.schemaName,
+					Digest:       ssbd.digest,
+					BeginTime:    time.Unix(ssElement.beginTime, 0),
+					BindingMatch: *match,
+				})
+			}
+			ssElement.Unlock()
+		}
+		ssbd.Unlock()
+	}
+	return stmts
 }
 
 // GetMoreThanCntBindableStmt gets users' select/update/delete SQLs that occurred more than the specified count.
@@ -411,6 +516,13 @@ func (ssMap *stmtSummaryByDigestMap) GetMoreThanCntBindableStmt(cnt int64) []*Bi
 							Users:     make(map[string]struct{}),
 						}
 						maps.Copy(stmt.Users, ssElement.authUsers)
+						stmt.ParamSamples = slices.Clone(ssElement.paramSamples)
+						stmt.SampleSQL = ssElement.sampleSQL
+						stmt.ExecCount = ssElement.execCount
+						if ssElement.execCount > 0 {
+							stmt.AvgLatency = ssElement.sumLatency / time.Duration(ssElement.execCount)
+							stmt.AvgProcessedKeys = ssElement.sumProcessedKeys / ssElement.execCount
+						}
 						// If it is SQL command prepare / execute, the ssElement.sampleSQL is `execute ...`, we should get the original select query.
 						// If it is binary protocol prepare / execute, ssbd.normalizedSQL should be same as ssElement.sampleSQL.
 						if ssElement.prepared {
@@ -701,6 +813,9 @@ func (ssElement *stmtSummaryByDigestElement) add(sei *StmtExecInfo, intervalSeco
 	// refreshInterval may change anytime, update endTime ASAP.
 	ssElement.endTime = ssElement.beginTime + intervalSeconds
 	ssElement.execCount++
+	if sei.Prepared && len(sei.ParamValues) > 0 {
+		ssElement.paramSamples = AddParamSample(ssElement.paramSamples, sei.ParamValues, ssElement.execCount)
+	}
 	if !sei.Succeed {
 		ssElement.sumErrors++
 	}
@@ -850,6 +965,7 @@ func (ssElement *stmtSummaryByDigestElement) add(sei *StmtExecInfo, intervalSeco
 	// SPM
 	if sei.PlanInBinding {
 		ssElement.planInBinding = true
+		ssElement.bindingMatches = AddBindingMatch(ssElement.bindingMatches, sei.BindSQL, sei.StartTime, sei.TotalLatency)
 	} else {
 		ssElement.planInBinding = false
 	}
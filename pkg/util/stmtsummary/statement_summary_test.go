@@ -1450,3 +1450,24 @@ func TestAccessPrivilege(t *testing.T) {
 	datums = reader.GetStmtSummaryHistoryRows()
 	require.Len(t, datums, loops)
 }
+
+func TestAddParamSample(t *testing.T) {
+	var samples [][]types.Datum
+	params := []types.Datum{types.NewIntDatum(0)}
+	for i := int64(1); i <= 100; i++ {
+		params[0].SetInt64(i)
+		samples = AddParamSample(samples, params, i)
+		require.LessOrEqual(t, len(samples), MaxParamSamples)
+	}
+	require.Len(t, samples, MaxParamSamples)
+	// The sampled parameters are copied, they are not changed with the parameters of the session.
+	params[0].SetInt64(0)
+	seen := make(map[int64]struct{}, len(samples))
+	for _, sample := range samples {
+		require.Len(t, sample, 1)
+		v := sample[0].GetInt64()
+		require.True(t, v >= 1 && v <= 100)
+		seen[v] = struct{}{}
+	}
+	require.Len(t, seen, MaxParamSamples)
+}
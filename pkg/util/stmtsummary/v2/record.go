@@ -24,6 +24,7 @@ import (
 	"time"
 
 	"github.com/pingcap/tidb/pkg/sessionctx/stmtctx"
+	"github.com/pingcap/tidb/pkg/types"
 	"github.com/pingcap/tidb/pkg/util/execdetails"
 	"github.com/pingcap/tidb/pkg/util/plancodec"
 	"github.com/pingcap/tidb/pkg/util/stmtsummary"
@@ -147,6 +148,11 @@ type StmtRecord struct {
 
 	KeyspaceName string `json:"keyspace_name,omitempty"`
 	KeyspaceID   uint32 `json:"keyspace_id,omitempty"`
+
+	// ParamSamples are only kept in memory for plan evolution, they are not persisted.
+	ParamSamples [][]types.Datum `json:"-"`
+	// BindingMatches are only kept in memory for the binding coverage report, they are not persisted.
+	BindingMatches map[string]*stmtsummary.BindingMatch `json:"-"`
 }
 
 // NewStmtRecord creates a new StmtRecord from StmtExecInfo.
@@ -225,6 +231,9 @@ func (r *StmtRecord) Add(info *stmtsummary.StmtExecInfo) {
 		r.AuthUsers[info.User] = struct{}{}
 	}
 	r.ExecCount++
+	if info.Prepared && len(info.ParamValues) > 0 {
+		r.ParamSamples = stmtsummary.AddParamSample(r.ParamSamples, info.ParamValues, r.ExecCount)
+	}
 	if !info.Succeed {
 		r.SumErrors++
 	}
@@ -367,6 +376,7 @@ func (r *StmtRecord) Add(info *stmtsummary.StmtExecInfo) {
 	// SPM
 	if info.PlanInBinding {
 		r.PlanInBinding = true
+		r.BindingMatches = stmtsummary.AddBindingMatch(r.BindingMatches, info.BindSQL, info.StartTime, info.TotalLatency)
 	} else {
 		r.PlanInBinding = false
 	}
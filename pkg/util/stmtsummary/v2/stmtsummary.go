@@ -19,6 +19,7 @@ import (
 	"errors"
 	"maps"
 	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -361,6 +362,13 @@ func (s *StmtSummary) GetMoreThanCntBindableStmt(cnt int64) []*stmtsummary.Binda
 						Users:     make(map[string]struct{}),
 					}
 					maps.Copy(stmt.Users, record.AuthUsers)
+					stmt.ParamSamples = slices.Clone(record.ParamSamples)
+					stmt.SampleSQL = record.SampleSQL
+					stmt.ExecCount = record.ExecCount
+					if record.ExecCount > 0 {
+						stmt.AvgLatency = record.SumLatency / time.Duration(record.ExecCount)
+						stmt.AvgProcessedKeys = record.SumProcessedKeys / record.ExecCount
+					}
 
 					// If it is SQL command prepare / execute, the ssElement.sampleSQL
 					// is `execute ...`, we should get the original select query.
@@ -377,6 +385,36 @@ func (s *StmtSummary) GetMoreThanCntBindableStmt(cnt int64) []*stmtsummary.Binda
 	return stmts
 }
 
+// GetBindingMatchedStmts is used to get the statements whose plans are
+// generated by bindings. Like GetMoreThanCntBindableStmt, only the current
+// window in memory is referred to, and the statements last executed with
+// the bindings before the specified time are skipped.
+func (s *StmtSummary) GetBindingMatchedStmts(since time.Time) []*stmtsummary.BindingMatchedStmt {
+	s.windowLock.Lock()
+	values := s.window.lru.Values()
+	begin := s.window.begin
+	s.windowLock.Unlock()
+	var stmts []*stmtsummary.BindingMatchedStmt
+	for _, value := range values {
+		record := value.(*lockedStmtRecord)
+		record.Lock()
+		for bindSQL, match := range record.BindingMatches {
+			if match.LastSeen.Before(since) {
+				continue
+			}
+			stmts = append(stmts, &stmtsummary.BindingMatchedStmt{
+				BindSQL:      bindSQL,
+				SchemaName:   record.SchemaName,
+				Digest:       record.Digest,
+				BeginTime:    begin,
+				BindingMatch: *match,
+			})
+		}
+		record.Unlock()
+	}
+	return stmts
+}
+
 func (s *StmtSummary) rotateLoop() {
 	tick := time.NewTicker(defaultRotateCheckInterval * time.Second)
 	defer tick.Stop()
@@ -611,3 +649,12 @@ func GetMoreThanCntBindableStmt(frequency int64) []*stmtsummary.BindableStmt {
 	}
 	return stmtsummary.StmtSummaryByDigestMap.GetMoreThanCntBindableStmt(frequency)
 }
+
+// GetBindingMatchedStmts wraps GlobalStmtSummary.GetBindingMatchedStmts and
+// stmtsummary.StmtSummaryByDigestMap.GetBindingMatchedStmts.
+func GetBindingMatchedStmts(since time.Time) []*stmtsummary.BindingMatchedStmt {
+	if config.GetGlobalConfig().Instance.StmtSummaryEnablePersistent {
+		return GlobalStmtSummary.GetBindingMatchedStmts(since)
+	}
+	return stmtsummary.StmtSummaryByDigestMap.GetBindingMatchedStmts(since)
+}
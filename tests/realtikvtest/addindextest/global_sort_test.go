@@ -28,6 +28,7 @@ import (
 	"github.com/pingcap/tidb/br/pkg/storage"
 	"github.com/pingcap/tidb/pkg/ddl/util/callback"
 	"github.com/pingcap/tidb/pkg/disttask/framework/dispatcher"
+	"github.com/pingcap/tidb/pkg/errno"
 	"github.com/pingcap/tidb/pkg/parser/model"
 	"github.com/pingcap/tidb/pkg/sessionctx/variable"
 	"github.com/pingcap/tidb/pkg/testkit"
@@ -185,3 +186,143 @@ func TestGlobalSortMultiSchemaChange(t *testing.T) {
 	tk.MustExec("set @@global.tidb_enable_dist_task = 0;")
 	tk.MustExec("set @@global.tidb_cloud_storage_uri = '';")
 }
+
+func TestGlobalSortPartitionTable(t *testing.T) {
+	gcsHost, gcsPort, cloudStorageURI := genStorageURI(t)
+	opt := fakestorage.Options{
+		Scheme:     "http",
+		Host:       gcsHost,
+		Port:       gcsPort,
+		PublicHost: gcsHost,
+	}
+	server, err := fakestorage.NewServerWithOptions(opt)
+	require.NoError(t, err)
+	server.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: "sorted"})
+
+	store := realtikvtest.CreateMockStoreAndSetup(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("drop database if exists addindexlit;")
+	tk.MustExec("create database addindexlit;")
+	tk.MustExec("use addindexlit;")
+	tk.MustExec(`set @@global.tidb_ddl_enable_fast_reorg = 1;`)
+	tk.MustExec("set @@global.tidb_enable_dist_task = 1;")
+	tk.MustExec(fmt.Sprintf(`set @@global.tidb_cloud_storage_uri = "%s"`, cloudStorageURI))
+	defer func() {
+		tk.MustExec("set @@global.tidb_enable_dist_task = 0;")
+		variable.CloudStorageURI.Store("")
+	}()
+
+	tk.MustExec("create table t (a int, b int, c int) partition by hash(a) partitions 4;")
+	var sb strings.Builder
+	sb.WriteString("insert into t values ")
+	size := 100
+	for i := 0; i < size; i++ {
+		sb.WriteString(fmt.Sprintf("(%d, %d, %d)", i, i, i))
+		if i != size-1 {
+			sb.WriteString(",")
+		}
+	}
+	sb.WriteString(";")
+	tk.MustExec(sb.String())
+
+	tk.MustExec("alter table t add index idx(a), add index idx1(b);")
+	tk.MustExec("admin check table t;")
+
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/ddl/forceMergeSort", "return()"))
+	tk.MustExec("alter table t add index idx2(c);")
+	tk.MustExec("admin check table t;")
+	require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/ddl/forceMergeSort"))
+
+	tk.MustExec("insert into t values (0, 0, 100);")
+	tk.MustGetErrCode("alter table t add unique index idx3(a, b);", errno.ErrDupEntry)
+}
+
+func TestGlobalSortCheckpoint(t *testing.T) {
+	gcsHost, gcsPort, cloudStorageURI := genStorageURI(t)
+	opt := fakestorage.Options{
+		Scheme:     "http",
+		Host:       gcsHost,
+		Port:       gcsPort,
+		PublicHost: gcsHost,
+	}
+	server, err := fakestorage.NewServerWithOptions(opt)
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+	server.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: "sorted"})
+
+	store := realtikvtest.CreateMockStoreAndSetup(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("drop database if exists addindexlit;")
+	tk.MustExec("create database addindexlit;")
+	tk.MustExec("use addindexlit;")
+	tk.MustExec(`set @@global.tidb_ddl_enable_fast_reorg = 1;`)
+	tk.MustExec("set @@global.tidb_enable_dist_task = 1;")
+	defer func() {
+		tk.MustExec("set @@global.tidb_enable_dist_task = 0;")
+		variable.CloudStorageURI.Store("")
+	}()
+
+	tk.MustExec("create table t (a int primary key, b int, c int);")
+	for i := 0; i < 100; i++ {
+		tk.MustExec(fmt.Sprintf("insert into t values (%d, %d, %d);", i, i, i))
+	}
+	tk.MustExec("split table t between (0) and (100) regions 10;")
+
+	// Make a checkpoint before every table scan task.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/ddl/mockReadIndexCheckpointInterval", "return(0)"))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/ddl/mockReadIndexCheckpointInterval"))
+	}()
+	tk.MustExec("alter table t add index idx(b);")
+	tk.MustExec("admin check table t;")
+
+	tk.MustExec(fmt.Sprintf(`set @@global.tidb_cloud_storage_uri = "%s"`, cloudStorageURI))
+	tk.MustExec("alter table t add unique index idx1(c);")
+	tk.MustExec("admin check table t;")
+	tk.MustExec("insert into t values (100, 100, 1);")
+	tk.MustGetErrCode("alter table t add unique index idx2(c);", errno.ErrDupEntry)
+}
+
+func TestGlobalSortFallbackToLocalSort(t *testing.T) {
+	gcsHost, gcsPort, cloudStorageURI := genStorageURI(t)
+	opt := fakestorage.Options{
+		Scheme:     "http",
+		Host:       gcsHost,
+		Port:       gcsPort,
+		PublicHost: gcsHost,
+	}
+	server, err := fakestorage.NewServerWithOptions(opt)
+	require.NoError(t, err)
+	t.Cleanup(server.Stop)
+	server.CreateBucketWithOpts(fakestorage.CreateBucketOpts{Name: "sorted"})
+
+	store := realtikvtest.CreateMockStoreAndSetup(t)
+	tk := testkit.NewTestKit(t, store)
+	tk.MustExec("drop database if exists addindexlit;")
+	tk.MustExec("create database addindexlit;")
+	tk.MustExec("use addindexlit;")
+	tk.MustExec(`set @@global.tidb_ddl_enable_fast_reorg = 1;`)
+	tk.MustExec("set @@global.tidb_enable_dist_task = 1;")
+	tk.MustExec(fmt.Sprintf(`set @@global.tidb_cloud_storage_uri = "%s"`, cloudStorageURI))
+	defer func() {
+		tk.MustExec("set @@global.tidb_enable_dist_task = 0;")
+		variable.CloudStorageURI.Store("")
+	}()
+
+	tk.MustExec("create table t (a int primary key, b int, c int);")
+	tk.MustExec("insert into t values (1, 1, 1), (2, 2, 2), (3, 3, 3);")
+
+	// The read index step falls back to local sort instead of failing the job.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/ddl/mockGlobalSortStorageErr", "1*return()"))
+	defer func() {
+		require.NoError(t, failpoint.Disable("github.com/pingcap/tidb/pkg/ddl/mockGlobalSortStorageErr"))
+	}()
+	tk.MustExec("alter table t add index idx(b);")
+	tk.MustExec("admin check table t;")
+	tk.MustQuery("select b from t use index(idx) order by b;").Check(testkit.Rows("1", "2", "3"))
+
+	// The storage errors of the unique index are not taken as the duplicate entries.
+	require.NoError(t, failpoint.Enable("github.com/pingcap/tidb/pkg/ddl/mockGlobalSortStorageErr", "1*return()"))
+	tk.MustExec("insert into t values (4, 4, 1);")
+	tk.MustGetErrCode("alter table t add unique index idx1(c);", errno.ErrDupEntry)
+}
@@ -186,6 +186,8 @@ func TestBackfillOperatorPipeline(t *testing.T) {
 		endKey,
 		totalRowCount,
 		nil,
+		nil,
+		nil,
 	)
 	require.NoError(t, err)
 	err = pipeline.Execute()
@@ -259,6 +261,8 @@ func TestBackfillOperatorPipelineException(t *testing.T) {
 			endKey,
 			&atomic.Int64{},
 			nil,
+			nil,
+			nil,
 		)
 		require.NoError(t, err)
 		err = pipeline.Execute()